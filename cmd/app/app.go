@@ -17,6 +17,7 @@ import (
 	"gorm.io/gorm"
 
 	"invest-mate/internal/shared/config"
+	"invest-mate/pkg/handlers"
 	"invest-mate/pkg/logger"
 )
 
@@ -238,6 +239,8 @@ func (app *App) setupServer() {
 		WriteTimeout: 30 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
+
+	app.Server.RegisterOnShutdown(handlers.CloseStreams)
 }
 
 // Регистрация маршрутов приложения
@@ -5,16 +5,24 @@ import (
 	"os"
 	"strings"
 
+	"invest-mate/internal/alerts"
 	"invest-mate/internal/assets"
+	"invest-mate/internal/marketdata"
+	"invest-mate/internal/notifications"
 	"invest-mate/internal/portfolios"
 	"invest-mate/internal/users"
+	"invest-mate/internal/watchlists"
 	"invest-mate/pkg/logger"
 )
 
 const (
-	ModuleAssets     = "assets"
-	ModuleUsers      = "users"
-	ModulePortfolios = "portfolios"
+	ModuleAssets        = "assets"
+	ModuleUsers         = "users"
+	ModulePortfolios    = "portfolios"
+	ModuleMarketData    = "marketdata"
+	ModuleNotifications = "notifications"
+	ModuleAlerts        = "alerts"
+	ModuleWatchlists    = "watchlists"
 
 	// Префикс модуля
 	ConfigEnablePrefix = "ENABLE_MODULE_"
@@ -26,6 +34,10 @@ var availableModules = []string{
 	ModuleAssets,
 	ModuleUsers,
 	ModulePortfolios,
+	ModuleMarketData,
+	ModuleNotifications,
+	ModuleAlerts,
+	ModuleWatchlists,
 }
 
 // Конфигурация модуля
@@ -62,6 +74,14 @@ func (app *App) registerModuleFromConfig(config ModuleConfig) {
 		module = &users.ModuleWrapper{}
 	case ModulePortfolios:
 		module = &portfolios.ModuleWrapper{}
+	case ModuleMarketData:
+		module = &marketdata.ModuleWrapper{}
+	case ModuleNotifications:
+		module = &notifications.ModuleWrapper{}
+	case ModuleAlerts:
+		module = &alerts.ModuleWrapper{}
+	case ModuleWatchlists:
+		module = &watchlists.ModuleWrapper{}
 	default:
 		logger.ErrorLog("Unknown module type: %s", config.Name)
 		return
@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"invest-mate/internal/alerts/models"
+	"invest-mate/internal/alerts/models/domain"
+	"invest-mate/internal/alerts/services"
+	sharedModels "invest-mate/internal/shared/models"
+	"invest-mate/pkg/handlers"
+	middleware "invest-mate/pkg/middlewares"
+)
+
+type AlertsHandler struct {
+	alertsService services.AlertsService
+}
+
+// Создание нового хендлера
+func NewAlertsHandler(alertsService services.AlertsService) *AlertsHandler {
+	return &AlertsHandler{
+		alertsService: alertsService,
+	}
+}
+
+// Регистрация маршрутов
+func (h *AlertsHandler) RegisterRoutes(router *gin.RouterGroup) {
+	alerts := router.Group("/alerts")
+	alerts.Use(middleware.AuthMiddleware())
+	{
+		alerts.GET("", h.GetAlerts)
+		alerts.POST("", h.CreateAlert)
+		alerts.GET("/:id", h.GetAlert)
+		alerts.PUT("/:id", h.UpdateAlert)
+		alerts.DELETE("/:id", h.DeleteAlert)
+		alerts.GET("/:id/triggers", h.GetTriggers)
+	}
+}
+
+// Обработчик создания оповещения
+func (h *AlertsHandler) CreateAlert(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.CreateAlertRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	alert, err := h.alertsService.CreateAlert(c.Request.Context(), userID, getUserRole(c), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, handlers.BuildResponse(alert))
+}
+
+// Обработчик получения списка оповещений
+func (h *AlertsHandler) GetAlerts(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	page, limit := handlers.ParsePaginationParams(c)
+
+	alerts, total, err := h.alertsService.GetAlerts(c.Request.Context(), userID, page, limit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildListResponse(alerts, total, page, limit))
+}
+
+// Обработчик получения оповещения по идентификатору
+func (h *AlertsHandler) GetAlert(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	alert, err := h.alertsService.GetAlert(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(alert))
+}
+
+// Обработчик изменения оповещения
+func (h *AlertsHandler) UpdateAlert(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.UpdateAlertRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	alert, err := h.alertsService.UpdateAlert(c.Request.Context(), userID, c.Param("id"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(alert))
+}
+
+// Обработчик удаления оповещения
+func (h *AlertsHandler) DeleteAlert(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.alertsService.DeleteAlert(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(result))
+}
+
+// Обработчик получения журнала срабатываний оповещения
+func (h *AlertsHandler) GetTriggers(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	page, limit := handlers.ParsePaginationParams(c)
+
+	triggers, total, err := h.alertsService.GetTriggers(c.Request.Context(), userID, c.Param("id"), page, limit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildListResponse(triggers, total, page, limit))
+}
+
+// Получение роли пользователя из контекста
+func getUserRole(c *gin.Context) sharedModels.UserRole {
+	return sharedModels.UserRole(c.GetString("role"))
+}
+
+// Формирование ответа с ошибкой
+func respondError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, models.ErrAlertNotFound),
+		errors.Is(err, models.ErrInstrumentNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, models.ErrAlertAccessDenied),
+		errors.Is(err, models.ErrAlertLimitReached):
+		status = http.StatusForbidden
+	case errors.Is(err, models.ErrInvalidRequest):
+		status = http.StatusBadRequest
+	}
+
+	c.JSON(status, gin.H{"error": err.Error()})
+}
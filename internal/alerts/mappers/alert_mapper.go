@@ -0,0 +1,94 @@
+package mappers
+
+import (
+	"invest-mate/internal/alerts/models"
+	"invest-mate/internal/alerts/models/domain"
+	"invest-mate/internal/alerts/models/entity"
+	sharedModels "invest-mate/internal/shared/models"
+)
+
+func FromAlertEntityToDomain(entity entity.Alert) *domain.Alert {
+	return &domain.Alert{
+		ID:             entity.ID,
+		UserID:         entity.UserID,
+		InstrumentUid:  entity.InstrumentUid,
+		Ticker:         entity.Ticker,
+		InstrumentType: sharedModels.InstrumentType(entity.InstrumentType),
+		Type:           models.AlertType(entity.Type),
+		Threshold:      entity.Threshold,
+		PeriodDays:     entity.PeriodDays,
+		Comment:        entity.Comment,
+		IsActive:       entity.IsActive,
+		LastValue:      entity.LastValue,
+		LastCheckedAt:  entity.LastCheckedAt,
+		TriggeredAt:    entity.TriggeredAt,
+		TriggerCount:   entity.TriggerCount,
+		CreatedAt:      entity.CreatedAt,
+		UpdatedAt:      entity.UpdatedAt,
+	}
+}
+
+func FromAlertEntityToDomainSlice(entitySlice []entity.Alert) []*domain.Alert {
+	domainSlice := make([]*domain.Alert, len(entitySlice))
+
+	for index, entity := range entitySlice {
+		domainSlice[index] = FromAlertEntityToDomain(entity)
+	}
+
+	return domainSlice
+}
+
+func FromAlertDomainToEntity(domain *domain.Alert) entity.Alert {
+	return entity.Alert{
+		ID:             domain.ID,
+		UserID:         domain.UserID,
+		InstrumentUid:  domain.InstrumentUid,
+		Ticker:         domain.Ticker,
+		InstrumentType: string(domain.InstrumentType),
+		Type:           string(domain.Type),
+		Threshold:      domain.Threshold,
+		PeriodDays:     domain.PeriodDays,
+		Comment:        domain.Comment,
+		IsActive:       domain.IsActive,
+		LastValue:      domain.LastValue,
+		LastCheckedAt:  domain.LastCheckedAt,
+		TriggeredAt:    domain.TriggeredAt,
+		TriggerCount:   domain.TriggerCount,
+		CreatedAt:      domain.CreatedAt,
+		UpdatedAt:      domain.UpdatedAt,
+	}
+}
+
+func FromTriggerEntityToDomain(entity entity.AlertTrigger) *domain.AlertTrigger {
+	return &domain.AlertTrigger{
+		ID:          entity.ID,
+		AlertID:     entity.AlertID,
+		UserID:      entity.UserID,
+		Type:        models.AlertType(entity.Type),
+		Threshold:   entity.Threshold,
+		Value:       entity.Value,
+		TriggeredAt: entity.TriggeredAt,
+	}
+}
+
+func FromTriggerEntityToDomainSlice(entitySlice []entity.AlertTrigger) []*domain.AlertTrigger {
+	domainSlice := make([]*domain.AlertTrigger, len(entitySlice))
+
+	for index, entity := range entitySlice {
+		domainSlice[index] = FromTriggerEntityToDomain(entity)
+	}
+
+	return domainSlice
+}
+
+func FromTriggerDomainToEntity(domain *domain.AlertTrigger) entity.AlertTrigger {
+	return entity.AlertTrigger{
+		ID:          domain.ID,
+		AlertID:     domain.AlertID,
+		UserID:      domain.UserID,
+		Type:        string(domain.Type),
+		Threshold:   domain.Threshold,
+		Value:       domain.Value,
+		TriggeredAt: domain.TriggeredAt,
+	}
+}
@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+
+	"invest-mate/internal/alerts/models/entity"
+)
+
+type AlertsMigrator struct{}
+
+func NewAlertsMigrator() *AlertsMigrator {
+	return &AlertsMigrator{}
+}
+
+func (m *AlertsMigrator) Migrate(db *gorm.DB) error {
+	return db.AutoMigrate(
+		&entity.Alert{},
+		&entity.AlertTrigger{},
+	)
+}
@@ -0,0 +1,64 @@
+package models
+
+import sharedModels "invest-mate/internal/shared/models"
+
+// Условие оповещения
+type AlertType string
+
+const (
+	// Цена не ниже порога (для облигаций — в % номинала)
+	AlertTypePriceAbove AlertType = "PRICE_ABOVE"
+	// Цена не выше порога
+	AlertTypePriceBelow AlertType = "PRICE_BELOW"
+	// Изменение цены за periodDays дней в процентах: рост не меньше положительного порога
+	// или падение не меньше отрицательного
+	AlertTypePriceChange AlertType = "PRICE_CHANGE"
+	// Доходность облигации к погашению не ниже порога
+	AlertTypeYieldAbove AlertType = "YIELD_ABOVE"
+	// Доходность облигации к погашению не выше порога
+	AlertTypeYieldBelow AlertType = "YIELD_BELOW"
+)
+
+// Проверка условия на валидность
+func (t AlertType) IsValid() bool {
+	switch t {
+	case AlertTypePriceAbove, AlertTypePriceBelow, AlertTypePriceChange, AlertTypeYieldAbove, AlertTypeYieldBelow:
+		return true
+	default:
+		return false
+	}
+}
+
+// Условие по доходности облигации
+func (t AlertType) IsYield() bool {
+	return t == AlertTypeYieldAbove || t == AlertTypeYieldBelow
+}
+
+// Выполнение условия для значения
+func (t AlertType) IsMet(value, threshold float64) bool {
+	switch t {
+	case AlertTypePriceAbove, AlertTypeYieldAbove:
+		return value >= threshold
+	case AlertTypePriceBelow, AlertTypeYieldBelow:
+		return value <= threshold
+	case AlertTypePriceChange:
+		if threshold >= 0 {
+			return value >= threshold
+		}
+		return value <= threshold
+	default:
+		return false
+	}
+}
+
+// Наибольшее число оповещений пользователя по роли (0 — без ограничения)
+func AlertLimit(role sharedModels.UserRole) int {
+	switch role {
+	case sharedModels.Admin:
+		return 0
+	case sharedModels.Subscriber:
+		return 100
+	default:
+		return 10
+	}
+}
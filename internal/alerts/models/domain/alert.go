@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"time"
+
+	"invest-mate/internal/alerts/models"
+	sharedModels "invest-mate/internal/shared/models"
+)
+
+// Оповещение пользователя по инструменту.
+// После срабатывания оповещение выключается, повторно его включают через isActive
+type Alert struct {
+	ID             string                      `json:"id"`
+	UserID         string                      `json:"userId"`
+	InstrumentUid  string                      `json:"instrumentUid"`
+	Ticker         string                      `json:"ticker"`
+	InstrumentType sharedModels.InstrumentType `json:"instrumentType"`
+	Type           models.AlertType            `json:"type"`
+	Threshold      float64                     `json:"threshold"`
+	PeriodDays     int                         `json:"periodDays,omitempty"`
+	Comment        string                      `json:"comment"`
+	IsActive       bool                        `json:"isActive"`
+	LastValue      *float64                    `json:"lastValue,omitempty"`
+	LastCheckedAt  *time.Time                  `json:"lastCheckedAt,omitempty"`
+	TriggeredAt    *time.Time                  `json:"triggeredAt,omitempty"`
+	TriggerCount   int                         `json:"triggerCount"`
+	CreatedAt      time.Time                   `json:"createdAt"`
+	UpdatedAt      time.Time                   `json:"updatedAt"`
+}
+
+// Срабатывание оповещения
+type AlertTrigger struct {
+	ID          string           `json:"id"`
+	AlertID     string           `json:"alertId"`
+	UserID      string           `json:"userId"`
+	Type        models.AlertType `json:"type"`
+	Threshold   float64          `json:"threshold"`
+	Value       float64          `json:"value"`
+	TriggeredAt time.Time        `json:"triggeredAt"`
+}
+
+type CreateAlertRequest struct {
+	InstrumentUid string           `json:"instrumentUid"`
+	Figi          string           `json:"figi"`
+	Ticker        string           `json:"ticker"`
+	Type          models.AlertType `json:"type"`
+	Threshold     float64          `json:"threshold"`
+	PeriodDays    int              `json:"periodDays"`
+	Comment       string           `json:"comment"`
+}
+
+type UpdateAlertRequest struct {
+	Threshold  *float64 `json:"threshold"`
+	PeriodDays *int     `json:"periodDays"`
+	Comment    *string  `json:"comment"`
+	IsActive   *bool    `json:"isActive"`
+}
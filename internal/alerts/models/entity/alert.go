@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"time"
+)
+
+type Alert struct {
+	ID             string  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	UserID         string  `gorm:"not null;index"`
+	InstrumentUid  string  `gorm:"type:text;not null"`
+	Ticker         string  `gorm:"type:text"`
+	InstrumentType string  `gorm:"size:32"`
+	Type           string  `gorm:"size:32;not null"`
+	Threshold      float64 `gorm:"type:double precision;not null"`
+	PeriodDays     int     `gorm:"not null;default:0"`
+	Comment        string  `gorm:"size:255"`
+	IsActive       bool    `gorm:"not null;default:true;index"`
+	LastValue      *float64
+	LastCheckedAt  *time.Time
+	TriggeredAt    *time.Time
+	TriggerCount   int       `gorm:"not null;default:0"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+}
+
+// Журнал срабатываний оповещений
+type AlertTrigger struct {
+	ID          string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	AlertID     string    `gorm:"type:uuid;not null;index;constraint:OnDelete:CASCADE"`
+	UserID      string    `gorm:"not null;index"`
+	Type        string    `gorm:"size:32;not null"`
+	Threshold   float64   `gorm:"type:double precision;not null"`
+	Value       float64   `gorm:"type:double precision;not null"`
+	TriggeredAt time.Time `gorm:"not null"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import (
+	"errors"
+)
+
+var (
+	ErrAlertNotFound      = errors.New("Оповещение не найдено")
+	ErrAlertAccessDenied  = errors.New("Нет доступа к оповещению")
+	ErrAlertLimitReached  = errors.New("Достигнуто наибольшее число оповещений")
+	ErrInvalidRequest     = errors.New("Некорректный запрос")
+	ErrInstrumentNotFound = errors.New("Инструмент не найден")
+)
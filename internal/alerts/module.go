@@ -0,0 +1,62 @@
+package alerts
+
+import (
+	"gorm.io/gorm"
+
+	"invest-mate/internal/alerts/handlers"
+	"invest-mate/internal/alerts/migrations"
+	"invest-mate/internal/alerts/repository"
+	"invest-mate/internal/alerts/services"
+	assetsRepository "invest-mate/internal/assets/repository"
+	assetsServices "invest-mate/internal/assets/services"
+	"invest-mate/internal/assets/storage"
+	"invest-mate/internal/marketdata"
+	"invest-mate/internal/notifications"
+	notificationsServices "invest-mate/internal/notifications/services"
+	"invest-mate/internal/shared/config"
+)
+
+type Module struct {
+	alertsHandler  *handlers.AlertsHandler
+	alertEvaluator services.AlertEvaluator
+	alertScheduler *services.AlertScheduler
+	dispatcher     notificationsServices.Dispatcher
+}
+
+// Инициализация модуля
+func InitModule(db *gorm.DB, cfg *config.Config) (*Module, error) {
+	alertsMigrator := migrations.NewAlertsMigrator()
+	if err := alertsMigrator.Migrate(db); err != nil {
+		return nil, err
+	}
+
+	tinkoffStorage := storage.GetInstance(assetsRepository.NewAssetRepository(db))
+
+	alertsRepo := repository.NewAlertsRepository(db)
+	alertsService := services.NewAlertsService(alertsRepo, tinkoffStorage)
+	alertsHandler := handlers.NewAlertsHandler(alertsService)
+
+	candlesService, err := marketdata.GetCandlesService(db)
+	if err != nil {
+		return nil, err
+	}
+
+	bondAnalyticsService := assetsServices.NewBondAnalyticsService(tinkoffStorage)
+	alertEvaluator := services.NewAlertEvaluator(alertsRepo, tinkoffStorage, candlesService, bondAnalyticsService)
+
+	dispatcher, err := notifications.GetDispatcher(db, cfg)
+	if err != nil {
+		return nil, err
+	}
+	alertEvaluator.OnTrigger(services.NewTriggerNotifier(dispatcher))
+
+	alertScheduler := services.NewAlertScheduler(alertEvaluator, cfg.AlertCheckInterval)
+	alertScheduler.Start()
+
+	return &Module{
+		alertsHandler:  alertsHandler,
+		alertEvaluator: alertEvaluator,
+		alertScheduler: alertScheduler,
+		dispatcher:     dispatcher,
+	}, nil
+}
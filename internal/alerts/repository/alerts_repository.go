@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"invest-mate/internal/alerts/mappers"
+	"invest-mate/internal/alerts/models"
+	"invest-mate/internal/alerts/models/domain"
+	"invest-mate/internal/alerts/models/entity"
+)
+
+type AlertsRepository interface {
+	Create(ctx context.Context, alert *domain.Alert) error
+	FindByID(ctx context.Context, id string) (*domain.Alert, error)
+	GetListByUser(ctx context.Context, userID string, limit, offset int) ([]*domain.Alert, error)
+	CountByUser(ctx context.Context, userID string) (int64, error)
+	GetActive(ctx context.Context) ([]*domain.Alert, error)
+	Update(ctx context.Context, alert *domain.Alert) error
+	Delete(ctx context.Context, id string) (bool, error)
+	SaveTrigger(ctx context.Context, alert *domain.Alert, trigger *domain.AlertTrigger) error
+	GetTriggers(ctx context.Context, alertID string, limit, offset int) ([]*domain.AlertTrigger, error)
+	CountTriggers(ctx context.Context, alertID string) (int64, error)
+}
+
+type alertsRepository struct {
+	db *gorm.DB
+}
+
+// Создание нового репозитория оповещений
+func NewAlertsRepository(db *gorm.DB) AlertsRepository {
+	return &alertsRepository{db: db}
+}
+
+// Создание оповещения в БД
+func (r *alertsRepository) Create(ctx context.Context, alert *domain.Alert) error {
+	entityAlert := mappers.FromAlertDomainToEntity(alert)
+
+	if err := r.db.WithContext(ctx).Create(&entityAlert).Error; err != nil {
+		return err
+	}
+
+	alert.ID = entityAlert.ID
+	alert.CreatedAt = entityAlert.CreatedAt
+	alert.UpdatedAt = entityAlert.UpdatedAt
+
+	return nil
+}
+
+// Найти оповещение по идентификатору в БД
+func (r *alertsRepository) FindByID(ctx context.Context, id string) (*domain.Alert, error) {
+	var entityAlert entity.Alert
+
+	err := r.db.WithContext(ctx).First(&entityAlert, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.ErrAlertNotFound
+		}
+		return nil, err
+	}
+
+	return mappers.FromAlertEntityToDomain(entityAlert), nil
+}
+
+// Получить список оповещений пользователя в БД
+func (r *alertsRepository) GetListByUser(ctx context.Context, userID string, limit, offset int) ([]*domain.Alert, error) {
+	var entityAlerts []entity.Alert
+
+	query := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC")
+
+	if limit > 0 {
+		query = query.Limit(limit).Offset(offset)
+	}
+
+	if err := query.Find(&entityAlerts).Error; err != nil {
+		return nil, err
+	}
+
+	return mappers.FromAlertEntityToDomainSlice(entityAlerts), nil
+}
+
+// Подсчёт оповещений пользователя в БД
+func (r *alertsRepository) CountByUser(ctx context.Context, userID string) (int64, error) {
+	var count int64
+
+	if err := r.db.WithContext(ctx).Model(&entity.Alert{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// Получить включённые оповещения всех пользователей из БД
+func (r *alertsRepository) GetActive(ctx context.Context) ([]*domain.Alert, error) {
+	var entityAlerts []entity.Alert
+
+	if err := r.db.WithContext(ctx).Where("is_active = ?", true).Order("created_at").Find(&entityAlerts).Error; err != nil {
+		return nil, err
+	}
+
+	return mappers.FromAlertEntityToDomainSlice(entityAlerts), nil
+}
+
+// Обновить оповещение в БД
+func (r *alertsRepository) Update(ctx context.Context, alert *domain.Alert) error {
+	entityAlert := mappers.FromAlertDomainToEntity(alert)
+
+	if err := r.db.WithContext(ctx).Save(&entityAlert).Error; err != nil {
+		return err
+	}
+
+	alert.UpdatedAt = entityAlert.UpdatedAt
+
+	return nil
+}
+
+// Удаление оповещения вместе с журналом срабатываний из БД
+func (r *alertsRepository) Delete(ctx context.Context, id string) (bool, error) {
+	var deleted bool
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&entity.AlertTrigger{}, "alert_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		result := tx.Delete(&entity.Alert{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		deleted = result.RowsAffected > 0
+
+		return nil
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return deleted, nil
+}
+
+// Сохранение срабатывания вместе с изменённым оповещением в БД
+func (r *alertsRepository) SaveTrigger(ctx context.Context, alert *domain.Alert, trigger *domain.AlertTrigger) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		entityAlert := mappers.FromAlertDomainToEntity(alert)
+
+		if err := tx.Save(&entityAlert).Error; err != nil {
+			return err
+		}
+
+		entityTrigger := mappers.FromTriggerDomainToEntity(trigger)
+
+		if err := tx.Create(&entityTrigger).Error; err != nil {
+			return err
+		}
+
+		alert.UpdatedAt = entityAlert.UpdatedAt
+		trigger.ID = entityTrigger.ID
+
+		return nil
+	})
+}
+
+// Получить срабатывания оповещения, начиная с последних, из БД
+func (r *alertsRepository) GetTriggers(ctx context.Context, alertID string, limit, offset int) ([]*domain.AlertTrigger, error) {
+	var entityTriggers []entity.AlertTrigger
+
+	query := r.db.WithContext(ctx).
+		Where("alert_id = ?", alertID).
+		Order("triggered_at DESC")
+
+	if limit > 0 {
+		query = query.Limit(limit).Offset(offset)
+	}
+
+	if err := query.Find(&entityTriggers).Error; err != nil {
+		return nil, err
+	}
+
+	return mappers.FromTriggerEntityToDomainSlice(entityTriggers), nil
+}
+
+// Подсчёт срабатываний оповещения в БД
+func (r *alertsRepository) CountTriggers(ctx context.Context, alertID string) (int64, error) {
+	var count int64
+
+	if err := r.db.WithContext(ctx).Model(&entity.AlertTrigger{}).Where("alert_id = ?", alertID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"invest-mate/internal/alerts/models"
+	"invest-mate/internal/alerts/models/domain"
+	"invest-mate/internal/alerts/repository"
+	assetsDomain "invest-mate/internal/assets/models/domain"
+	mdModels "invest-mate/internal/marketdata/models"
+	mdDomain "invest-mate/internal/marketdata/models/domain"
+	"invest-mate/pkg/logger"
+)
+
+// Последние цены из кэша (реализуется хранилищем модуля активов)
+type PriceSource interface {
+	GetLastPrices(ctx context.Context, uids []string) (map[string]float64, error)
+}
+
+// Исторические свечи (реализуется сервисом свечей модуля рыночных данных)
+type CandleSource interface {
+	GetCandles(ctx context.Context, query *mdDomain.CandlesQuery) (*mdDomain.CandleSeries, error)
+}
+
+// Аналитика облигаций (реализуется сервисом модуля активов)
+type BondAnalyticsSource interface {
+	GetBondAnalytics(ctx context.Context, instrumentUid string, pricePercent *float64) (*assetsDomain.BondAnalytics, error)
+}
+
+// Обработчик срабатываний оповещений
+type TriggerHandler func(ctx context.Context, alert *domain.Alert, trigger *domain.AlertTrigger)
+
+type AlertEvaluator interface {
+	Evaluate(ctx context.Context) (int, error)
+	OnTrigger(handler TriggerHandler)
+}
+
+type alertEvaluator struct {
+	alertsRepo repository.AlertsRepository
+	prices     PriceSource
+	candles    CandleSource
+	bonds      BondAnalyticsSource
+
+	handlers []TriggerHandler
+}
+
+// Создание нового сервиса проверки оповещений
+func NewAlertEvaluator(
+	alertsRepo repository.AlertsRepository,
+	prices PriceSource,
+	candles CandleSource,
+	bonds BondAnalyticsSource,
+) AlertEvaluator {
+	return &alertEvaluator{
+		alertsRepo: alertsRepo,
+		prices:     prices,
+		candles:    candles,
+		bonds:      bonds,
+	}
+}
+
+// Регистрация обработчика срабатываний (вызывается после записи срабатывания в БД)
+func (e *alertEvaluator) OnTrigger(handler TriggerHandler) {
+	e.handlers = append(e.handlers, handler)
+}
+
+// Проверка всех активных оповещений по кэшу последних цен;
+// возвращает число сработавших оповещений
+func (e *alertEvaluator) Evaluate(ctx context.Context) (int, error) {
+	alerts, err := e.alertsRepo.GetActive(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(alerts) == 0 {
+		return 0, nil
+	}
+
+	uids := make([]string, 0, len(alerts))
+	for _, alert := range alerts {
+		uids = append(uids, alert.InstrumentUid)
+	}
+
+	lastPrices, err := e.prices.GetLastPrices(ctx, uids)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load last prices: %w", err)
+	}
+
+	run := &evaluationRun{
+		lastPrices: lastPrices,
+		basePrices: make(map[string]float64),
+		yields:     make(map[string]float64),
+		now:        time.Now(),
+	}
+
+	triggered := 0
+	for _, alert := range alerts {
+		if err := ctx.Err(); err != nil {
+			return triggered, err
+		}
+
+		value, err := e.currentValue(ctx, run, alert)
+		if err != nil {
+			logger.InfoLog("Alert %s skipped: %v", alert.ID, err)
+			continue
+		}
+
+		fired, err := e.apply(ctx, run.now, alert, value)
+		if err != nil {
+			logger.ErrorLog("Failed to save alert %s: %v", alert.ID, err)
+			continue
+		}
+
+		if fired {
+			triggered++
+		}
+	}
+
+	if triggered > 0 {
+		logger.InfoLog("Alerts evaluated: %d checked, %d triggered", len(alerts), triggered)
+	}
+
+	return triggered, nil
+}
+
+// Данные одной проверки, общие для оповещений по одному инструменту
+type evaluationRun struct {
+	lastPrices map[string]float64
+	// Цены закрытия на начало периода по ключу uid/periodDays
+	basePrices map[string]float64
+	// Доходности к погашению по uid
+	yields map[string]float64
+	now    time.Time
+}
+
+// Текущее значение, с которым сравнивается порог оповещения
+func (e *alertEvaluator) currentValue(ctx context.Context, run *evaluationRun, alert *domain.Alert) (float64, error) {
+	price, ok := run.lastPrices[alert.InstrumentUid]
+	if !ok || price <= 0 {
+		return 0, errors.New("no last price")
+	}
+
+	switch alert.Type {
+	case models.AlertTypePriceAbove, models.AlertTypePriceBelow:
+		return price, nil
+	case models.AlertTypePriceChange:
+		base, err := e.basePrice(ctx, run, alert.InstrumentUid, alert.PeriodDays)
+		if err != nil {
+			return 0, err
+		}
+		return (price - base) / base * 100, nil
+	case models.AlertTypeYieldAbove, models.AlertTypeYieldBelow:
+		return e.yieldToMaturity(ctx, run, alert.InstrumentUid, price)
+	default:
+		return 0, fmt.Errorf("unknown alert type %s", alert.Type)
+	}
+}
+
+// Цена закрытия последней дневной свечи не позже начала периода
+func (e *alertEvaluator) basePrice(ctx context.Context, run *evaluationRun, uid string, periodDays int) (float64, error) {
+	key := fmt.Sprintf("%s/%d", uid, periodDays)
+	if base, ok := run.basePrices[key]; ok {
+		return base, nil
+	}
+
+	to := run.now.AddDate(0, 0, -periodDays)
+	// Запас на выходные и праздники
+	from := to.AddDate(0, 0, -10)
+
+	series, err := e.candles.GetCandles(ctx, &mdDomain.CandlesQuery{
+		InstrumentUid: uid,
+		Interval:      mdModels.CandleIntervalDay,
+		From:          &from,
+		To:            &to,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to load candles: %w", err)
+	}
+
+	for i := len(series.Candles) - 1; i >= 0; i-- {
+		if closePrice := series.Candles[i].Close; closePrice > 0 {
+			run.basePrices[key] = closePrice
+			return closePrice, nil
+		}
+	}
+
+	return 0, errors.New("no candles for the start of the period")
+}
+
+// Доходность облигации к погашению по последней цене
+func (e *alertEvaluator) yieldToMaturity(ctx context.Context, run *evaluationRun, uid string, price float64) (float64, error) {
+	if ytm, ok := run.yields[uid]; ok {
+		return ytm, nil
+	}
+
+	analytics, err := e.bonds.GetBondAnalytics(ctx, uid, &price)
+	if err != nil {
+		return 0, fmt.Errorf("failed to calculate bond analytics: %w", err)
+	}
+
+	if analytics.YieldToMaturity == nil {
+		return 0, errors.New("yield to maturity is not available")
+	}
+
+	run.yields[uid] = *analytics.YieldToMaturity
+
+	return *analytics.YieldToMaturity, nil
+}
+
+// Сохранение результата проверки; при выполнении условия оповещение
+// выключается и записывается срабатывание
+func (e *alertEvaluator) apply(ctx context.Context, now time.Time, alert *domain.Alert, value float64) (bool, error) {
+	alert.LastValue = &value
+	alert.LastCheckedAt = &now
+
+	if !alert.Type.IsMet(value, alert.Threshold) {
+		return false, e.alertsRepo.Update(ctx, alert)
+	}
+
+	alert.IsActive = false
+	alert.TriggeredAt = &now
+	alert.TriggerCount++
+
+	trigger := &domain.AlertTrigger{
+		ID:          uuid.New().String(),
+		AlertID:     alert.ID,
+		UserID:      alert.UserID,
+		Type:        alert.Type,
+		Threshold:   alert.Threshold,
+		Value:       value,
+		TriggeredAt: now,
+	}
+
+	if err := e.alertsRepo.SaveTrigger(ctx, alert, trigger); err != nil {
+		return false, err
+	}
+
+	logger.InfoLog("Alert triggered: %s (%s %s, value %.4f, threshold %.4f)",
+		alert.ID, alert.Ticker, alert.Type, value, alert.Threshold)
+
+	for _, handler := range e.handlers {
+		handler(ctx, alert, trigger)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"invest-mate/internal/alerts/models"
+	"invest-mate/internal/alerts/models/domain"
+	notificationsModels "invest-mate/internal/notifications/models"
+	notificationsDomain "invest-mate/internal/notifications/models/domain"
+	"invest-mate/pkg/logger"
+)
+
+// Рассылка уведомлений (реализуется диспетчером модуля уведомлений)
+type Notifier interface {
+	Notify(ctx context.Context, notification *notificationsDomain.Notification) ([]*notificationsDomain.Delivery, error)
+}
+
+// Данные срабатывания в уведомлении
+type triggerNotificationData struct {
+	Alert   *domain.Alert        `json:"alert"`
+	Trigger *domain.AlertTrigger `json:"trigger"`
+}
+
+// Обработчик срабатываний, отправляющий уведомление владельцу оповещения
+func NewTriggerNotifier(notifier Notifier) TriggerHandler {
+	return func(ctx context.Context, alert *domain.Alert, trigger *domain.AlertTrigger) {
+		_, err := notifier.Notify(ctx, &notificationsDomain.Notification{
+			UserID:  alert.UserID,
+			Event:   notificationsModels.EventAlertTriggered,
+			Title:   fmt.Sprintf("Invest Mate: сработало оповещение по %s", alert.Ticker),
+			Message: triggerMessage(alert, trigger),
+			Data:    triggerNotificationData{Alert: alert, Trigger: trigger},
+		})
+		if err != nil {
+			logger.ErrorLog("Failed to notify about alert %s: %v", alert.ID, err)
+		}
+	}
+}
+
+// Текст уведомления о срабатывании
+func triggerMessage(alert *domain.Alert, trigger *domain.AlertTrigger) string {
+	var text string
+
+	switch alert.Type {
+	case models.AlertTypePriceAbove:
+		text = fmt.Sprintf("Цена %s достигла %s (порог — не ниже %s).",
+			alert.Ticker, formatNumber(trigger.Value), formatNumber(trigger.Threshold))
+	case models.AlertTypePriceBelow:
+		text = fmt.Sprintf("Цена %s опустилась до %s (порог — не выше %s).",
+			alert.Ticker, formatNumber(trigger.Value), formatNumber(trigger.Threshold))
+	case models.AlertTypePriceChange:
+		text = fmt.Sprintf("Цена %s изменилась на %+.2f%% за %d дн. (порог %+.2f%%).",
+			alert.Ticker, trigger.Value, alert.PeriodDays, trigger.Threshold)
+	case models.AlertTypeYieldAbove:
+		text = fmt.Sprintf("Доходность к погашению %s — %.2f%% (порог — не ниже %.2f%%).",
+			alert.Ticker, trigger.Value, trigger.Threshold)
+	case models.AlertTypeYieldBelow:
+		text = fmt.Sprintf("Доходность к погашению %s — %.2f%% (порог — не выше %.2f%%).",
+			alert.Ticker, trigger.Value, trigger.Threshold)
+	default:
+		text = fmt.Sprintf("Оповещение %s по %s: значение %s.", alert.Type, alert.Ticker, formatNumber(trigger.Value))
+	}
+
+	if alert.Comment != "" {
+		text += "\n" + alert.Comment
+	}
+
+	return text
+}
+
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,24 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"invest-mate/pkg/scheduler"
+)
+
+// Периодическая проверка активных оповещений
+type AlertScheduler struct {
+	*scheduler.Runner
+}
+
+// Создание планировщика проверки оповещений с интервалом запуска
+// (первая проверка — сразу после старта)
+func NewAlertScheduler(evaluator AlertEvaluator, interval time.Duration) *AlertScheduler {
+	return &AlertScheduler{
+		Runner: scheduler.NewRunner("Alert", interval, func(ctx context.Context) error {
+			_, err := evaluator.Evaluate(ctx)
+			return err
+		}),
+	}
+}
@@ -0,0 +1,246 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"invest-mate/internal/alerts/models"
+	"invest-mate/internal/alerts/models/domain"
+	"invest-mate/internal/alerts/repository"
+	assetsDomain "invest-mate/internal/assets/models/domain"
+	sharedInstruments "invest-mate/internal/shared/instruments"
+	sharedModels "invest-mate/internal/shared/models"
+	"invest-mate/pkg/logger"
+	"invest-mate/pkg/services"
+)
+
+const (
+	maxAlertPeriodDays    = 365
+	maxAlertCommentLength = 255
+)
+
+// Поиск инструмента (реализуется хранилищем модуля активов)
+type InstrumentResolver interface {
+	FindInstrument(ctx context.Context, fieldName string, fieldValue string) (*assetsDomain.Instrument, error)
+}
+
+type AlertsService interface {
+	CreateAlert(ctx context.Context, userID string, role sharedModels.UserRole, req *domain.CreateAlertRequest) (*domain.Alert, error)
+	GetAlerts(ctx context.Context, userID string, page, limit int) ([]*domain.Alert, int64, error)
+	GetAlert(ctx context.Context, userID, id string) (*domain.Alert, error)
+	UpdateAlert(ctx context.Context, userID, id string, req *domain.UpdateAlertRequest) (*domain.Alert, error)
+	DeleteAlert(ctx context.Context, userID, id string) (bool, error)
+	GetTriggers(ctx context.Context, userID, id string, page, limit int) ([]*domain.AlertTrigger, int64, error)
+}
+
+type alertsService struct {
+	alertsRepo  repository.AlertsRepository
+	instruments InstrumentResolver
+}
+
+// Создание нового сервиса оповещений
+func NewAlertsService(alertsRepo repository.AlertsRepository, instruments InstrumentResolver) AlertsService {
+	return &alertsService{
+		alertsRepo:  alertsRepo,
+		instruments: instruments,
+	}
+}
+
+// Создание оповещения с проверкой ограничения по роли пользователя
+func (s *alertsService) CreateAlert(ctx context.Context, userID string, role sharedModels.UserRole, req *domain.CreateAlertRequest) (*domain.Alert, error) {
+	req.Type = models.AlertType(strings.ToUpper(string(req.Type)))
+
+	if err := validateAlert(req.Type, req.Threshold, req.PeriodDays, req.Comment); err != nil {
+		return nil, err
+	}
+
+	if limit := models.AlertLimit(role); limit > 0 {
+		count, err := s.alertsRepo.CountByUser(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		if count >= int64(limit) {
+			return nil, fmt.Errorf("%w: %d for role %s", models.ErrAlertLimitReached, limit, role)
+		}
+	}
+
+	instrument, err := sharedInstruments.Resolve(ctx, s.instruments, req.InstrumentUid, req.Figi, req.Ticker, models.ErrInvalidRequest, models.ErrInstrumentNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Type.IsYield() && instrument.InstrumentType != sharedModels.InstrumentTypeBond {
+		return nil, fmt.Errorf("%w: yield alerts are available for bonds only", models.ErrInvalidRequest)
+	}
+
+	alert := &domain.Alert{
+		ID:             uuid.New().String(),
+		UserID:         userID,
+		InstrumentUid:  instrument.Uid,
+		Ticker:         instrument.Ticker,
+		InstrumentType: instrument.InstrumentType,
+		Type:           req.Type,
+		Threshold:      req.Threshold,
+		Comment:        strings.TrimSpace(req.Comment),
+		IsActive:       true,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	if req.Type == models.AlertTypePriceChange {
+		alert.PeriodDays = req.PeriodDays
+	}
+
+	if err := s.alertsRepo.Create(ctx, alert); err != nil {
+		return nil, err
+	}
+
+	logger.InfoLog("Alert created: %s (user %s)", alert.ID, userID)
+
+	return alert, nil
+}
+
+// Получение списка оповещений пользователя
+func (s *alertsService) GetAlerts(ctx context.Context, userID string, page, limit int) ([]*domain.Alert, int64, error) {
+	limit, offset := services.PageOffset(page, limit)
+
+	alerts, err := s.alertsRepo.GetListByUser(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.alertsRepo.CountByUser(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return alerts, total, nil
+}
+
+// Получение оповещения пользователя по идентификатору
+func (s *alertsService) GetAlert(ctx context.Context, userID, id string) (*domain.Alert, error) {
+	return s.getOwnedAlert(ctx, userID, id)
+}
+
+// Изменение порога, периода, комментария или включение оповещения
+func (s *alertsService) UpdateAlert(ctx context.Context, userID, id string, req *domain.UpdateAlertRequest) (*domain.Alert, error) {
+	alert, err := s.getOwnedAlert(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Threshold != nil {
+		alert.Threshold = *req.Threshold
+	}
+	if req.PeriodDays != nil && alert.Type == models.AlertTypePriceChange {
+		alert.PeriodDays = *req.PeriodDays
+	}
+	if req.Comment != nil {
+		alert.Comment = strings.TrimSpace(*req.Comment)
+	}
+	if req.IsActive != nil {
+		alert.IsActive = *req.IsActive
+	}
+
+	if err := validateAlert(alert.Type, alert.Threshold, alert.PeriodDays, alert.Comment); err != nil {
+		return nil, err
+	}
+
+	alert.UpdatedAt = time.Now()
+
+	if err := s.alertsRepo.Update(ctx, alert); err != nil {
+		return nil, err
+	}
+
+	return alert, nil
+}
+
+// Удаление оповещения
+func (s *alertsService) DeleteAlert(ctx context.Context, userID, id string) (bool, error) {
+	if _, err := s.getOwnedAlert(ctx, userID, id); err != nil {
+		return false, err
+	}
+
+	deleted, err := s.alertsRepo.Delete(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	if deleted {
+		logger.InfoLog("Alert deleted: %s (user %s)", id, userID)
+	}
+
+	return deleted, nil
+}
+
+// Получение журнала срабатываний оповещения
+func (s *alertsService) GetTriggers(ctx context.Context, userID, id string, page, limit int) ([]*domain.AlertTrigger, int64, error) {
+	if _, err := s.getOwnedAlert(ctx, userID, id); err != nil {
+		return nil, 0, err
+	}
+
+	limit, offset := services.PageOffset(page, limit)
+
+	triggers, err := s.alertsRepo.GetTriggers(ctx, id, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.alertsRepo.CountTriggers(ctx, id)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return triggers, total, nil
+}
+
+// Получение оповещения с проверкой владельца
+func (s *alertsService) getOwnedAlert(ctx context.Context, userID, id string) (*domain.Alert, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, models.ErrAlertNotFound
+	}
+
+	alert, err := s.alertsRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if alert.UserID != userID {
+		return nil, models.ErrAlertAccessDenied
+	}
+
+	return alert, nil
+}
+
+// Проверка условия оповещения
+func validateAlert(alertType models.AlertType, threshold float64, periodDays int, comment string) error {
+	if !alertType.IsValid() {
+		return fmt.Errorf("%w: type must be PRICE_ABOVE, PRICE_BELOW, PRICE_CHANGE, YIELD_ABOVE or YIELD_BELOW", models.ErrInvalidRequest)
+	}
+
+	switch alertType {
+	case models.AlertTypePriceAbove, models.AlertTypePriceBelow:
+		if threshold <= 0 {
+			return fmt.Errorf("%w: threshold must be positive", models.ErrInvalidRequest)
+		}
+	case models.AlertTypePriceChange:
+		if threshold == 0 || threshold <= -100 {
+			return fmt.Errorf("%w: threshold must be a non-zero percent greater than -100", models.ErrInvalidRequest)
+		}
+		if periodDays < 1 || periodDays > maxAlertPeriodDays {
+			return fmt.Errorf("%w: periodDays must be from 1 to %d", models.ErrInvalidRequest, maxAlertPeriodDays)
+		}
+	}
+
+	if utf8.RuneCountInString(comment) > maxAlertCommentLength {
+		return fmt.Errorf("%w: comment must be at most %d characters", models.ErrInvalidRequest, maxAlertCommentLength)
+	}
+
+	return nil
+}
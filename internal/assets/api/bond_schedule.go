@@ -0,0 +1,105 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"invest-mate/internal/assets/mappers/schedule"
+	"invest-mate/internal/assets/models/domain"
+	"invest-mate/internal/assets/models/dto"
+	"invest-mate/internal/shared/api"
+	"invest-mate/pkg/logger"
+)
+
+const (
+	getBondCouponsEndpoint = "tinkoff.public.invest.api.contract.v1.InstrumentsService/GetBondCoupons"
+	getBondEventsEndpoint  = "tinkoff.public.invest.api.contract.v1.InstrumentsService/GetBondEvents"
+
+	// Купоны приходят отдельным запросом
+	couponEventType = "EVENT_TYPE_CPN"
+)
+
+// Получение графика купонов облигации за период
+func GetBondCoupons(ctx context.Context, instrumentUid string, from, to time.Time) ([]domain.BondCoupon, error) {
+	body := map[string]string{
+		"instrumentId": instrumentUid,
+		"from":         from.UTC().Format(time.RFC3339),
+		"to":           to.UTC().Format(time.RFC3339),
+	}
+
+	var response dto.GetBondCouponsResponse
+
+	if err := postContract(ctx, getBondCouponsEndpoint, body, &response); err != nil {
+		return nil, err
+	}
+
+	logger.InfoLog("Successfully parsed %d coupons of %s from %s", len(response.Events), instrumentUid, getBondCouponsEndpoint)
+
+	return schedule.FromCouponDtoToDomainSlice(instrumentUid, response.Events), nil
+}
+
+// Получение событий облигации (оферты, погашения, амортизации, конвертации) за период
+func GetBondEvents(ctx context.Context, instrumentUid string, from, to time.Time) ([]domain.BondEvent, error) {
+	body := map[string]string{
+		"instrumentId": instrumentUid,
+		"from":         from.UTC().Format(time.RFC3339),
+		"to":           to.UTC().Format(time.RFC3339),
+		"type":         "EVENT_TYPE_UNSPECIFIED",
+	}
+
+	var response dto.GetBondEventsResponse
+
+	if err := postContract(ctx, getBondEventsEndpoint, body, &response); err != nil {
+		return nil, err
+	}
+
+	events := make([]domain.BondEvent, 0, len(response.Events))
+	for _, event := range response.Events {
+		if event.EventType == couponEventType {
+			continue
+		}
+		events = append(events, schedule.FromEventDtoToDomain(instrumentUid, event))
+	}
+
+	logger.InfoLog("Successfully parsed %d events of %s from %s", len(events), instrumentUid, getBondEventsEndpoint)
+
+	return events, nil
+}
+
+// Запрос к методу API с разбором ответа
+func postContract(ctx context.Context, endpoint string, body any, response any) error {
+	client := api.NewTinkoffClient()
+
+	resp, err := client.DoRequest(ctx, "POST", endpoint, body)
+
+	if err != nil {
+		return fmt.Errorf("request %s: %w", endpoint, err)
+	}
+
+	defer resp.Body.Close()
+
+	logger.InfoLog("%s API response status: %d", endpoint, resp.StatusCode)
+
+	if resp.StatusCode != http.StatusOK {
+		return client.HandleAPIError(resp, endpoint)
+	}
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+
+	if err != nil {
+		return fmt.Errorf("read response body: %w", err)
+	}
+
+	if err := json.Unmarshal(bodyBytes, response); err != nil {
+		logger.ErrorLog("Failed to decode JSON for %s. Body start: %s",
+			endpoint, string(bodyBytes[:min(500, len(bodyBytes))]))
+
+		return fmt.Errorf("decode DTO response for %s: %w", endpoint, err)
+	}
+
+	return nil
+}
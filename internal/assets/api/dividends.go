@@ -0,0 +1,32 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"invest-mate/internal/assets/mappers/schedule"
+	"invest-mate/internal/assets/models/domain"
+	"invest-mate/internal/assets/models/dto"
+	"invest-mate/pkg/logger"
+)
+
+const getDividendsEndpoint = "tinkoff.public.invest.api.contract.v1.InstrumentsService/GetDividends"
+
+// Получение дивидендов акции или фонда за период
+func GetDividends(ctx context.Context, instrumentUid string, from, to time.Time) ([]domain.Dividend, error) {
+	body := map[string]string{
+		"instrumentId": instrumentUid,
+		"from":         from.UTC().Format(time.RFC3339),
+		"to":           to.UTC().Format(time.RFC3339),
+	}
+
+	var response dto.GetDividendsResponse
+
+	if err := postContract(ctx, getDividendsEndpoint, body, &response); err != nil {
+		return nil, err
+	}
+
+	logger.InfoLog("Successfully parsed %d dividends of %s from %s", len(response.Dividends), instrumentUid, getDividendsEndpoint)
+
+	return schedule.FromDividendDtoToDomainSlice(instrumentUid, response.Dividends), nil
+}
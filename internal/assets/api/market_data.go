@@ -0,0 +1,63 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"invest-mate/internal/assets/mappers/prices"
+	"invest-mate/internal/assets/models/domain"
+	"invest-mate/internal/assets/models/dto"
+	"invest-mate/internal/shared/api"
+	"invest-mate/pkg/logger"
+)
+
+const getLastPricesEndpoint = "tinkoff.public.invest.api.contract.v1.MarketDataService/GetLastPrices"
+
+// Получение последних цен инструментов по uid
+func GetLastPrices(ctx context.Context, instrumentIds []string) ([]domain.LastPrice, error) {
+	if len(instrumentIds) == 0 {
+		return []domain.LastPrice{}, nil
+	}
+
+	client := api.NewTinkoffClient()
+
+	body := map[string][]string{
+		"instrumentId": instrumentIds,
+	}
+
+	resp, err := client.DoRequest(ctx, "POST", getLastPricesEndpoint, body)
+
+	if err != nil {
+		return nil, fmt.Errorf("request %s: %w", getLastPricesEndpoint, err)
+	}
+
+	defer resp.Body.Close()
+
+	logger.InfoLog("%s API response status: %d", getLastPricesEndpoint, resp.StatusCode)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.HandleAPIError(resp, getLastPricesEndpoint)
+	}
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+
+	if err != nil {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+
+	var response dto.GetLastPricesResponse
+
+	if err := json.Unmarshal(bodyBytes, &response); err != nil {
+		logger.ErrorLog("Failed to decode JSON for %s. Body start: %s",
+			getLastPricesEndpoint, string(bodyBytes[:min(500, len(bodyBytes))]))
+
+		return nil, fmt.Errorf("decode DTO response for %s: %w", getLastPricesEndpoint, err)
+	}
+
+	logger.InfoLog("Successfully parsed %d last prices from %s", len(response.LastPrices), getLastPricesEndpoint)
+
+	return prices.FromLastPriceDtoToDomainSlice(response.LastPrices), nil
+}
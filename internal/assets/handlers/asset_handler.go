@@ -2,22 +2,30 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 
 	"invest-mate/internal/assets/services"
+	"invest-mate/internal/assets/storage"
 	sharedModels "invest-mate/internal/shared/models"
 	"invest-mate/pkg/handlers"
 	middleware "invest-mate/pkg/middlewares"
 )
 
 type AssetHandler struct {
-	assetService services.AssetService
+	assetService         services.AssetService
+	bondAnalyticsService services.BondAnalyticsService
 }
 
 // Создание нового хендлера
-func NewAssetHandler(assetService services.AssetService) *AssetHandler {
-	return &AssetHandler{assetService: assetService}
+func NewAssetHandler(assetService services.AssetService, bondAnalyticsService services.BondAnalyticsService) *AssetHandler {
+	return &AssetHandler{
+		assetService:         assetService,
+		bondAnalyticsService: bondAnalyticsService,
+	}
 }
 
 // Регистрация маршрутов
@@ -27,9 +35,14 @@ func (h *AssetHandler) RegisterRoutes(router *gin.RouterGroup) {
 	assets.Use(middleware.RoleMiddleware(string(sharedModels.Admin)))
 	{
 		assets.GET("/", handleWithParams(h.assetService.GetAssets, h.assetService.GetAssetByField))
-		assets.GET("/bonds", handleWithParams(h.assetService.GetBonds, h.assetService.GetBondByField))
+		assets.GET("/bonds", h.GetBonds)
+		assets.GET("/bonds/:uid/analytics", h.GetBondAnalytics)
+		assets.GET("/bonds/:uid/coupons", handleSchedule(h.assetService.GetBondCoupons))
+		assets.GET("/bonds/:uid/events", handleSchedule(h.assetService.GetBondEvents))
 		assets.GET("/shares", handleWithParams(h.assetService.GetShares, h.assetService.GetShareByField))
+		assets.GET("/shares/:uid/dividends", handleSchedule(h.assetService.GetDividends))
 		assets.GET("/etfs", handleWithParams(h.assetService.GetEtfs, h.assetService.GetEtfByField))
+		assets.GET("/etfs/:uid/dividends", handleSchedule(h.assetService.GetDividends))
 		assets.GET("/currencies", handleWithParams(h.assetService.GetCurrencies, h.assetService.GetCurrencyByField))
 	}
 }
@@ -56,3 +69,82 @@ func handleWithParams[T any, P any](
 		}
 	}
 }
+
+// Обработчик запроса графика выплат инструмента (refresh=true — запросить заново из API)
+func handleSchedule[T any](
+	getFunc func(ctx context.Context, instrumentUid string, refresh bool) ([]T, error),
+) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		data, err := getFunc(c.Request.Context(), c.Param("uid"), c.Query("refresh") == "true")
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, storage.ErrBondNotFound) || errors.Is(err, storage.ErrDividendPayerNotFound) {
+				status = http.StatusNotFound
+			}
+
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, handlers.BuildResponse(data))
+	}
+}
+
+// Обработчик списка облигаций (withAnalytics=true — с доходностями по последним ценам)
+func (h *AssetHandler) GetBonds(c *gin.Context) {
+	if c.Query("withAnalytics") != "true" {
+		handleWithParams(h.assetService.GetBonds, h.assetService.GetBondByField)(c)
+		return
+	}
+
+	page, limit := handlers.ParsePaginationParams(c)
+	if limit == 0 || limit > services.MaxBondAnalyticsPage {
+		limit = services.MaxBondAnalyticsPage
+	}
+
+	bonds, total, err := h.bondAnalyticsService.GetBondsWithAnalytics(c.Request.Context(), page, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildListResponse(bonds, total, page, limit))
+}
+
+// Обработчик расчёта доходности облигации (price — чистая цена в процентах номинала)
+func (h *AssetHandler) GetBondAnalytics(c *gin.Context) {
+	var price *float64
+
+	if value := c.Query("price"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Query parameter 'price' must be a number"})
+			return
+		}
+		price = &parsed
+	}
+
+	analytics, err := h.bondAnalyticsService.GetBondAnalytics(c.Request.Context(), c.Param("uid"), price)
+	if err != nil {
+		respondAnalyticsError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(analytics))
+}
+
+// Ответ с ошибкой расчёта аналитики
+func respondAnalyticsError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, storage.ErrBondNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, services.ErrInvalidBondPrice):
+		status = http.StatusBadRequest
+	case errors.Is(err, services.ErrBondPriceUnavailable):
+		status = http.StatusUnprocessableEntity
+	}
+
+	c.JSON(status, gin.H{"error": err.Error()})
+}
@@ -26,6 +26,7 @@ func FromDtoToDomain(dto dto.Etf) domain.Etf {
 		RealExchange:          dto.RealExchange,
 		ShortEnabledFlag:      dto.ShortEnabledFlag,
 		Name:                  dto.Name,
+		CountryOfRisk:         dto.CountryOfRisk,
 		CountryOfRiskName:     dto.CountryOfRiskName,
 		TradingStatus:         dto.TradingStatus,
 		OtcFlag:               dto.OtcFlag,
@@ -82,6 +83,7 @@ func FromDomainToEntity(domain domain.Etf) entity.Etf {
 		RealExchange:          domain.RealExchange,
 		ShortEnabledFlag:      domain.ShortEnabledFlag,
 		Name:                  domain.Name,
+		CountryOfRisk:         domain.CountryOfRisk,
 		CountryOfRiskName:     domain.CountryOfRiskName,
 		TradingStatus:         domain.TradingStatus,
 		OtcFlag:               domain.OtcFlag,
@@ -138,6 +140,7 @@ func FromEntityToDomain(entity entity.Etf) domain.Etf {
 		RealExchange:          entity.RealExchange,
 		ShortEnabledFlag:      entity.ShortEnabledFlag,
 		Name:                  entity.Name,
+		CountryOfRisk:         entity.CountryOfRisk,
 		CountryOfRiskName:     entity.CountryOfRiskName,
 		TradingStatus:         entity.TradingStatus,
 		OtcFlag:               entity.OtcFlag,
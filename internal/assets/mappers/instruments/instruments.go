@@ -0,0 +1,118 @@
+package instruments
+
+import (
+	"invest-mate/internal/assets/models/domain"
+	"invest-mate/internal/shared/models"
+)
+
+func FromDomain(marker domain.Marker) domain.Instrument {
+	switch v := marker.(type) {
+	case domain.Bond:
+		{
+			return domain.Instrument{
+				Uid:               v.Uid,
+				Figi:              v.Figi,
+				Ticker:            v.Ticker,
+				Isin:              v.Isin,
+				ClassCode:         v.ClassCode,
+				PositionUid:       v.PositionUid,
+				Name:              v.Name,
+				Currency:          v.Currency,
+				Lot:               v.Lot,
+				MinPriceIncrement: v.MinPriceIncrement,
+				Nominal:           v.Nominal,
+				Sector:            v.Sector,
+				CountryOfRisk:     v.CountryOfRisk,
+				CountryOfRiskName: v.CountryOfRiskName,
+
+				First1minCandleDate: v.First1minCandleDate,
+				First1dayCandleDate: v.First1dayCandleDate,
+
+				InstrumentType: models.InstrumentTypeBond,
+			}
+		}
+	case domain.Share:
+		{
+			return domain.Instrument{
+				Uid:               v.Uid,
+				Figi:              v.Figi,
+				Ticker:            v.Ticker,
+				Isin:              v.Isin,
+				ClassCode:         v.ClassCode,
+				PositionUid:       v.PositionUid,
+				Name:              v.Name,
+				Currency:          v.Currency,
+				Lot:               v.Lot,
+				MinPriceIncrement: v.MinPriceIncrement,
+				Nominal:           v.Nominal,
+				Sector:            v.Sector,
+				CountryOfRisk:     v.CountryOfRisk,
+				CountryOfRiskName: v.CountryOfRiskName,
+
+				First1minCandleDate: v.First1minCandleDate,
+				First1dayCandleDate: v.First1dayCandleDate,
+
+				InstrumentType: models.InstrumentTypeShare,
+			}
+		}
+	case domain.Etf:
+		{
+			return domain.Instrument{
+				Uid:               v.Uid,
+				Figi:              v.Figi,
+				Ticker:            v.Ticker,
+				Isin:              v.Isin,
+				ClassCode:         v.ClassCode,
+				PositionUid:       v.PositionUid,
+				Name:              v.Name,
+				Currency:          v.Currency,
+				Lot:               v.Lot,
+				MinPriceIncrement: v.MinPriceIncrement,
+				Sector:            v.Sector,
+				CountryOfRisk:     v.CountryOfRisk,
+				CountryOfRiskName: v.CountryOfRiskName,
+
+				First1minCandleDate: v.First1minCandleDate,
+				First1dayCandleDate: v.First1dayCandleDate,
+
+				InstrumentType: models.InstrumentTypeETF,
+			}
+		}
+	case domain.Currency:
+		{
+			return domain.Instrument{
+				Uid:               v.Uid,
+				Figi:              v.Figi,
+				Ticker:            v.Ticker,
+				Isin:              v.Isin,
+				ClassCode:         v.ClassCode,
+				PositionUid:       v.PositionUid,
+				Name:              v.Name,
+				Currency:          v.Currency,
+				Lot:               v.Lot,
+				MinPriceIncrement: v.MinPriceIncrement,
+				Nominal:           v.Nominal,
+				CountryOfRiskName: v.CountryOfRiskName,
+
+				First1minCandleDate: v.First1minCandleDate,
+				First1dayCandleDate: v.First1dayCandleDate,
+
+				InstrumentType: models.InstrumentTypeCurrency,
+			}
+		}
+	default:
+		{
+			return domain.Instrument{}
+		}
+	}
+}
+
+func FromDomainSlice[T domain.Marker](domainSlice []T) []domain.Instrument {
+	instrumentSlice := make([]domain.Instrument, len(domainSlice))
+
+	for index, domain := range domainSlice {
+		instrumentSlice[index] = FromDomain(domain)
+	}
+
+	return instrumentSlice
+}
@@ -0,0 +1,32 @@
+package prices
+
+import (
+	"time"
+
+	"invest-mate/internal/assets/models/domain"
+	"invest-mate/internal/assets/models/dto"
+)
+
+func FromLastPriceDtoToDomain(dto dto.LastPrice) domain.LastPrice {
+	lastPrice := domain.LastPrice{
+		Figi:          dto.Figi,
+		InstrumentUid: dto.InstrumentUid,
+		Price:         dto.Price.ToFloat(),
+	}
+
+	if parsed, err := time.Parse(time.RFC3339Nano, dto.Time); err == nil {
+		lastPrice.Time = parsed
+	}
+
+	return lastPrice
+}
+
+func FromLastPriceDtoToDomainSlice(dtoSlice []dto.LastPrice) []domain.LastPrice {
+	domainSlice := make([]domain.LastPrice, len(dtoSlice))
+
+	for index, dto := range dtoSlice {
+		domainSlice[index] = FromLastPriceDtoToDomain(dto)
+	}
+
+	return domainSlice
+}
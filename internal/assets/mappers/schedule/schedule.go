@@ -0,0 +1,282 @@
+package schedule
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"invest-mate/internal/assets/models/domain"
+	"invest-mate/internal/assets/models/dto"
+	"invest-mate/internal/assets/models/entity"
+)
+
+func FromCouponDtoToDomain(instrumentUid string, dto dto.Coupon) domain.BondCoupon {
+	couponNumber, _ := strconv.Atoi(dto.CouponNumber)
+
+	return domain.BondCoupon{
+		InstrumentUid:   instrumentUid,
+		Figi:            dto.Figi,
+		CouponNumber:    couponNumber,
+		CouponDate:      parseTime(dto.CouponDate),
+		FixDate:         parseOptionalTime(dto.FixDate),
+		CouponStartDate: parseOptionalTime(dto.CouponStartDate),
+		CouponEndDate:   parseOptionalTime(dto.CouponEndDate),
+		CouponPeriod:    dto.CouponPeriod,
+		CouponType:      strings.TrimPrefix(dto.CouponType, "COUPON_TYPE_"),
+		PayOneBond:      dto.PayOneBond.ToFloat(),
+		Currency:        strings.ToUpper(dto.PayOneBond.Currency),
+	}
+}
+
+func FromCouponDtoToDomainSlice(instrumentUid string, dtoSlice []dto.Coupon) []domain.BondCoupon {
+	domainSlice := make([]domain.BondCoupon, len(dtoSlice))
+
+	for index, dto := range dtoSlice {
+		domainSlice[index] = FromCouponDtoToDomain(instrumentUid, dto)
+	}
+
+	return domainSlice
+}
+
+func FromCouponDomainToEntity(domain domain.BondCoupon, fetchedAt time.Time) entity.BondCoupon {
+	return entity.BondCoupon{
+		InstrumentUid:   domain.InstrumentUid,
+		Figi:            domain.Figi,
+		CouponNumber:    domain.CouponNumber,
+		CouponDate:      domain.CouponDate,
+		FixDate:         domain.FixDate,
+		CouponStartDate: domain.CouponStartDate,
+		CouponEndDate:   domain.CouponEndDate,
+		CouponPeriod:    domain.CouponPeriod,
+		CouponType:      domain.CouponType,
+		PayOneBond:      domain.PayOneBond,
+		Currency:        domain.Currency,
+		FetchedAt:       fetchedAt,
+	}
+}
+
+func FromCouponDomainToEntitySlice(domainSlice []domain.BondCoupon, fetchedAt time.Time) []entity.BondCoupon {
+	entitySlice := make([]entity.BondCoupon, len(domainSlice))
+
+	for index, domain := range domainSlice {
+		entitySlice[index] = FromCouponDomainToEntity(domain, fetchedAt)
+	}
+
+	return entitySlice
+}
+
+func FromCouponEntityToDomain(entity entity.BondCoupon) domain.BondCoupon {
+	return domain.BondCoupon{
+		InstrumentUid:   entity.InstrumentUid,
+		Figi:            entity.Figi,
+		CouponNumber:    entity.CouponNumber,
+		CouponDate:      entity.CouponDate,
+		FixDate:         entity.FixDate,
+		CouponStartDate: entity.CouponStartDate,
+		CouponEndDate:   entity.CouponEndDate,
+		CouponPeriod:    entity.CouponPeriod,
+		CouponType:      entity.CouponType,
+		PayOneBond:      entity.PayOneBond,
+		Currency:        entity.Currency,
+	}
+}
+
+func FromCouponEntityToDomainSlice(entitySlice []entity.BondCoupon) []domain.BondCoupon {
+	domainSlice := make([]domain.BondCoupon, len(entitySlice))
+
+	for index, entity := range entitySlice {
+		domainSlice[index] = FromCouponEntityToDomain(entity)
+	}
+
+	return domainSlice
+}
+
+func FromEventDtoToDomain(instrumentUid string, dto dto.BondEvent) domain.BondEvent {
+	return domain.BondEvent{
+		InstrumentUid: instrumentUid,
+		EventNumber:   dto.EventNumber,
+		EventType:     eventType(dto.EventType),
+		EventDate:     parseTime(dto.EventDate),
+		FixDate:       parseOptionalTime(dto.FixDate),
+		PayDate:       parseOptionalTime(dto.PayDate),
+		PayOneBond:    dto.PayOneBond.ToFloat(),
+		MoneyFlow:     dto.MoneyFlowVal.ToFloat(),
+		Currency:      strings.ToUpper(firstNonEmpty(dto.PayOneBond.Currency, dto.MoneyFlowVal.Currency)),
+		Value:         dto.Value.ToFloat(),
+		Execution:     dto.Execution,
+		OperationType: dto.OperationType,
+		Note:          dto.Note,
+	}
+}
+
+func FromEventDomainToEntity(domain domain.BondEvent, fetchedAt time.Time) entity.BondEvent {
+	return entity.BondEvent{
+		InstrumentUid: domain.InstrumentUid,
+		EventNumber:   domain.EventNumber,
+		EventType:     string(domain.EventType),
+		EventDate:     domain.EventDate,
+		FixDate:       domain.FixDate,
+		PayDate:       domain.PayDate,
+		PayOneBond:    domain.PayOneBond,
+		MoneyFlow:     domain.MoneyFlow,
+		Currency:      domain.Currency,
+		Value:         domain.Value,
+		Execution:     domain.Execution,
+		OperationType: domain.OperationType,
+		Note:          domain.Note,
+		FetchedAt:     fetchedAt,
+	}
+}
+
+func FromEventDomainToEntitySlice(domainSlice []domain.BondEvent, fetchedAt time.Time) []entity.BondEvent {
+	entitySlice := make([]entity.BondEvent, len(domainSlice))
+
+	for index, domain := range domainSlice {
+		entitySlice[index] = FromEventDomainToEntity(domain, fetchedAt)
+	}
+
+	return entitySlice
+}
+
+func FromEventEntityToDomain(entity entity.BondEvent) domain.BondEvent {
+	return domain.BondEvent{
+		InstrumentUid: entity.InstrumentUid,
+		EventNumber:   entity.EventNumber,
+		EventType:     domain.BondEventType(entity.EventType),
+		EventDate:     entity.EventDate,
+		FixDate:       entity.FixDate,
+		PayDate:       entity.PayDate,
+		PayOneBond:    entity.PayOneBond,
+		MoneyFlow:     entity.MoneyFlow,
+		Currency:      entity.Currency,
+		Value:         entity.Value,
+		Execution:     entity.Execution,
+		OperationType: entity.OperationType,
+		Note:          entity.Note,
+	}
+}
+
+func FromEventEntityToDomainSlice(entitySlice []entity.BondEvent) []domain.BondEvent {
+	domainSlice := make([]domain.BondEvent, len(entitySlice))
+
+	for index, entity := range entitySlice {
+		domainSlice[index] = FromEventEntityToDomain(entity)
+	}
+
+	return domainSlice
+}
+
+// Тип события из справочника API (амортизация определяется по дате погашения облигации)
+func eventType(value string) domain.BondEventType {
+	switch value {
+	case "EVENT_TYPE_CALL":
+		return domain.BondEventTypeCall
+	case "EVENT_TYPE_MTY":
+		return domain.BondEventTypeMaturity
+	case "EVENT_TYPE_CONV":
+		return domain.BondEventTypeConversion
+	default:
+		return domain.BondEventTypeOther
+	}
+}
+
+// Разбор даты API; пустая или некорректная дата — нулевое время
+func parseTime(value string) time.Time {
+	parsed, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return parsed
+}
+
+// Разбор необязательной даты API
+func parseOptionalTime(value string) *time.Time {
+	parsed := parseTime(value)
+	if parsed.IsZero() || parsed.Year() <= 1970 {
+		return nil
+	}
+
+	return &parsed
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+func FromDividendDtoToDomain(instrumentUid string, dto dto.Dividend) domain.Dividend {
+	return domain.Dividend{
+		InstrumentUid: instrumentUid,
+		AmountPerUnit: dto.DividendNet.ToFloat(),
+		Currency:      strings.ToUpper(dto.DividendNet.Currency),
+		DeclaredDate:  parseOptionalTime(dto.DeclaredDate),
+		LastBuyDate:   parseOptionalTime(dto.LastBuyDate),
+		RecordDate:    parseOptionalTime(dto.RecordDate),
+		PaymentDate:   parseOptionalTime(dto.PaymentDate),
+		DividendType:  dto.DividendType,
+		Regularity:    dto.Regularity,
+		ClosePrice:    dto.ClosePrice.ToFloat(),
+		YieldPercent:  dto.YieldValue.ToFloat(),
+	}
+}
+
+func FromDividendDtoToDomainSlice(instrumentUid string, dtoSlice []dto.Dividend) []domain.Dividend {
+	domainSlice := make([]domain.Dividend, len(dtoSlice))
+
+	for index, dto := range dtoSlice {
+		domainSlice[index] = FromDividendDtoToDomain(instrumentUid, dto)
+	}
+
+	return domainSlice
+}
+
+func FromDividendDomainToEntitySlice(domainSlice []domain.Dividend, fetchedAt time.Time) []entity.Dividend {
+	entitySlice := make([]entity.Dividend, len(domainSlice))
+
+	for index, domain := range domainSlice {
+		entitySlice[index] = entity.Dividend{
+			InstrumentUid: domain.InstrumentUid,
+			AmountPerUnit: domain.AmountPerUnit,
+			Currency:      domain.Currency,
+			DeclaredDate:  domain.DeclaredDate,
+			LastBuyDate:   domain.LastBuyDate,
+			RecordDate:    domain.RecordDate,
+			PaymentDate:   domain.PaymentDate,
+			DividendType:  domain.DividendType,
+			Regularity:    domain.Regularity,
+			ClosePrice:    domain.ClosePrice,
+			YieldPercent:  domain.YieldPercent,
+			FetchedAt:     fetchedAt,
+		}
+	}
+
+	return entitySlice
+}
+
+func FromDividendEntityToDomainSlice(entitySlice []entity.Dividend) []domain.Dividend {
+	domainSlice := make([]domain.Dividend, len(entitySlice))
+
+	for index, entity := range entitySlice {
+		domainSlice[index] = domain.Dividend{
+			InstrumentUid: entity.InstrumentUid,
+			AmountPerUnit: entity.AmountPerUnit,
+			Currency:      entity.Currency,
+			DeclaredDate:  entity.DeclaredDate,
+			LastBuyDate:   entity.LastBuyDate,
+			RecordDate:    entity.RecordDate,
+			PaymentDate:   entity.PaymentDate,
+			DividendType:  entity.DividendType,
+			Regularity:    entity.Regularity,
+			ClosePrice:    entity.ClosePrice,
+			YieldPercent:  entity.YieldPercent,
+		}
+	}
+
+	return domainSlice
+}
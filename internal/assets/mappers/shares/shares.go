@@ -26,6 +26,7 @@ func FromDtoToDomain(dto dto.Share) domain.Share {
 		RealExchange:          dto.RealExchange,
 		ShortEnabledFlag:      dto.ShortEnabledFlag,
 		Name:                  dto.Name,
+		CountryOfRisk:         dto.CountryOfRisk,
 		CountryOfRiskName:     dto.CountryOfRiskName,
 		TradingStatus:         dto.TradingStatus,
 		OtcFlag:               dto.OtcFlag,
@@ -83,6 +84,7 @@ func FromDomainToEntity(domain domain.Share) entity.Share {
 		RealExchange:          domain.RealExchange,
 		ShortEnabledFlag:      domain.ShortEnabledFlag,
 		Name:                  domain.Name,
+		CountryOfRisk:         domain.CountryOfRisk,
 		CountryOfRiskName:     domain.CountryOfRiskName,
 		TradingStatus:         domain.TradingStatus,
 		OtcFlag:               domain.OtcFlag,
@@ -140,6 +142,7 @@ func FromEntityToDomain(entity entity.Share) domain.Share {
 		RealExchange:          entity.RealExchange,
 		ShortEnabledFlag:      entity.ShortEnabledFlag,
 		Name:                  entity.Name,
+		CountryOfRisk:         entity.CountryOfRisk,
 		CountryOfRiskName:     entity.CountryOfRiskName,
 		TradingStatus:         entity.TradingStatus,
 		OtcFlag:               entity.OtcFlag,
@@ -19,5 +19,9 @@ func (m *AssetsMigrator) Migrate(db *gorm.DB) error {
 		&entity.Share{},
 		&entity.Etf{},
 		&entity.Currency{},
+		&entity.BondCoupon{},
+		&entity.BondEvent{},
+		&entity.Dividend{},
+		&entity.ScheduleFetch{},
 	)
 }
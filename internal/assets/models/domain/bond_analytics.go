@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+// Горизонт расчёта дюрации
+const (
+	BondHorizonMaturity = "MATURITY"
+	BondHorizonOffer    = "OFFER"
+)
+
+// Доходность и процентный риск облигации при заданной цене
+// (доходности — эффективные годовые, в процентах; дюрации — в годах)
+type BondAnalytics struct {
+	InstrumentUid   string    `json:"instrumentUid"`
+	Ticker          string    `json:"ticker"`
+	Currency        string    `json:"currency"`
+	SettlementDate  time.Time `json:"settlementDate"`
+	Nominal         float64   `json:"nominal"`
+	PricePercent    float64   `json:"pricePercent"`
+	CleanPrice      float64   `json:"cleanPrice"`
+	AccruedInterest float64   `json:"accruedInterest"`
+	DirtyPrice      float64   `json:"dirtyPrice"`
+
+	CurrentYield    *float64   `json:"currentYield,omitempty"`
+	YieldToMaturity *float64   `json:"yieldToMaturity,omitempty"`
+	MaturityDate    *time.Time `json:"maturityDate,omitempty"`
+	YieldToOffer    *float64   `json:"yieldToOffer,omitempty"`
+	OfferDate       *time.Time `json:"offerDate,omitempty"`
+
+	// Дюрация и выпуклость считаются до оферты, если она есть, иначе до погашения
+	DurationHorizon  string   `json:"durationHorizon,omitempty"`
+	MacaulayDuration *float64 `json:"macaulayDuration,omitempty"`
+	ModifiedDuration *float64 `json:"modifiedDuration,omitempty"`
+	Convexity        *float64 `json:"convexity,omitempty"`
+
+	// Неизвестные купоны (плавающие) приняты равными последнему известному
+	EstimatedCoupons bool `json:"estimatedCoupons"`
+}
+
+// Облигация с рассчитанной доходностью
+type BondWithAnalytics struct {
+	Bond
+	Analytics *BondAnalytics `json:"analytics,omitempty"`
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+type BondEventType string
+
+const (
+	BondEventTypeCall         BondEventType = "CALL"
+	BondEventTypeMaturity     BondEventType = "MATURITY"
+	BondEventTypeAmortization BondEventType = "AMORTIZATION"
+	BondEventTypeConversion   BondEventType = "CONVERSION"
+	BondEventTypeOther        BondEventType = "OTHER"
+)
+
+// Купон облигации (выплата на одну облигацию)
+type BondCoupon struct {
+	InstrumentUid   string     `json:"instrumentUid"`
+	Figi            string     `json:"figi"`
+	CouponNumber    int        `json:"couponNumber"`
+	CouponDate      time.Time  `json:"couponDate"`
+	FixDate         *time.Time `json:"fixDate,omitempty"`
+	CouponStartDate *time.Time `json:"couponStartDate,omitempty"`
+	CouponEndDate   *time.Time `json:"couponEndDate,omitempty"`
+	CouponPeriod    int        `json:"couponPeriod"`
+	CouponType      string     `json:"couponType"`
+	PayOneBond      float64    `json:"payOneBond"`
+	Currency        string     `json:"currency"`
+}
+
+// Событие облигации: оферта, погашение, амортизация или конвертация
+type BondEvent struct {
+	InstrumentUid string        `json:"instrumentUid"`
+	EventNumber   int           `json:"eventNumber"`
+	EventType     BondEventType `json:"eventType"`
+	EventDate     time.Time     `json:"eventDate"`
+	FixDate       *time.Time    `json:"fixDate,omitempty"`
+	PayDate       *time.Time    `json:"payDate,omitempty"`
+	PayOneBond    float64       `json:"payOneBond"`
+	MoneyFlow     float64       `json:"moneyFlow"`
+	Currency      string        `json:"currency"`
+	// Цена оферты в процентах номинала
+	Value         float64 `json:"value"`
+	Execution     string  `json:"execution"`
+	OperationType string  `json:"operationType"`
+	Note          string  `json:"note"`
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// Дивиденд на одну акцию или пай фонда
+type Dividend struct {
+	InstrumentUid string     `json:"instrumentUid"`
+	AmountPerUnit float64    `json:"amountPerUnit"`
+	Currency      string     `json:"currency"`
+	DeclaredDate  *time.Time `json:"declaredDate,omitempty"`
+	LastBuyDate   *time.Time `json:"lastBuyDate,omitempty"`
+	RecordDate    *time.Time `json:"recordDate,omitempty"`
+	PaymentDate   *time.Time `json:"paymentDate,omitempty"`
+	DividendType  string     `json:"dividendType"`
+	Regularity    string     `json:"regularity"`
+	ClosePrice    float64    `json:"closePrice"`
+	YieldPercent  float64    `json:"yieldPercent"`
+}
@@ -20,6 +20,7 @@ type Etf struct {
 	RealExchange          string  `json:"realExchange"`
 	ShortEnabledFlag      bool    `json:"shortEnabledFlag"`
 	Name                  string  `json:"name"`
+	CountryOfRisk         string  `json:"countryOfRisk"`
 	CountryOfRiskName     string  `json:"countryOfRiskName"`
 	TradingStatus         string  `json:"tradingStatus"`
 	OtcFlag               bool    `json:"otcFlag"`
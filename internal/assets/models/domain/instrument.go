@@ -0,0 +1,26 @@
+package domain
+
+import "invest-mate/internal/shared/models"
+
+// Общие сведения об инструменте любого типа
+type Instrument struct {
+	Uid               string  `json:"uid"`
+	Figi              string  `json:"figi"`
+	Ticker            string  `json:"ticker"`
+	Isin              string  `json:"isin"`
+	ClassCode         string  `json:"classCode"`
+	PositionUid       string  `json:"positionUid"`
+	Name              string  `json:"name"`
+	Currency          string  `json:"currency"`
+	Lot               int     `json:"lot"`
+	MinPriceIncrement float64 `json:"minPriceIncrement"`
+	Nominal           float64 `json:"nominal"`
+	Sector            string  `json:"sector"`
+	CountryOfRisk     string  `json:"countryOfRisk"`
+	CountryOfRiskName string  `json:"countryOfRiskName"`
+
+	First1minCandleDate string `json:"first1minCandleDate"`
+	First1dayCandleDate string `json:"first1dayCandleDate"`
+
+	InstrumentType models.InstrumentType `json:"instrumentType"`
+}
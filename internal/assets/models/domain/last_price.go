@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// Последняя цена инструмента
+type LastPrice struct {
+	Figi          string    `json:"figi"`
+	InstrumentUid string    `json:"instrumentUid"`
+	Price         float64   `json:"price"`
+	Time          time.Time `json:"time"`
+}
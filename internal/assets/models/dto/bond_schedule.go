@@ -0,0 +1,36 @@
+package dto
+
+type Coupon struct {
+	Figi            string     `json:"figi"`
+	CouponDate      string     `json:"couponDate"`
+	CouponNumber    string     `json:"couponNumber"`
+	FixDate         string     `json:"fixDate"`
+	PayOneBond      MoneyValue `json:"payOneBond"`
+	CouponType      string     `json:"couponType"`
+	CouponStartDate string     `json:"couponStartDate"`
+	CouponEndDate   string     `json:"couponEndDate"`
+	CouponPeriod    int        `json:"couponPeriod"`
+}
+
+type GetBondCouponsResponse struct {
+	Events []Coupon `json:"events"`
+}
+
+type BondEvent struct {
+	InstrumentId  string     `json:"instrumentId"`
+	EventNumber   int        `json:"eventNumber"`
+	EventDate     string     `json:"eventDate"`
+	EventType     string     `json:"eventType"`
+	FixDate       string     `json:"fixDate"`
+	PayDate       string     `json:"payDate"`
+	PayOneBond    MoneyValue `json:"payOneBond"`
+	MoneyFlowVal  MoneyValue `json:"moneyFlowVal"`
+	Execution     string     `json:"execution"`
+	OperationType string     `json:"operationType"`
+	Value         Quotation  `json:"value"`
+	Note          string     `json:"note"`
+}
+
+type GetBondEventsResponse struct {
+	Events []BondEvent `json:"events"`
+}
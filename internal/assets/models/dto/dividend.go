@@ -0,0 +1,18 @@
+package dto
+
+type Dividend struct {
+	DividendNet  MoneyValue `json:"dividendNet"`
+	PaymentDate  string     `json:"paymentDate"`
+	DeclaredDate string     `json:"declaredDate"`
+	LastBuyDate  string     `json:"lastBuyDate"`
+	DividendType string     `json:"dividendType"`
+	RecordDate   string     `json:"recordDate"`
+	Regularity   string     `json:"regularity"`
+	ClosePrice   MoneyValue `json:"closePrice"`
+	YieldValue   Quotation  `json:"yieldValue"`
+	CreatedAt    string     `json:"createdAt"`
+}
+
+type GetDividendsResponse struct {
+	Dividends []Dividend `json:"dividends"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+type LastPrice struct {
+	Figi          string    `json:"figi"`
+	Price         Quotation `json:"price"`
+	Time          string    `json:"time"`
+	InstrumentUid string    `json:"instrumentUid"`
+}
+
+type GetLastPricesResponse struct {
+	LastPrices []LastPrice `json:"lastPrices"`
+}
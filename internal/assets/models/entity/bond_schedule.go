@@ -0,0 +1,37 @@
+package entity
+
+import "time"
+
+type BondCoupon struct {
+	ID              uint      `gorm:"primaryKey"`
+	InstrumentUid   string    `gorm:"size:255;not null;index"`
+	Figi            string    `gorm:"size:255"`
+	CouponNumber    int       `gorm:"not null"`
+	CouponDate      time.Time `gorm:"not null;index"`
+	FixDate         *time.Time
+	CouponStartDate *time.Time
+	CouponEndDate   *time.Time
+	CouponPeriod    int
+	CouponType      string `gorm:"size:50"`
+	PayOneBond      float64
+	Currency        string    `gorm:"size:8"`
+	FetchedAt       time.Time `gorm:"not null"`
+}
+
+type BondEvent struct {
+	ID            uint      `gorm:"primaryKey"`
+	InstrumentUid string    `gorm:"size:255;not null;index"`
+	EventNumber   int       `gorm:"not null"`
+	EventType     string    `gorm:"size:20;not null"`
+	EventDate     time.Time `gorm:"not null;index"`
+	FixDate       *time.Time
+	PayDate       *time.Time
+	PayOneBond    float64
+	MoneyFlow     float64
+	Currency      string `gorm:"size:8"`
+	Value         float64
+	Execution     string    `gorm:"size:50"`
+	OperationType string    `gorm:"size:100"`
+	Note          string    `gorm:"type:text"`
+	FetchedAt     time.Time `gorm:"not null"`
+}
@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+type Dividend struct {
+	ID            uint   `gorm:"primaryKey"`
+	InstrumentUid string `gorm:"size:255;not null;index"`
+	AmountPerUnit float64
+	Currency      string `gorm:"size:8"`
+	DeclaredDate  *time.Time
+	LastBuyDate   *time.Time
+	RecordDate    *time.Time `gorm:"index"`
+	PaymentDate   *time.Time `gorm:"index"`
+	DividendType  string     `gorm:"size:100"`
+	Regularity    string     `gorm:"size:100"`
+	ClosePrice    float64
+	YieldPercent  float64
+	FetchedAt     time.Time `gorm:"not null"`
+}
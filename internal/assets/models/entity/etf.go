@@ -25,6 +25,7 @@ type Etf struct {
 	Exchange              string `gorm:"size:50"`
 	RealExchange          string `gorm:"size:50"`
 	ShortEnabledFlag      bool
+	CountryOfRisk         string `gorm:"size:100"`
 	CountryOfRiskName     string `gorm:"size:255"`
 	TradingStatus         string `gorm:"size:50"`
 	OtcFlag               bool
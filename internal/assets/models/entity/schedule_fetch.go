@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// Виды графиков выплат, загружаемых из API
+const (
+	ScheduleKindCoupons   = "COUPONS"
+	ScheduleKindEvents    = "EVENTS"
+	ScheduleKindDividends = "DIVIDENDS"
+)
+
+// Время последней загрузки графика инструмента (в том числе пустого)
+type ScheduleFetch struct {
+	InstrumentUid string    `gorm:"size:255;primaryKey"`
+	Kind          string    `gorm:"size:20;primaryKey"`
+	FetchedAt     time.Time `gorm:"not null"`
+}
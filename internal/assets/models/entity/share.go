@@ -25,6 +25,7 @@ type Share struct {
 	RealExchange          string `gorm:"size:255"`
 	ShortEnabledFlag      bool
 	Name                  string `gorm:"size:255"`
+	CountryOfRisk         string `gorm:"size:100"`
 	CountryOfRiskName     string `gorm:"size:255"`
 	TradingStatus         string `gorm:"size:255"`
 	OtcFlag               bool
@@ -1,6 +1,8 @@
 package assets
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 
 	"invest-mate/internal/assets/handlers"
@@ -23,9 +25,11 @@ func InitModule(db *gorm.DB, cfg *config.Config) (*Module, error) {
 	}
 
 	assetRepo := repository.NewAssetRepository(db)
-	tinkoffStorage := storage.NewTinkoffStorage(assetRepo)
+	tinkoffStorage := storage.GetInstance(assetRepo)
+	tinkoffStorage.SetPriceTTL(time.Duration(cfg.CacheTTL) * time.Second)
 	assetService := services.NewAssetService(assetRepo, tinkoffStorage)
-	assetHandler := handlers.NewAssetHandler(assetService)
+	bondAnalyticsService := services.NewBondAnalyticsService(tinkoffStorage)
+	assetHandler := handlers.NewAssetHandler(assetService, bondAnalyticsService)
 
 	return &Module{
 		assetHandler: assetHandler,
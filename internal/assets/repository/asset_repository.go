@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -27,6 +28,16 @@ type AssetRepository interface {
 
 	GetCurrencies(ctx context.Context, limit, offset int) ([]entity.Currency, error)
 	GetCurrencyByField(ctx context.Context, fieldName string, fieldValue string) (*entity.Currency, error)
+
+	GetBondCoupons(ctx context.Context, instrumentUid string) ([]entity.BondCoupon, error)
+	GetBondCouponsByUids(ctx context.Context, instrumentUids []string) ([]entity.BondCoupon, error)
+	SaveBondCoupons(ctx context.Context, instrumentUid string, coupons []entity.BondCoupon) error
+	GetBondEvents(ctx context.Context, instrumentUid string) ([]entity.BondEvent, error)
+	GetBondEventsByUids(ctx context.Context, instrumentUids []string) ([]entity.BondEvent, error)
+	SaveBondEvents(ctx context.Context, instrumentUid string, events []entity.BondEvent) error
+	GetDividends(ctx context.Context, instrumentUid string) ([]entity.Dividend, error)
+	SaveDividends(ctx context.Context, instrumentUid string, dividends []entity.Dividend) error
+	GetScheduleFetches(ctx context.Context, kind string, instrumentUids []string) (map[string]time.Time, error)
 }
 
 type assetRepository struct {
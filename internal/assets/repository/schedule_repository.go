@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"invest-mate/internal/assets/models/entity"
+)
+
+// Получение купонов облигации из БД
+func (r *assetRepository) GetBondCoupons(ctx context.Context, instrumentUid string) ([]entity.BondCoupon, error) {
+	var coupons []entity.BondCoupon
+
+	err := r.db.WithContext(ctx).
+		Where("instrument_uid = ?", instrumentUid).
+		Order("coupon_date, coupon_number").
+		Find(&coupons).Error
+	if err != nil {
+		return nil, fmt.Errorf("get bond coupons: %w", err)
+	}
+
+	return coupons, nil
+}
+
+// Получение купонов набора облигаций из БД одним запросом
+func (r *assetRepository) GetBondCouponsByUids(ctx context.Context, instrumentUids []string) ([]entity.BondCoupon, error) {
+	var coupons []entity.BondCoupon
+
+	if len(instrumentUids) == 0 {
+		return coupons, nil
+	}
+
+	err := r.db.WithContext(ctx).
+		Where("instrument_uid IN ?", instrumentUids).
+		Order("instrument_uid, coupon_date, coupon_number").
+		Find(&coupons).Error
+	if err != nil {
+		return nil, fmt.Errorf("get bond coupons: %w", err)
+	}
+
+	return coupons, nil
+}
+
+// Замена купонов облигации в БД
+func (r *assetRepository) SaveBondCoupons(ctx context.Context, instrumentUid string, coupons []entity.BondCoupon) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&entity.BondCoupon{}, "instrument_uid = ?", instrumentUid).Error; err != nil {
+			return fmt.Errorf("delete bond coupons: %w", err)
+		}
+
+		if err := saveScheduleFetch(tx, instrumentUid, entity.ScheduleKindCoupons); err != nil {
+			return err
+		}
+
+		if len(coupons) == 0 {
+			return nil
+		}
+
+		return tx.CreateInBatches(coupons, 500).Error
+	})
+}
+
+// Получение событий облигации из БД
+func (r *assetRepository) GetBondEvents(ctx context.Context, instrumentUid string) ([]entity.BondEvent, error) {
+	var events []entity.BondEvent
+
+	err := r.db.WithContext(ctx).
+		Where("instrument_uid = ?", instrumentUid).
+		Order("event_date, event_number").
+		Find(&events).Error
+	if err != nil {
+		return nil, fmt.Errorf("get bond events: %w", err)
+	}
+
+	return events, nil
+}
+
+// Получение событий набора облигаций из БД одним запросом
+func (r *assetRepository) GetBondEventsByUids(ctx context.Context, instrumentUids []string) ([]entity.BondEvent, error) {
+	var events []entity.BondEvent
+
+	if len(instrumentUids) == 0 {
+		return events, nil
+	}
+
+	err := r.db.WithContext(ctx).
+		Where("instrument_uid IN ?", instrumentUids).
+		Order("instrument_uid, event_date, event_number").
+		Find(&events).Error
+	if err != nil {
+		return nil, fmt.Errorf("get bond events: %w", err)
+	}
+
+	return events, nil
+}
+
+// Замена событий облигации в БД
+func (r *assetRepository) SaveBondEvents(ctx context.Context, instrumentUid string, events []entity.BondEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&entity.BondEvent{}, "instrument_uid = ?", instrumentUid).Error; err != nil {
+			return fmt.Errorf("delete bond events: %w", err)
+		}
+
+		if err := saveScheduleFetch(tx, instrumentUid, entity.ScheduleKindEvents); err != nil {
+			return err
+		}
+
+		if len(events) == 0 {
+			return nil
+		}
+
+		return tx.CreateInBatches(events, 500).Error
+	})
+}
+
+// Получение дивидендов инструмента из БД
+func (r *assetRepository) GetDividends(ctx context.Context, instrumentUid string) ([]entity.Dividend, error) {
+	var dividends []entity.Dividend
+
+	err := r.db.WithContext(ctx).
+		Where("instrument_uid = ?", instrumentUid).
+		Order("record_date").
+		Find(&dividends).Error
+	if err != nil {
+		return nil, fmt.Errorf("get dividends: %w", err)
+	}
+
+	return dividends, nil
+}
+
+// Замена дивидендов инструмента в БД
+func (r *assetRepository) SaveDividends(ctx context.Context, instrumentUid string, dividends []entity.Dividend) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&entity.Dividend{}, "instrument_uid = ?", instrumentUid).Error; err != nil {
+			return fmt.Errorf("delete dividends: %w", err)
+		}
+
+		if err := saveScheduleFetch(tx, instrumentUid, entity.ScheduleKindDividends); err != nil {
+			return err
+		}
+
+		if len(dividends) == 0 {
+			return nil
+		}
+
+		return tx.CreateInBatches(dividends, 500).Error
+	})
+}
+
+// Получение времени последней загрузки графиков вида kind из БД
+func (r *assetRepository) GetScheduleFetches(ctx context.Context, kind string, instrumentUids []string) (map[string]time.Time, error) {
+	fetchedAt := make(map[string]time.Time, len(instrumentUids))
+
+	if len(instrumentUids) == 0 {
+		return fetchedAt, nil
+	}
+
+	var fetches []entity.ScheduleFetch
+
+	err := r.db.WithContext(ctx).
+		Where("kind = ? AND instrument_uid IN ?", kind, instrumentUids).
+		Find(&fetches).Error
+	if err != nil {
+		return nil, fmt.Errorf("get schedule fetches: %w", err)
+	}
+
+	for _, fetch := range fetches {
+		fetchedAt[fetch.InstrumentUid] = fetch.FetchedAt
+	}
+
+	return fetchedAt, nil
+}
+
+// Отметка о загрузке графика, чтобы пустой результат тоже считался сохранённым
+func saveScheduleFetch(tx *gorm.DB, instrumentUid, kind string) error {
+	fetch := entity.ScheduleFetch{
+		InstrumentUid: instrumentUid,
+		Kind:          kind,
+		FetchedAt:     time.Now(),
+	}
+
+	err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "instrument_uid"}, {Name: "kind"}},
+		DoUpdates: clause.AssignmentColumns([]string{"fetched_at"}),
+	}).Create(&fetch).Error
+	if err != nil {
+		return fmt.Errorf("save schedule fetch: %w", err)
+	}
+
+	return nil
+}
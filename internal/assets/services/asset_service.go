@@ -21,9 +21,12 @@ type AssetService interface {
 
 	GetBonds(ctx context.Context, page, limit int) ([]domain.Bond, int64, error)
 	GetBondByField(ctx context.Context, fieldName string, fieldValue string) (*domain.Bond, error)
+	GetBondCoupons(ctx context.Context, instrumentUid string, refresh bool) ([]domain.BondCoupon, error)
+	GetBondEvents(ctx context.Context, instrumentUid string, refresh bool) ([]domain.BondEvent, error)
 
 	GetShares(ctx context.Context, page, limit int) ([]domain.Share, int64, error)
 	GetShareByField(ctx context.Context, fieldName string, fieldValue string) (*domain.Share, error)
+	GetDividends(ctx context.Context, instrumentUid string, refresh bool) ([]domain.Dividend, error)
 
 	GetEtfs(ctx context.Context, page, limit int) ([]domain.Etf, int64, error)
 	GetEtfByField(ctx context.Context, fieldName string, fieldValue string) (*domain.Etf, error)
@@ -80,6 +83,16 @@ func (s *assetService) GetBondByField(ctx context.Context, fieldName string, fie
 	return nil, err
 }
 
+// Получение графика купонов облигации
+func (s *assetService) GetBondCoupons(ctx context.Context, instrumentUid string, refresh bool) ([]domain.BondCoupon, error) {
+	return s.tinkoffStorage.GetBondCoupons(ctx, instrumentUid, refresh)
+}
+
+// Получение событий облигации (оферты, погашения, амортизации)
+func (s *assetService) GetBondEvents(ctx context.Context, instrumentUid string, refresh bool) ([]domain.BondEvent, error) {
+	return s.tinkoffStorage.GetBondEvents(ctx, instrumentUid, refresh)
+}
+
 // Получение акций
 func (s *assetService) GetShares(ctx context.Context, page, limit int) ([]domain.Share, int64, error) {
 	return services.GetWithPagination(ctx, s.tinkoffStorage.GetShares, page, limit)
@@ -98,6 +111,11 @@ func (s *assetService) GetShareByField(ctx context.Context, fieldName string, fi
 	return nil, err
 }
 
+// Получение дивидендов акции или фонда
+func (s *assetService) GetDividends(ctx context.Context, instrumentUid string, refresh bool) ([]domain.Dividend, error) {
+	return s.tinkoffStorage.GetDividends(ctx, instrumentUid, refresh)
+}
+
 // Получение фондов
 func (s *assetService) GetEtfs(ctx context.Context, page, limit int) ([]domain.Etf, int64, error) {
 	return services.GetWithPagination(ctx, s.tinkoffStorage.GetEtfs, page, limit)
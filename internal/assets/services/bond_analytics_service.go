@@ -0,0 +1,250 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"invest-mate/internal/assets/models/domain"
+	"invest-mate/internal/assets/storage"
+	"invest-mate/pkg/finance"
+	"invest-mate/pkg/logger"
+	"invest-mate/pkg/services"
+)
+
+// Наибольшее число облигаций на странице списка с доходностями
+const MaxBondAnalyticsPage = 50
+
+var (
+	ErrBondPriceUnavailable = errors.New("Нет цены облигации")
+	ErrInvalidBondPrice     = errors.New("Цена облигации должна быть положительной")
+)
+
+type BondAnalyticsService interface {
+	GetBondAnalytics(ctx context.Context, instrumentUid string, pricePercent *float64) (*domain.BondAnalytics, error)
+	GetBondsWithAnalytics(ctx context.Context, page, limit int) ([]domain.BondWithAnalytics, int64, error)
+	GetBondsAnalytics(ctx context.Context, instrumentUids []string) (map[string]*domain.BondAnalytics, error)
+}
+
+type bondAnalyticsService struct {
+	tinkoffStorage *storage.TinkoffStorage
+}
+
+// Создание нового сервиса аналитики облигаций
+func NewBondAnalyticsService(tinkoffStorage *storage.TinkoffStorage) BondAnalyticsService {
+	return &bondAnalyticsService{tinkoffStorage: tinkoffStorage}
+}
+
+// Расчёт доходности облигации по цене в процентах номинала (по умолчанию — последней цене)
+func (s *bondAnalyticsService) GetBondAnalytics(ctx context.Context, instrumentUid string, pricePercent *float64) (*domain.BondAnalytics, error) {
+	if pricePercent != nil && *pricePercent <= 0 {
+		return nil, ErrInvalidBondPrice
+	}
+
+	bond, err := s.tinkoffStorage.GetBond(ctx, instrumentUid)
+	if err != nil {
+		return nil, err
+	}
+
+	price := 0.0
+	if pricePercent != nil {
+		price = *pricePercent
+	} else {
+		lastPrices, err := s.tinkoffStorage.GetLastPrices(ctx, []string{instrumentUid})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrBondPriceUnavailable, err)
+		}
+		price = lastPrices[instrumentUid]
+	}
+
+	if err := checkBondPrice(bond, price); err != nil {
+		return nil, err
+	}
+
+	coupons, err := s.tinkoffStorage.GetBondCoupons(ctx, bond.Uid, false)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.tinkoffStorage.GetBondEvents(ctx, bond.Uid, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return analyzeBond(bond, price, coupons, events, settlementDate()), nil
+}
+
+// Страница облигаций с доходностями по последним ценам
+func (s *bondAnalyticsService) GetBondsWithAnalytics(ctx context.Context, page, limit int) ([]domain.BondWithAnalytics, int64, error) {
+	if limit <= 0 || limit > MaxBondAnalyticsPage {
+		limit = MaxBondAnalyticsPage
+	}
+
+	bonds, total, err := services.GetWithPagination(ctx, s.tinkoffStorage.GetBonds, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	analytics := s.analyzeBonds(ctx, bonds)
+
+	result := make([]domain.BondWithAnalytics, len(bonds))
+	for i := range bonds {
+		result[i].Bond = bonds[i]
+		result[i].Analytics = analytics[bonds[i].Uid]
+	}
+
+	return result, total, nil
+}
+
+// Доходности набора облигаций по последним ценам. Облигации без цены
+// или графика выплат в результат не попадают
+func (s *bondAnalyticsService) GetBondsAnalytics(ctx context.Context, instrumentUids []string) (map[string]*domain.BondAnalytics, error) {
+	all, err := s.tinkoffStorage.GetBonds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool, len(instrumentUids))
+	for _, uid := range instrumentUids {
+		wanted[uid] = true
+	}
+
+	bonds := make([]domain.Bond, 0, len(instrumentUids))
+	for _, bond := range all {
+		if wanted[bond.Uid] {
+			bonds = append(bonds, bond)
+		}
+	}
+
+	return s.analyzeBonds(ctx, bonds), nil
+}
+
+// Расчёт доходностей набора облигаций: последние цены запрашиваются одним вызовом,
+// графики выплат читаются из БД одним запросом на вид и только для облигаций с ценой
+func (s *bondAnalyticsService) analyzeBonds(ctx context.Context, bonds []domain.Bond) map[string]*domain.BondAnalytics {
+	result := make(map[string]*domain.BondAnalytics, len(bonds))
+	if len(bonds) == 0 {
+		return result
+	}
+
+	uids := make([]string, 0, len(bonds))
+	for _, bond := range bonds {
+		uids = append(uids, bond.Uid)
+	}
+
+	lastPrices, err := s.tinkoffStorage.GetLastPrices(ctx, uids)
+	if err != nil {
+		// Без цен облигации отдаются без доходностей
+		logger.ErrorLog("Failed to load last prices for bond analytics: %v", err)
+		return result
+	}
+
+	priced := make([]domain.Bond, 0, len(bonds))
+	for _, bond := range bonds {
+		if checkBondPrice(&bond, lastPrices[bond.Uid]) == nil {
+			priced = append(priced, bond)
+		}
+	}
+
+	coupons, events, err := s.tinkoffStorage.GetBondSchedules(ctx, priced)
+	if err != nil {
+		logger.ErrorLog("Failed to load bond schedules for analytics: %v", err)
+		return result
+	}
+
+	settlement := settlementDate()
+
+	for i := range priced {
+		bond := &priced[i]
+
+		bondCoupons, hasCoupons := coupons[bond.Uid]
+		bondEvents, hasEvents := events[bond.Uid]
+		if !hasCoupons || !hasEvents {
+			continue
+		}
+
+		result[bond.Uid] = analyzeBond(bond, lastPrices[bond.Uid], bondCoupons, bondEvents, settlement)
+	}
+
+	return result
+}
+
+// Проверка, что по цене и номиналу можно рассчитать доходность
+func checkBondPrice(bond *domain.Bond, pricePercent float64) error {
+	if pricePercent <= 0 || bond.Nominal <= 0 {
+		return ErrBondPriceUnavailable
+	}
+
+	return nil
+}
+
+// Дата расчётов: начало текущего дня по UTC
+func settlementDate() time.Time {
+	year, month, day := time.Now().UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// Расчёт доходностей облигации по чистой цене в процентах номинала и графику выплат на дату расчётов
+func analyzeBond(bond *domain.Bond, pricePercent float64, coupons []domain.BondCoupon, events []domain.BondEvent, settlement time.Time) *domain.BondAnalytics {
+	flows := buildBondCashFlows(bond, coupons, events, settlement)
+
+	analytics := &domain.BondAnalytics{
+		InstrumentUid:    bond.Uid,
+		Ticker:           bond.Ticker,
+		Currency:         bond.Currency,
+		SettlementDate:   settlement,
+		Nominal:          bond.Nominal,
+		PricePercent:     pricePercent,
+		CleanPrice:       pricePercent * bond.Nominal / 100,
+		AccruedInterest:  flows.accrued,
+		EstimatedCoupons: flows.estimated,
+		MaturityDate:     flows.maturity,
+	}
+
+	analytics.DirtyPrice = analytics.CleanPrice + analytics.AccruedInterest
+
+	if annual := flows.annualCoupon(bond.CouponQuantityPerYear); annual > 0 {
+		currentYield := annual / analytics.CleanPrice * 100
+		analytics.CurrentYield = &currentYield
+	}
+
+	if flows.maturity != nil {
+		maturityFlows := flows.until(*flows.maturity, 100)
+		analytics.YieldToMaturity = yieldPercent(settlement, analytics.DirtyPrice, maturityFlows)
+		setDuration(analytics, domain.BondHorizonMaturity, maturityFlows, analytics.YieldToMaturity)
+	}
+
+	if flows.offer != nil {
+		redemption := flows.offer.Value
+		if redemption <= 0 {
+			redemption = 100
+		}
+
+		offerDate := flows.offer.EventDate
+		offerFlows := flows.until(offerDate, redemption)
+
+		analytics.OfferDate = &offerDate
+		analytics.YieldToOffer = yieldPercent(settlement, analytics.DirtyPrice, offerFlows)
+		setDuration(analytics, domain.BondHorizonOffer, offerFlows, analytics.YieldToOffer)
+	}
+
+	return analytics
+}
+
+// Дюрация и выпуклость потоков до горизонта по доходности к нему
+func setDuration(analytics *domain.BondAnalytics, horizon string, flows []finance.Flow, yield *float64) {
+	if yield == nil {
+		return
+	}
+
+	macaulay, modified, convexity, ok := finance.Duration(flows, analytics.SettlementDate, *yield/100)
+	if !ok {
+		return
+	}
+
+	analytics.DurationHorizon = horizon
+	analytics.MacaulayDuration = &macaulay
+	analytics.ModifiedDuration = &modified
+	analytics.Convexity = &convexity
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"testing"
+
+	"invest-mate/internal/assets/models/domain"
+)
+
+func TestAnalyzeBondDuration(t *testing.T) {
+	// Приведённые по ставке 5% выплаты облигаций, торгующихся по номиналу
+	bulletCoupon, bulletRedemption := 50/1.05, 1050/(1.05*1.05)
+	amortizingFirst, amortizingSecond := 550/1.05, 525/(1.05*1.05)
+
+	tests := []struct {
+		name      string
+		fixture   func() (*domain.Bond, []domain.BondCoupon, []domain.BondEvent)
+		horizon   string
+		macaulay  float64
+		modified  float64
+		convexity float64
+	}{
+		{
+			name:      "bullet bond to maturity",
+			fixture:   bulletBond,
+			horizon:   domain.BondHorizonMaturity,
+			macaulay:  (bulletCoupon + 2*bulletRedemption) / 1000,
+			modified:  (bulletCoupon + 2*bulletRedemption) / 1000 / 1.05,
+			convexity: (2*bulletCoupon + 6*bulletRedemption) / (1000 * 1.05 * 1.05),
+		},
+		{
+			// Амортизация сокращает дюрацию относительно облигации без неё
+			name:      "amortizing bond to maturity",
+			fixture:   amortizingBond,
+			horizon:   domain.BondHorizonMaturity,
+			macaulay:  (amortizingFirst + 2*amortizingSecond) / 1000,
+			modified:  (amortizingFirst + 2*amortizingSecond) / 1000 / 1.05,
+			convexity: (2*amortizingFirst + 6*amortizingSecond) / (1000 * 1.05 * 1.05),
+		},
+		{
+			// Все выплаты до оферты приходятся на одну дату через год
+			name:      "callable bond to the offer",
+			fixture:   callableBond,
+			horizon:   domain.BondHorizonOffer,
+			macaulay:  1,
+			modified:  1 / 1.07,
+			convexity: 2 / (1.07 * 1.07),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bond, coupons, events := tt.fixture()
+			analytics := analyzeBond(bond, 100, coupons, events, settlement)
+
+			if analytics.DurationHorizon != tt.horizon {
+				t.Errorf("horizon = %q, want %q", analytics.DurationHorizon, tt.horizon)
+			}
+			assertPercent(t, "macaulay", analytics.MacaulayDuration, tt.macaulay)
+			assertPercent(t, "modified", analytics.ModifiedDuration, tt.modified)
+			assertPercent(t, "convexity", analytics.Convexity, tt.convexity)
+		})
+	}
+}
+
+func TestAnalyzeBondWithoutSchedule(t *testing.T) {
+	// Бессрочная облигация без графика: ни доходности к погашению, ни дюрации
+	bond := &domain.Bond{Uid: "perpetual", Nominal: 1000, PerpetualFlag: true, MaturityDate: "2027-01-01T00:00:00Z"}
+	analytics := analyzeBond(bond, 100, nil, nil, settlement)
+
+	if analytics.YieldToMaturity != nil || analytics.MacaulayDuration != nil || analytics.DurationHorizon != "" {
+		t.Errorf("analytics = %+v, want no yield and duration", analytics)
+	}
+}
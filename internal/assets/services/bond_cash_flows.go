@@ -0,0 +1,174 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"invest-mate/internal/assets/models/domain"
+	"invest-mate/pkg/finance"
+)
+
+// Будущие выплаты по одной облигации после даты расчётов
+type bondCashFlows struct {
+	settlement time.Time
+	nominal    float64
+	// Купоны и амортизации по датам
+	coupons       []finance.Flow
+	amortizations []finance.Flow
+	// Накопленный купонный доход на дату расчётов
+	accrued    float64
+	nextCoupon float64
+	estimated  bool
+
+	maturity *time.Time
+	offer    *domain.BondEvent
+}
+
+// Построение графика будущих выплат по купонам, амортизациям и событиям облигации
+func buildBondCashFlows(bond *domain.Bond, coupons []domain.BondCoupon, events []domain.BondEvent, settlement time.Time) *bondCashFlows {
+	flows := &bondCashFlows{
+		settlement:    settlement,
+		nominal:       bond.Nominal,
+		coupons:       make([]finance.Flow, 0),
+		amortizations: make([]finance.Flow, 0),
+		accrued:       bond.AciValue,
+	}
+
+	sorted := append([]domain.BondCoupon(nil), coupons...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].CouponDate.Before(sorted[j].CouponDate)
+	})
+
+	lastKnown := 0.0
+	var previousDate *time.Time
+
+	for i := range sorted {
+		coupon := sorted[i]
+
+		amount := coupon.PayOneBond
+		if amount > 0 {
+			lastKnown = amount
+		} else if coupon.CouponDate.After(settlement) && lastKnown > 0 {
+			amount = lastKnown
+			flows.estimated = true
+		}
+
+		if coupon.CouponDate.After(settlement) {
+			if len(flows.coupons) == 0 {
+				flows.nextCoupon = amount
+				flows.accrued = accruedInterest(coupon, amount, previousDate, settlement, bond.AciValue)
+			}
+
+			if amount > 0 {
+				flows.coupons = append(flows.coupons, finance.Flow{Date: coupon.CouponDate, Amount: amount})
+			}
+		}
+
+		previousDate = &sorted[i].CouponDate
+	}
+
+	for i := range events {
+		event := events[i]
+
+		switch {
+		case event.EventType == domain.BondEventTypeAmortization && event.EventDate.After(settlement) && event.PayOneBond > 0:
+			flows.amortizations = append(flows.amortizations, finance.Flow{Date: event.EventDate, Amount: event.PayOneBond})
+		case event.EventType == domain.BondEventTypeCall && event.EventDate.After(settlement):
+			if flows.offer == nil || event.EventDate.Before(flows.offer.EventDate) {
+				flows.offer = &events[i]
+			}
+		}
+	}
+
+	sort.Slice(flows.amortizations, func(i, j int) bool {
+		return flows.amortizations[i].Date.Before(flows.amortizations[j].Date)
+	})
+
+	if maturity, err := time.Parse(time.RFC3339, bond.MaturityDate); err == nil && !bond.PerpetualFlag && maturity.After(settlement) {
+		flows.maturity = &maturity
+	}
+
+	return flows
+}
+
+// НКД по текущему купонному периоду пропорционально прошедшим дням
+func accruedInterest(coupon domain.BondCoupon, amount float64, previousDate *time.Time, settlement time.Time, fallback float64) float64 {
+	start := coupon.CouponStartDate
+	if start == nil {
+		start = previousDate
+	}
+
+	if start == nil || amount <= 0 || !coupon.CouponDate.After(*start) || settlement.Before(*start) {
+		return fallback
+	}
+
+	return amount * settlement.Sub(*start).Hours() / coupon.CouponDate.Sub(*start).Hours()
+}
+
+// Выплаты до даты погашения (или оферты) включительно с возвратом остатка номинала.
+// redemptionPercent — цена выкупа в процентах номинала
+func (f *bondCashFlows) until(horizon time.Time, redemptionPercent float64) []finance.Flow {
+	result := make([]finance.Flow, 0, len(f.coupons)+len(f.amortizations)+1)
+	outstanding := f.nominal
+
+	for _, flow := range f.coupons {
+		if !flow.Date.After(horizon) {
+			result = append(result, flow)
+		}
+	}
+
+	for _, flow := range f.amortizations {
+		if !flow.Date.After(horizon) {
+			result = append(result, flow)
+			outstanding -= flow.Amount
+		}
+	}
+
+	if outstanding > 0 {
+		result = append(result, finance.Flow{Date: horizon, Amount: outstanding * redemptionPercent / 100})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Date.Before(result[j].Date)
+	})
+
+	return result
+}
+
+// Годовой купонный доход по ближайшему купону и числу выплат в год
+func (f *bondCashFlows) annualCoupon(couponsPerYear int) float64 {
+	if couponsPerYear > 0 {
+		return f.nextCoupon * float64(couponsPerYear)
+	}
+
+	total := 0.0
+	yearLater := f.settlement.AddDate(1, 0, 0)
+
+	for _, flow := range f.coupons {
+		if !flow.Date.After(yearLater) {
+			total += flow.Amount
+		}
+	}
+
+	return total
+}
+
+// Эффективная доходность выплат при покупке по полной цене, в процентах
+func yieldPercent(settlement time.Time, dirtyPrice float64, flows []finance.Flow) *float64 {
+	if dirtyPrice <= 0 || len(flows) == 0 {
+		return nil
+	}
+
+	all := make([]finance.Flow, 0, len(flows)+1)
+	all = append(all, finance.Flow{Date: settlement, Amount: -dirtyPrice})
+	all = append(all, flows...)
+
+	rate, err := finance.Xirr(all)
+	if err != nil {
+		return nil
+	}
+
+	rate *= 100
+
+	return &rate
+}
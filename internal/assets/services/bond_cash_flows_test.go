@@ -0,0 +1,279 @@
+package services
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"invest-mate/internal/assets/models/domain"
+	"invest-mate/pkg/finance"
+)
+
+const floatTolerance = 1e-6
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func assertClose(t *testing.T, name string, got, want float64) {
+	t.Helper()
+
+	if math.Abs(got-want) > floatTolerance {
+		t.Errorf("%s = %v, want %v", name, got, want)
+	}
+}
+
+func assertPercent(t *testing.T, name string, got *float64, want float64) {
+	t.Helper()
+
+	if got == nil {
+		t.Fatalf("%s is not calculated", name)
+	}
+	assertClose(t, name, *got, want)
+}
+
+func coupon(couponDate time.Time, amount float64) domain.BondCoupon {
+	return domain.BondCoupon{InstrumentUid: "bond", CouponDate: couponDate, PayOneBond: amount}
+}
+
+// Дата расчётов в фикстурах; между соседними 1 января ровно 365 дней
+var settlement = date(2025, 1, 1)
+
+// Облигация без амортизации: номинал 1000, купон 50 раз в год, погашение через два года
+func bulletBond() (*domain.Bond, []domain.BondCoupon, []domain.BondEvent) {
+	bond := &domain.Bond{Uid: "bullet", Ticker: "BULLET", Nominal: 1000, CouponQuantityPerYear: 1, MaturityDate: "2027-01-01T00:00:00Z"}
+	coupons := []domain.BondCoupon{
+		coupon(date(2027, 1, 1), 50),
+		coupon(date(2025, 1, 1), 50),
+		coupon(date(2026, 1, 1), 50),
+	}
+	return bond, coupons, []domain.BondEvent{}
+}
+
+// Облигация с амортизацией: половина номинала гасится через год, купон 5% на остаток
+func amortizingBond() (*domain.Bond, []domain.BondCoupon, []domain.BondEvent) {
+	bond := &domain.Bond{Uid: "amortizing", Ticker: "AMORT", Nominal: 1000, AmortizationFlag: true, MaturityDate: "2027-01-01T00:00:00Z"}
+	coupons := []domain.BondCoupon{
+		coupon(date(2025, 1, 1), 50),
+		coupon(date(2026, 1, 1), 50),
+		coupon(date(2027, 1, 1), 25),
+	}
+	events := []domain.BondEvent{
+		{EventType: domain.BondEventTypeAmortization, EventDate: date(2026, 1, 1), PayOneBond: 500},
+		{EventType: domain.BondEventTypeAmortization, EventDate: date(2024, 1, 1), PayOneBond: 100},
+		{EventType: domain.BondEventTypeMaturity, EventDate: date(2027, 1, 1), PayOneBond: 500},
+	}
+	return bond, coupons, events
+}
+
+// Облигация с офертой через год по 101% номинала; последний купон ещё не объявлен
+func callableBond() (*domain.Bond, []domain.BondCoupon, []domain.BondEvent) {
+	bond := &domain.Bond{Uid: "callable", Ticker: "CALL", Nominal: 1000, CouponQuantityPerYear: 1, MaturityDate: "2028-01-01T00:00:00Z"}
+	coupons := []domain.BondCoupon{
+		coupon(date(2025, 1, 1), 60),
+		coupon(date(2026, 1, 1), 60),
+		coupon(date(2027, 1, 1), 60),
+		coupon(date(2028, 1, 1), 0),
+	}
+	events := []domain.BondEvent{
+		{EventType: domain.BondEventTypeCall, EventDate: date(2027, 1, 1), Value: 100},
+		{EventType: domain.BondEventTypeCall, EventDate: date(2026, 1, 1), Value: 101},
+		{EventType: domain.BondEventTypeCall, EventDate: date(2024, 1, 1), Value: 100},
+	}
+	return bond, coupons, events
+}
+
+func assertFlows(t *testing.T, got []finance.Flow, want []finance.Flow) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("flows = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if !got[i].Date.Equal(want[i].Date) || math.Abs(got[i].Amount-want[i].Amount) > floatTolerance {
+			t.Errorf("flow %d = %v %v, want %v %v", i, got[i].Date.Format(time.DateOnly), got[i].Amount, want[i].Date.Format(time.DateOnly), want[i].Amount)
+		}
+	}
+}
+
+func TestBuildBondCashFlows(t *testing.T) {
+	tests := []struct {
+		name      string
+		fixture   func() (*domain.Bond, []domain.BondCoupon, []domain.BondEvent)
+		horizon   time.Time
+		redeem    float64
+		want      []finance.Flow
+		estimated bool
+		offer     *time.Time
+	}{
+		{
+			name:    "bullet bond repays nominal at maturity",
+			fixture: bulletBond,
+			horizon: date(2027, 1, 1),
+			redeem:  100,
+			want: []finance.Flow{
+				{Date: date(2026, 1, 1), Amount: 50},
+				{Date: date(2027, 1, 1), Amount: 50},
+				{Date: date(2027, 1, 1), Amount: 1000},
+			},
+		},
+		{
+			// Прошедшая амортизация не учитывается, остаток номинала после будущей — 500
+			name:    "amortizing bond repays the outstanding nominal",
+			fixture: amortizingBond,
+			horizon: date(2027, 1, 1),
+			redeem:  100,
+			want: []finance.Flow{
+				{Date: date(2026, 1, 1), Amount: 50},
+				{Date: date(2026, 1, 1), Amount: 500},
+				{Date: date(2027, 1, 1), Amount: 25},
+				{Date: date(2027, 1, 1), Amount: 500},
+			},
+		},
+		{
+			// Ближайшая будущая оферта, выкуп по цене оферты
+			name:    "callable bond is redeemed at the offer price",
+			fixture: callableBond,
+			horizon: date(2026, 1, 1),
+			redeem:  101,
+			want: []finance.Flow{
+				{Date: date(2026, 1, 1), Amount: 60},
+				{Date: date(2026, 1, 1), Amount: 1010},
+			},
+			estimated: true,
+			offer:     ptr(date(2026, 1, 1)),
+		},
+		{
+			// Необъявленный купон принимается равным последнему известному
+			name:    "unknown coupon is extrapolated from the last known",
+			fixture: callableBond,
+			horizon: date(2028, 1, 1),
+			redeem:  100,
+			want: []finance.Flow{
+				{Date: date(2026, 1, 1), Amount: 60},
+				{Date: date(2027, 1, 1), Amount: 60},
+				{Date: date(2028, 1, 1), Amount: 60},
+				{Date: date(2028, 1, 1), Amount: 1000},
+			},
+			estimated: true,
+			offer:     ptr(date(2026, 1, 1)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bond, coupons, events := tt.fixture()
+			flows := buildBondCashFlows(bond, coupons, events, settlement)
+
+			assertFlows(t, flows.until(tt.horizon, tt.redeem), tt.want)
+
+			if flows.estimated != tt.estimated {
+				t.Errorf("estimated = %v, want %v", flows.estimated, tt.estimated)
+			}
+
+			switch {
+			case tt.offer == nil && flows.offer != nil:
+				t.Errorf("offer = %v, want none", flows.offer.EventDate)
+			case tt.offer != nil && (flows.offer == nil || !flows.offer.EventDate.Equal(*tt.offer)):
+				t.Errorf("offer = %+v, want %v", flows.offer, *tt.offer)
+			}
+
+			if flows.maturity == nil || !flows.maturity.Equal(mustParse(bond.MaturityDate)) {
+				t.Errorf("maturity = %v, want %s", flows.maturity, bond.MaturityDate)
+			}
+		})
+	}
+}
+
+func TestBuildBondCashFlowsAccruedInterest(t *testing.T) {
+	bond, coupons, events := bulletBond()
+	bond.AciValue = 7
+
+	// 181 день из 365 текущего купонного периода
+	flows := buildBondCashFlows(bond, coupons, events, date(2025, 7, 1))
+	assertClose(t, "accrued", flows.accrued, 50.0*181/365)
+	assertClose(t, "next coupon", flows.nextCoupon, 50)
+	assertClose(t, "annual coupon", flows.annualCoupon(bond.CouponQuantityPerYear), 50)
+
+	// Без начала купонного периода используется НКД из справочника
+	flows = buildBondCashFlows(bond, coupons[:1], events, date(2025, 7, 1))
+	assertClose(t, "fallback accrued", flows.accrued, 7)
+}
+
+func TestAnalyzeBondYields(t *testing.T) {
+	tests := []struct {
+		name         string
+		fixture      func() (*domain.Bond, []domain.BondCoupon, []domain.BondEvent)
+		pricePercent float64
+		maturity     float64
+		offer        *float64
+		current      *float64
+	}{
+		{
+			name:         "bullet bond at par yields its coupon",
+			fixture:      bulletBond,
+			pricePercent: 100,
+			maturity:     5,
+			current:      ptr(5.0),
+		},
+		{
+			// 1000 = 550 / (1 + y) + 525 / (1 + y)^2 при y = 5%
+			name:         "amortizing bond at par yields its coupon",
+			fixture:      amortizingBond,
+			pricePercent: 100,
+			maturity:     5,
+			// Без числа выплат в год текущая доходность считается по купонам следующего года
+			current: ptr(5.0),
+		},
+		{
+			// 1000 = 1070 / (1 + y) при y = 7%
+			name:         "callable bond yields to the offer",
+			fixture:      callableBond,
+			pricePercent: 100,
+			maturity:     6,
+			offer:        ptr(7.0),
+			current:      ptr(6.0),
+		},
+		{
+			// 960 = 50 / (1 + y) + 1050 / (1 + y)^2, положительный корень квадратного уравнения
+			name:         "bullet bond below par",
+			fixture:      bulletBond,
+			pricePercent: 96,
+			maturity:     (50+math.Sqrt(50*50+4*960*1050))/(2*960)*100 - 100,
+			current:      ptr(50.0 / 960 * 100),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bond, coupons, events := tt.fixture()
+			analytics := analyzeBond(bond, tt.pricePercent, coupons, events, settlement)
+
+			assertClose(t, "dirty price", analytics.DirtyPrice, tt.pricePercent*10)
+			assertPercent(t, "yield to maturity", analytics.YieldToMaturity, tt.maturity)
+
+			if tt.offer == nil && analytics.YieldToOffer != nil {
+				t.Errorf("yield to offer = %v, want none", *analytics.YieldToOffer)
+			}
+			if tt.offer != nil {
+				assertPercent(t, "yield to offer", analytics.YieldToOffer, *tt.offer)
+			}
+
+			if tt.current != nil {
+				assertPercent(t, "current yield", analytics.CurrentYield, *tt.current)
+			}
+		})
+	}
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+func mustParse(value string) time.Time {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
@@ -0,0 +1,244 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"invest-mate/internal/assets/api"
+	"invest-mate/internal/assets/mappers/schedule"
+	"invest-mate/internal/assets/models/domain"
+	"invest-mate/internal/assets/models/entity"
+	"invest-mate/pkg/logger"
+)
+
+const (
+	// Срок, после которого график выплат запрашивается заново
+	scheduleTTL = 24 * time.Hour
+	// Горизонт запроса для облигаций без даты погашения
+	bondScheduleHorizon = 50
+)
+
+var ErrBondNotFound = errors.New("Облигация не найдена")
+
+// Получение графика купонов облигации (из БД, при устаревании — из API)
+func (ts *TinkoffStorage) GetBondCoupons(ctx context.Context, instrumentUid string, refresh bool) ([]domain.BondCoupon, error) {
+	bond, err := ts.GetBond(ctx, instrumentUid)
+	if err != nil {
+		return nil, err
+	}
+
+	var stored []entity.BondCoupon
+
+	if ts.repo != nil {
+		stored, err = ts.repo.GetBondCoupons(ctx, instrumentUid)
+		if err != nil {
+			return nil, err
+		}
+
+		fetchedAt, err := ts.repo.GetScheduleFetches(ctx, entity.ScheduleKindCoupons, []string{instrumentUid})
+		if err != nil {
+			return nil, err
+		}
+
+		if !refresh && scheduleFresh(fetchedAt[instrumentUid]) {
+			return schedule.FromCouponEntityToDomainSlice(stored), nil
+		}
+	}
+
+	return ts.fetchBondCoupons(ctx, bond, schedule.FromCouponEntityToDomainSlice(stored))
+}
+
+// Получение событий облигации (из БД, при устаревании — из API)
+func (ts *TinkoffStorage) GetBondEvents(ctx context.Context, instrumentUid string, refresh bool) ([]domain.BondEvent, error) {
+	bond, err := ts.GetBond(ctx, instrumentUid)
+	if err != nil {
+		return nil, err
+	}
+
+	var stored []entity.BondEvent
+
+	if ts.repo != nil {
+		stored, err = ts.repo.GetBondEvents(ctx, instrumentUid)
+		if err != nil {
+			return nil, err
+		}
+
+		fetchedAt, err := ts.repo.GetScheduleFetches(ctx, entity.ScheduleKindEvents, []string{instrumentUid})
+		if err != nil {
+			return nil, err
+		}
+
+		if !refresh && scheduleFresh(fetchedAt[instrumentUid]) {
+			return schedule.FromEventEntityToDomainSlice(stored), nil
+		}
+	}
+
+	return ts.fetchBondEvents(ctx, bond, schedule.FromEventEntityToDomainSlice(stored))
+}
+
+// Графики купонов и событий набора облигаций. Сохранённые графики читаются из БД
+// одним запросом на каждый вид, из API запрашиваются только отсутствующие и устаревшие.
+// Облигации, график которых получить не удалось, в результат не попадают
+func (ts *TinkoffStorage) GetBondSchedules(ctx context.Context, bonds []domain.Bond) (map[string][]domain.BondCoupon, map[string][]domain.BondEvent, error) {
+	storedCoupons := make(map[string][]entity.BondCoupon, len(bonds))
+	storedEvents := make(map[string][]entity.BondEvent, len(bonds))
+	couponsFetchedAt := make(map[string]time.Time)
+	eventsFetchedAt := make(map[string]time.Time)
+
+	if ts.repo != nil {
+		uids := make([]string, 0, len(bonds))
+		for _, bond := range bonds {
+			uids = append(uids, bond.Uid)
+		}
+
+		coupons, err := ts.repo.GetBondCouponsByUids(ctx, uids)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, coupon := range coupons {
+			storedCoupons[coupon.InstrumentUid] = append(storedCoupons[coupon.InstrumentUid], coupon)
+		}
+
+		events, err := ts.repo.GetBondEventsByUids(ctx, uids)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, event := range events {
+			storedEvents[event.InstrumentUid] = append(storedEvents[event.InstrumentUid], event)
+		}
+
+		if couponsFetchedAt, err = ts.repo.GetScheduleFetches(ctx, entity.ScheduleKindCoupons, uids); err != nil {
+			return nil, nil, err
+		}
+
+		if eventsFetchedAt, err = ts.repo.GetScheduleFetches(ctx, entity.ScheduleKindEvents, uids); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	coupons := make(map[string][]domain.BondCoupon, len(bonds))
+	events := make(map[string][]domain.BondEvent, len(bonds))
+
+	for i := range bonds {
+		bond := &bonds[i]
+
+		if stored := storedCoupons[bond.Uid]; scheduleFresh(couponsFetchedAt[bond.Uid]) {
+			coupons[bond.Uid] = schedule.FromCouponEntityToDomainSlice(stored)
+		} else if fetched, err := ts.fetchBondCoupons(ctx, bond, schedule.FromCouponEntityToDomainSlice(stored)); err == nil {
+			coupons[bond.Uid] = fetched
+		} else {
+			logger.ErrorLog("Failed to load coupons of %s: %v", bond.Ticker, err)
+		}
+
+		if stored := storedEvents[bond.Uid]; scheduleFresh(eventsFetchedAt[bond.Uid]) {
+			events[bond.Uid] = schedule.FromEventEntityToDomainSlice(stored)
+		} else if fetched, err := ts.fetchBondEvents(ctx, bond, schedule.FromEventEntityToDomainSlice(stored)); err == nil {
+			events[bond.Uid] = fetched
+		} else {
+			logger.ErrorLog("Failed to load events of %s: %v", bond.Ticker, err)
+		}
+	}
+
+	return coupons, events, nil
+}
+
+// Запрос купонов из API с сохранением в БД (при ошибке — сохранённые купоны, если есть)
+func (ts *TinkoffStorage) fetchBondCoupons(ctx context.Context, bond *domain.Bond, stored []domain.BondCoupon) ([]domain.BondCoupon, error) {
+	from, to := bondSchedulePeriod(bond)
+
+	coupons, err := api.GetBondCoupons(ctx, bond.Uid, from, to)
+	if err != nil {
+		if len(stored) > 0 {
+			logger.ErrorLog("Failed to refresh coupons of %s, using stored: %v", bond.Ticker, err)
+			return stored, nil
+		}
+		return nil, err
+	}
+
+	if ts.repo != nil {
+		entities := schedule.FromCouponDomainToEntitySlice(coupons, time.Now())
+		if err := ts.repo.SaveBondCoupons(ctx, bond.Uid, entities); err != nil {
+			logger.ErrorLog("Failed to save coupons of %s: %v", bond.Ticker, err)
+		}
+	}
+
+	return coupons, nil
+}
+
+// Запрос событий из API с сохранением в БД (при ошибке — сохранённые события, если есть)
+func (ts *TinkoffStorage) fetchBondEvents(ctx context.Context, bond *domain.Bond, stored []domain.BondEvent) ([]domain.BondEvent, error) {
+	from, to := bondSchedulePeriod(bond)
+
+	events, err := api.GetBondEvents(ctx, bond.Uid, from, to)
+	if err != nil {
+		if len(stored) > 0 {
+			logger.ErrorLog("Failed to refresh events of %s, using stored: %v", bond.Ticker, err)
+			return stored, nil
+		}
+		return nil, err
+	}
+
+	markAmortizations(events, bond)
+
+	if ts.repo != nil {
+		entities := schedule.FromEventDomainToEntitySlice(events, time.Now())
+		if err := ts.repo.SaveBondEvents(ctx, bond.Uid, entities); err != nil {
+			logger.ErrorLog("Failed to save events of %s: %v", bond.Ticker, err)
+		}
+	}
+
+	return events, nil
+}
+
+// Сохранённый график (в том числе пустой) ещё не устарел
+func scheduleFresh(fetchedAt time.Time) bool {
+	return !fetchedAt.IsZero() && time.Since(fetchedAt) < scheduleTTL
+}
+
+// Поиск облигации хранилища по uid
+func (ts *TinkoffStorage) GetBond(ctx context.Context, instrumentUid string) (*domain.Bond, error) {
+	bonds, err := ts.GetBonds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range bonds {
+		if bonds[i].Uid == instrumentUid {
+			return &bonds[i], nil
+		}
+	}
+
+	return nil, ErrBondNotFound
+}
+
+// Период запроса графика: от размещения до погашения облигации
+func bondSchedulePeriod(bond *domain.Bond) (time.Time, time.Time) {
+	now := time.Now().UTC()
+
+	from := now.AddDate(-bondScheduleHorizon, 0, 0)
+	if placement, err := time.Parse(time.RFC3339, bond.PlacementDate); err == nil && placement.Year() > 1970 {
+		from = placement
+	}
+
+	to := now.AddDate(bondScheduleHorizon, 0, 0)
+	if maturity, err := time.Parse(time.RFC3339, bond.MaturityDate); err == nil && maturity.Year() > 1970 && !bond.PerpetualFlag {
+		to = maturity.AddDate(0, 0, 1)
+	}
+
+	return from, to
+}
+
+// Частичные погашения до даты погашения облигации считаются амортизацией
+func markAmortizations(events []domain.BondEvent, bond *domain.Bond) {
+	maturity, err := time.Parse(time.RFC3339, bond.MaturityDate)
+	if err != nil || !bond.AmortizationFlag {
+		return
+	}
+
+	for i := range events {
+		if events[i].EventType == domain.BondEventTypeMaturity && events[i].EventDate.Before(maturity.Truncate(24*time.Hour)) {
+			events[i].EventType = domain.BondEventTypeAmortization
+		}
+	}
+}
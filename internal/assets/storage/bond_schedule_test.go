@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"invest-mate/internal/assets/models/domain"
+)
+
+func TestMarkAmortizations(t *testing.T) {
+	date := func(year int, month time.Month, day, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	// API отдаёт частичные погашения как MATURITY; последнее погашение приходит в день погашения позже полуночи
+	events := func() []domain.BondEvent {
+		return []domain.BondEvent{
+			{EventType: domain.BondEventTypeMaturity, EventDate: date(2025, 6, 1, 0), PayOneBond: 250},
+			{EventType: domain.BondEventTypeCall, EventDate: date(2025, 12, 1, 0)},
+			{EventType: domain.BondEventTypeMaturity, EventDate: date(2026, 6, 1, 0), PayOneBond: 250},
+			{EventType: domain.BondEventTypeMaturity, EventDate: date(2027, 1, 1, 12), PayOneBond: 500},
+		}
+	}
+
+	tests := []struct {
+		name string
+		bond domain.Bond
+		want []domain.BondEventType
+	}{
+		{
+			name: "partial redemptions before maturity become amortizations",
+			bond: domain.Bond{AmortizationFlag: true, MaturityDate: "2027-01-01T07:00:00Z"},
+			want: []domain.BondEventType{
+				domain.BondEventTypeAmortization,
+				domain.BondEventTypeCall,
+				domain.BondEventTypeAmortization,
+				domain.BondEventTypeMaturity,
+			},
+		},
+		{
+			name: "bond without amortization keeps events",
+			bond: domain.Bond{MaturityDate: "2027-01-01T07:00:00Z"},
+			want: []domain.BondEventType{
+				domain.BondEventTypeMaturity,
+				domain.BondEventTypeCall,
+				domain.BondEventTypeMaturity,
+				domain.BondEventTypeMaturity,
+			},
+		},
+		{
+			name: "unknown maturity keeps events",
+			bond: domain.Bond{AmortizationFlag: true},
+			want: []domain.BondEventType{
+				domain.BondEventTypeMaturity,
+				domain.BondEventTypeCall,
+				domain.BondEventTypeMaturity,
+				domain.BondEventTypeMaturity,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := events()
+			markAmortizations(got, &tt.bond)
+
+			for i, want := range tt.want {
+				if got[i].EventType != want {
+					t.Errorf("event %d = %s, want %s", i, got[i].EventType, want)
+				}
+			}
+		})
+	}
+}
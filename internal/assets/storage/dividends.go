@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"invest-mate/internal/assets/api"
+	"invest-mate/internal/assets/mappers/schedule"
+	"invest-mate/internal/assets/models/domain"
+	"invest-mate/internal/assets/models/entity"
+	"invest-mate/pkg/logger"
+)
+
+const (
+	// Глубина истории дивидендов в годах
+	dividendsHistoryYears = 20
+	// Горизонт объявленных дивидендов в годах
+	dividendsForwardYears = 2
+)
+
+var ErrDividendPayerNotFound = errors.New("Акция или фонд не найдены")
+
+// Получение дивидендов акции или фонда (из БД, при устаревании — из API)
+func (ts *TinkoffStorage) GetDividends(ctx context.Context, instrumentUid string, refresh bool) ([]domain.Dividend, error) {
+	ticker, err := ts.findDividendPayer(ctx, instrumentUid)
+	if err != nil {
+		return nil, err
+	}
+
+	var stored []domain.Dividend
+
+	if ts.repo != nil {
+		entities, err := ts.repo.GetDividends(ctx, instrumentUid)
+		if err != nil {
+			return nil, err
+		}
+
+		fetchedAt, err := ts.repo.GetScheduleFetches(ctx, entity.ScheduleKindDividends, []string{instrumentUid})
+		if err != nil {
+			return nil, err
+		}
+
+		if !refresh && scheduleFresh(fetchedAt[instrumentUid]) {
+			return schedule.FromDividendEntityToDomainSlice(entities), nil
+		}
+
+		stored = schedule.FromDividendEntityToDomainSlice(entities)
+	}
+
+	now := time.Now().UTC()
+
+	dividends, err := api.GetDividends(ctx, instrumentUid, now.AddDate(-dividendsHistoryYears, 0, 0), now.AddDate(dividendsForwardYears, 0, 0))
+	if err != nil {
+		if len(stored) > 0 {
+			logger.ErrorLog("Failed to refresh dividends of %s, using stored: %v", ticker, err)
+			return stored, nil
+		}
+		return nil, err
+	}
+
+	if ts.repo != nil {
+		entities := schedule.FromDividendDomainToEntitySlice(dividends, time.Now())
+		if err := ts.repo.SaveDividends(ctx, instrumentUid, entities); err != nil {
+			logger.ErrorLog("Failed to save dividends of %s: %v", ticker, err)
+		}
+	}
+
+	return dividends, nil
+}
+
+// Поиск акции или фонда хранилища по uid; возвращает тикер
+func (ts *TinkoffStorage) findDividendPayer(ctx context.Context, instrumentUid string) (string, error) {
+	if err := ts.EnsureInitialized(ctx); err != nil {
+		return "", err
+	}
+
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+
+	for _, share := range ts.shares {
+		if share.Uid == instrumentUid {
+			return share.Ticker, nil
+		}
+	}
+
+	for _, etf := range ts.etfs {
+		if etf.Uid == instrumentUid {
+			return etf.Ticker, nil
+		}
+	}
+
+	return "", ErrDividendPayerNotFound
+}
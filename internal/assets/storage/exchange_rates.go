@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"strings"
+
+	"invest-mate/internal/assets/models/domain"
+)
+
+const baseRateCurrency = "RUB"
+
+// Получение курсов валют к рублю по последним ценам валютных инструментов
+func (ts *TinkoffStorage) GetExchangeRates(ctx context.Context) (map[string]float64, error) {
+	byIso, err := ts.GetRateInstruments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	uids := make([]string, 0, len(byIso))
+	for _, currency := range byIso {
+		uids = append(uids, currency.Uid)
+	}
+
+	priceByUid, err := ts.GetLastPrices(ctx, uids)
+	if err != nil {
+		return nil, err
+	}
+
+	rates := map[string]float64{baseRateCurrency: 1}
+
+	for iso, currency := range byIso {
+		price, ok := priceByUid[currency.Uid]
+		if !ok || price <= 0 {
+			continue
+		}
+
+		// Цена валюты указывается за номинал (например, за 100 единиц)
+		if currency.Nominal > 0 {
+			price /= currency.Nominal
+		}
+
+		rates[iso] = price
+	}
+
+	return rates, nil
+}
+
+// Валютные инструменты, по ценам которых считаются курсы к рублю (ключ — ISO-код валюты)
+func (ts *TinkoffStorage) GetRateInstruments(ctx context.Context) (map[string]domain.Currency, error) {
+	currencies, err := ts.GetCurrencies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return rateInstruments(currencies), nil
+}
+
+// Выбор одного инструмента с расчётами в рублях на каждую валюту (предпочтительно «завтра»)
+func rateInstruments(currencies []domain.Currency) map[string]domain.Currency {
+	result := make(map[string]domain.Currency)
+
+	for _, currency := range currencies {
+		iso := strings.ToUpper(currency.IsoCurrencyName)
+
+		if iso == "" || iso == baseRateCurrency || !strings.EqualFold(currency.Currency, baseRateCurrency) {
+			continue
+		}
+
+		current, ok := result[iso]
+		if !ok || (!isTomorrowSettlement(current) && isTomorrowSettlement(currency)) {
+			result[iso] = currency
+		}
+	}
+
+	return result
+}
+
+// Инструмент с расчётами «завтра»
+func isTomorrowSettlement(currency domain.Currency) bool {
+	return strings.Contains(strings.ToUpper(currency.Ticker), "TOM")
+}
@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"invest-mate/internal/assets/mappers/instruments"
+	"invest-mate/internal/assets/models/domain"
+)
+
+// Получение всех инструментов хранилища в общем виде
+func (ts *TinkoffStorage) GetInstruments(ctx context.Context) ([]domain.Instrument, error) {
+	if err := ts.EnsureInitialized(ctx); err != nil {
+		return nil, err
+	}
+
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+
+	result := make([]domain.Instrument, 0, len(ts.bonds)+len(ts.shares)+len(ts.etfs)+len(ts.currencies))
+	result = append(result, instruments.FromDomainSlice(ts.bonds)...)
+	result = append(result, instruments.FromDomainSlice(ts.shares)...)
+	result = append(result, instruments.FromDomainSlice(ts.etfs)...)
+	result = append(result, instruments.FromDomainSlice(ts.currencies)...)
+
+	return result, nil
+}
+
+// Поиск инструмента по полю (uid, figi, ticker, isin, positionUid)
+func (ts *TinkoffStorage) FindInstrument(ctx context.Context, fieldName string, fieldValue string) (*domain.Instrument, error) {
+	if err := ts.EnsureInitialized(ctx); err != nil {
+		return nil, err
+	}
+
+	match, err := instrumentMatcher(fieldName, fieldValue)
+	if err != nil {
+		return nil, err
+	}
+
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+
+	for _, bond := range ts.bonds {
+		if instrument := instruments.FromDomain(bond); match(instrument) {
+			return &instrument, nil
+		}
+	}
+
+	for _, share := range ts.shares {
+		if instrument := instruments.FromDomain(share); match(instrument) {
+			return &instrument, nil
+		}
+	}
+
+	for _, etf := range ts.etfs {
+		if instrument := instruments.FromDomain(etf); match(instrument) {
+			return &instrument, nil
+		}
+	}
+
+	for _, currency := range ts.currencies {
+		if instrument := instruments.FromDomain(currency); match(instrument) {
+			return &instrument, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Получение инструментов по списку идентификаторов
+func (ts *TinkoffStorage) GetInstrumentsByUids(ctx context.Context, uids []string) (map[string]domain.Instrument, error) {
+	all, err := ts.GetInstruments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]struct{}, len(uids))
+	for _, uid := range uids {
+		wanted[uid] = struct{}{}
+	}
+
+	result := make(map[string]domain.Instrument, len(uids))
+	for _, instrument := range all {
+		if _, ok := wanted[instrument.Uid]; ok {
+			result[instrument.Uid] = instrument
+		}
+	}
+
+	return result, nil
+}
+
+// Функция сравнения инструмента по полю
+func instrumentMatcher(fieldName string, fieldValue string) (func(domain.Instrument) bool, error) {
+	switch fieldName {
+	case "uid":
+		return func(i domain.Instrument) bool { return i.Uid == fieldValue }, nil
+	case "figi":
+		return func(i domain.Instrument) bool { return strings.EqualFold(i.Figi, fieldValue) }, nil
+	case "ticker":
+		return func(i domain.Instrument) bool { return strings.EqualFold(i.Ticker, fieldValue) }, nil
+	case "isin":
+		return func(i domain.Instrument) bool { return strings.EqualFold(i.Isin, fieldValue) }, nil
+	case "positionUid":
+		return func(i domain.Instrument) bool { return i.PositionUid == fieldValue }, nil
+	default:
+		return nil, fmt.Errorf("unsupported instrument field: %s", fieldName)
+	}
+}
@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"invest-mate/internal/assets/api"
+)
+
+const (
+	defaultPriceTTL = time.Minute
+	// Наибольшее число инструментов в одном запросе GetLastPrices
+	lastPricesBatchSize = 1000
+)
+
+// Цена в кэше с моментом загрузки
+type cachedPrice struct {
+	price     float64
+	fetchedAt time.Time
+}
+
+// Установка срока жизни цен в кэше
+func (ts *TinkoffStorage) SetPriceTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+
+	ts.pricesMu.Lock()
+	defer ts.pricesMu.Unlock()
+
+	ts.priceTTL = ttl
+}
+
+// Получение последних цен инструментов по uid.
+// Свежие цены берутся из кэша, остальные запрашиваются из API пакетами
+func (ts *TinkoffStorage) GetLastPrices(ctx context.Context, uids []string) (map[string]float64, error) {
+	result := make(map[string]float64, len(uids))
+	missing := make([]string, 0)
+
+	ts.pricesMu.RLock()
+	for _, uid := range uniqueUids(uids) {
+		cached, ok := ts.prices[uid]
+		if ok && time.Since(cached.fetchedAt) < ts.priceTTL {
+			result[uid] = cached.price
+			continue
+		}
+		missing = append(missing, uid)
+	}
+	ts.pricesMu.RUnlock()
+
+	fetched, err := ts.RefreshLastPrices(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	for uid, price := range fetched {
+		result[uid] = price
+	}
+
+	return result, nil
+}
+
+// Запрос последних цен из API в обход кэша с сохранением в кэш
+func (ts *TinkoffStorage) RefreshLastPrices(ctx context.Context, uids []string) (map[string]float64, error) {
+	uids = uniqueUids(uids)
+	result := make(map[string]float64, len(uids))
+
+	for start := 0; start < len(uids); start += lastPricesBatchSize {
+		end := min(start+lastPricesBatchSize, len(uids))
+
+		lastPrices, err := api.GetLastPrices(ctx, uids[start:end])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, lastPrice := range lastPrices {
+			if lastPrice.Price > 0 {
+				result[lastPrice.InstrumentUid] = lastPrice.Price
+			}
+		}
+	}
+
+	if len(result) == 0 {
+		return result, nil
+	}
+
+	fetchedAt := time.Now()
+
+	ts.pricesMu.Lock()
+	defer ts.pricesMu.Unlock()
+
+	for uid, price := range result {
+		ts.prices[uid] = cachedPrice{price: price, fetchedAt: fetchedAt}
+	}
+
+	return result, nil
+}
+
+// Список uid без повторов и пустых значений
+func uniqueUids(uids []string) []string {
+	seen := make(map[string]struct{}, len(uids))
+	result := make([]string, 0, len(uids))
+
+	for _, uid := range uids {
+		if _, ok := seen[uid]; ok || uid == "" {
+			continue
+		}
+		seen[uid] = struct{}{}
+		result = append(result, uid)
+	}
+
+	return result
+}
@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"sync"
+	"time"
 
 	"invest-mate/internal/assets/models/domain"
 	"invest-mate/internal/assets/repository"
@@ -20,6 +21,11 @@ type TinkoffStorage struct {
 	initialized bool
 	initOnce    sync.Once
 
+	// Кэш последних цен по uid
+	pricesMu sync.RWMutex
+	prices   map[string]cachedPrice
+	priceTTL time.Duration
+
 	repo repository.AssetRepository
 }
 
@@ -29,7 +35,20 @@ var (
 )
 
 func NewTinkoffStorage(repo repository.AssetRepository) *TinkoffStorage {
-	return &TinkoffStorage{repo: repo}
+	return &TinkoffStorage{
+		repo:     repo,
+		prices:   make(map[string]cachedPrice),
+		priceTTL: defaultPriceTTL,
+	}
+}
+
+// Получение общего для всех модулей экземпляра хранилища
+func GetInstance(repo repository.AssetRepository) *TinkoffStorage {
+	once.Do(func() {
+		instance = NewTinkoffStorage(repo)
+	})
+
+	return instance
 }
 
 // Получение инструментов из хранилища
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"invest-mate/internal/marketdata/mappers"
+	"invest-mate/internal/marketdata/models"
+	"invest-mate/internal/marketdata/models/domain"
+	"invest-mate/internal/marketdata/models/dto"
+	"invest-mate/internal/shared/api"
+	"invest-mate/pkg/logger"
+)
+
+const getCandlesEndpoint = "tinkoff.public.invest.api.contract.v1.MarketDataService/GetCandles"
+
+// Источник исторических свечей Tinkoff
+type CandleSource struct{}
+
+// Создание источника свечей
+func NewCandleSource() *CandleSource {
+	return &CandleSource{}
+}
+
+// Получение свечей инструмента за период (не длиннее допустимого для интервала)
+func (s *CandleSource) GetCandles(ctx context.Context, instrumentUid string, interval models.CandleInterval, from, to time.Time) ([]domain.Candle, error) {
+	return GetCandles(ctx, instrumentUid, interval, from, to)
+}
+
+// Получение свечей инструмента за период
+func GetCandles(ctx context.Context, instrumentUid string, interval models.CandleInterval, from, to time.Time) ([]domain.Candle, error) {
+	client := api.NewTinkoffClient()
+
+	body := map[string]string{
+		"instrumentId": instrumentUid,
+		"from":         from.UTC().Format(time.RFC3339),
+		"to":           to.UTC().Format(time.RFC3339),
+		"interval":     interval.ApiName(),
+	}
+
+	resp, err := client.DoRequest(ctx, "POST", getCandlesEndpoint, body)
+
+	if err != nil {
+		return nil, fmt.Errorf("request %s: %w", getCandlesEndpoint, err)
+	}
+
+	defer resp.Body.Close()
+
+	logger.InfoLog("%s API response status: %d", getCandlesEndpoint, resp.StatusCode)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.HandleAPIError(resp, getCandlesEndpoint)
+	}
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+
+	if err != nil {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+
+	var response dto.GetCandlesResponse
+
+	if err := json.Unmarshal(bodyBytes, &response); err != nil {
+		logger.ErrorLog("Failed to decode JSON for %s. Body start: %s",
+			getCandlesEndpoint, string(bodyBytes[:min(500, len(bodyBytes))]))
+
+		return nil, fmt.Errorf("decode DTO response for %s: %w", getCandlesEndpoint, err)
+	}
+
+	logger.InfoLog("Successfully parsed %d candles of %s from %s", len(response.Candles), instrumentUid, getCandlesEndpoint)
+
+	return mappers.FromCandleDtoToDomainSlice(response.Candles), nil
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"invest-mate/internal/marketdata/models"
+	"invest-mate/internal/marketdata/models/domain"
+	"invest-mate/internal/marketdata/services"
+	"invest-mate/internal/marketdata/stream"
+	"invest-mate/pkg/handlers"
+	middleware "invest-mate/pkg/middlewares"
+)
+
+type MarketDataHandler struct {
+	candlesService services.CandlesService
+	pricesService  services.PricesService
+	priceHub       *stream.PriceHub
+}
+
+// Создание нового хендлера
+func NewMarketDataHandler(candlesService services.CandlesService, pricesService services.PricesService, priceHub *stream.PriceHub) *MarketDataHandler {
+	return &MarketDataHandler{
+		candlesService: candlesService,
+		pricesService:  pricesService,
+		priceHub:       priceHub,
+	}
+}
+
+// Регистрация маршрутов
+func (h *MarketDataHandler) RegisterRoutes(router *gin.RouterGroup) {
+	marketData := router.Group("/marketdata")
+	marketData.Use(middleware.AuthMiddleware())
+	{
+		marketData.GET("/candles", h.GetCandles)
+		marketData.GET("/prices", h.GetLastPrices)
+		marketData.GET("/prices/stream", h.StreamPrices)
+	}
+}
+
+// Обработчик получения свечей инструмента (uid, figi или ticker; interval, from, to)
+func (h *MarketDataHandler) GetCandles(c *gin.Context) {
+	from, err := parseTimeQuery(c, "from")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	to, err := parseTimeQuery(c, "to")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	query := &domain.CandlesQuery{
+		InstrumentUid: c.Query("uid"),
+		Figi:          c.Query("figi"),
+		Ticker:        c.Query("ticker"),
+		Interval:      models.CandleInterval(c.Query("interval")),
+		From:          from,
+		To:            to,
+	}
+
+	series, err := h.candlesService.GetCandles(c.Request.Context(), query)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(series))
+}
+
+// Обработчик получения последних цен инструментов (uid — можно указать несколько раз)
+func (h *MarketDataHandler) GetLastPrices(c *gin.Context) {
+	prices, err := h.pricesService.GetLastPrices(c.Request.Context(), c.QueryArray("uid"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(prices))
+}
+
+// Обработчик потока цен инструментов по SSE (uid — можно указать несколько раз)
+func (h *MarketDataHandler) StreamPrices(c *gin.Context) {
+	uids := c.QueryArray("uid")
+	if err := h.pricesService.ValidateUids(c.Request.Context(), uids); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	subscription := h.priceHub.Subscribe(uids)
+	defer subscription.Close()
+
+	handlers.StreamEvents(c, subscription.Ticks(), func(c *gin.Context, tick stream.Tick) {
+		c.SSEvent("price", tick)
+	})
+}
+
+// Разбор даты из параметра запроса
+func parseTimeQuery(c *gin.Context, name string) (*time.Time, error) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return &parsed, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s must be a date (YYYY-MM-DD) or RFC3339 timestamp", models.ErrInvalidRequest, name)
+}
+
+// Ответ с ошибкой
+func respondError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, models.ErrInstrumentNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, models.ErrInvalidRequest),
+		errors.Is(err, models.ErrRangeTooLarge):
+		status = http.StatusBadRequest
+	case errors.Is(err, models.ErrMarketDataUnavailable):
+		status = http.StatusBadGateway
+	}
+
+	c.JSON(status, gin.H{"error": err.Error()})
+}
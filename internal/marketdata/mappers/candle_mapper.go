@@ -0,0 +1,100 @@
+package mappers
+
+import (
+	"strconv"
+	"time"
+
+	"invest-mate/internal/marketdata/models"
+	"invest-mate/internal/marketdata/models/domain"
+	"invest-mate/internal/marketdata/models/dto"
+	"invest-mate/internal/marketdata/models/entity"
+)
+
+func FromCandleDtoToDomain(dto dto.HistoricCandle) domain.Candle {
+	candle := domain.Candle{
+		Open:       dto.Open.ToFloat(),
+		High:       dto.High.ToFloat(),
+		Low:        dto.Low.ToFloat(),
+		Close:      dto.Close.ToFloat(),
+		IsComplete: dto.IsComplete,
+	}
+
+	if volume, err := strconv.ParseInt(dto.Volume, 10, 64); err == nil {
+		candle.Volume = volume
+	}
+
+	if parsed, err := time.Parse(time.RFC3339Nano, dto.Time); err == nil {
+		candle.Time = parsed.UTC()
+	}
+
+	return candle
+}
+
+func FromCandleDtoToDomainSlice(dtoSlice []dto.HistoricCandle) []domain.Candle {
+	domainSlice := make([]domain.Candle, 0, len(dtoSlice))
+
+	for _, dto := range dtoSlice {
+		candle := FromCandleDtoToDomain(dto)
+		if candle.Time.IsZero() {
+			continue
+		}
+		domainSlice = append(domainSlice, candle)
+	}
+
+	return domainSlice
+}
+
+func FromCandleDomainToEntity(instrumentUid string, interval models.CandleInterval, candle domain.Candle, fetchedAt time.Time) entity.Candle {
+	return entity.Candle{
+		InstrumentUid: instrumentUid,
+		Interval:      string(interval),
+		Time:          candle.Time,
+		Open:          candle.Open,
+		High:          candle.High,
+		Low:           candle.Low,
+		Close:         candle.Close,
+		Volume:        candle.Volume,
+		IsComplete:    candle.IsComplete,
+		FetchedAt:     fetchedAt,
+	}
+}
+
+func FromCandleEntityToDomain(entity entity.Candle) domain.Candle {
+	return domain.Candle{
+		Time:       entity.Time.UTC(),
+		Open:       entity.Open,
+		High:       entity.High,
+		Low:        entity.Low,
+		Close:      entity.Close,
+		Volume:     entity.Volume,
+		IsComplete: entity.IsComplete,
+	}
+}
+
+func FromCandleEntityToDomainSlice(entitySlice []entity.Candle) []domain.Candle {
+	domainSlice := make([]domain.Candle, len(entitySlice))
+
+	for index, entity := range entitySlice {
+		domainSlice[index] = FromCandleEntityToDomain(entity)
+	}
+
+	return domainSlice
+}
+
+func FromCoverageDomainToEntity(coverage *domain.CandleCoverage) entity.CandleCoverage {
+	return entity.CandleCoverage{
+		InstrumentUid: coverage.InstrumentUid,
+		Interval:      string(coverage.Interval),
+		From:          coverage.From,
+		To:            coverage.To,
+	}
+}
+
+func FromCoverageEntityToDomain(entity entity.CandleCoverage) *domain.CandleCoverage {
+	return &domain.CandleCoverage{
+		InstrumentUid: entity.InstrumentUid,
+		Interval:      models.CandleInterval(entity.Interval),
+		From:          entity.From.UTC(),
+		To:            entity.To.UTC(),
+	}
+}
@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+
+	"invest-mate/internal/marketdata/models/entity"
+)
+
+type MarketDataMigrator struct{}
+
+func NewMarketDataMigrator() *MarketDataMigrator {
+	return &MarketDataMigrator{}
+}
+
+func (m *MarketDataMigrator) Migrate(db *gorm.DB) error {
+	return db.AutoMigrate(
+		&entity.Candle{},
+		&entity.CandleCoverage{},
+	)
+}
@@ -0,0 +1,70 @@
+package models
+
+import "time"
+
+// Интервал свечей
+type CandleInterval string
+
+const (
+	CandleInterval1Min  CandleInterval = "1_MIN"
+	CandleInterval5Min  CandleInterval = "5_MIN"
+	CandleInterval15Min CandleInterval = "15_MIN"
+	CandleIntervalHour  CandleInterval = "HOUR"
+	CandleIntervalDay   CandleInterval = "DAY"
+	CandleIntervalWeek  CandleInterval = "WEEK"
+	CandleIntervalMonth CandleInterval = "MONTH"
+)
+
+const day = 24 * time.Hour
+
+// Проверка интервала
+func (i CandleInterval) IsValid() bool {
+	switch i {
+	case CandleInterval1Min, CandleInterval5Min, CandleInterval15Min,
+		CandleIntervalHour, CandleIntervalDay, CandleIntervalWeek, CandleIntervalMonth:
+		return true
+	}
+	return false
+}
+
+// Внутридневной интервал (история начинается с даты первой минутной свечи)
+func (i CandleInterval) IsIntraday() bool {
+	return i == CandleInterval1Min || i == CandleInterval5Min || i == CandleInterval15Min || i == CandleIntervalHour
+}
+
+// Название интервала в API
+func (i CandleInterval) ApiName() string {
+	return "CANDLE_INTERVAL_" + string(i)
+}
+
+// Наибольший период одного запроса GetCandles для интервала
+func (i CandleInterval) MaxRequestPeriod() time.Duration {
+	switch i {
+	case CandleIntervalHour:
+		return 7 * day
+	case CandleIntervalDay:
+		return 365 * day
+	case CandleIntervalWeek:
+		return 2 * 365 * day
+	case CandleIntervalMonth:
+		return 10 * 365 * day
+	default:
+		return day
+	}
+}
+
+// Период выборки по умолчанию
+func (i CandleInterval) DefaultPeriod() time.Duration {
+	switch i {
+	case CandleInterval1Min, CandleInterval5Min:
+		return day
+	case CandleInterval15Min:
+		return 7 * day
+	case CandleIntervalHour:
+		return 30 * day
+	case CandleIntervalDay:
+		return 365 * day
+	default:
+		return 5 * 365 * day
+	}
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"time"
+
+	"invest-mate/internal/marketdata/models"
+)
+
+// Свеча (цены — в валюте инструмента, для облигаций — в % номинала)
+type Candle struct {
+	Time       time.Time `json:"time"`
+	Open       float64   `json:"open"`
+	High       float64   `json:"high"`
+	Low        float64   `json:"low"`
+	Close      float64   `json:"close"`
+	Volume     int64     `json:"volume"`
+	IsComplete bool      `json:"isComplete"`
+}
+
+// Свечи инструмента за период
+type CandleSeries struct {
+	InstrumentUid string                `json:"instrumentUid"`
+	Ticker        string                `json:"ticker"`
+	Interval      models.CandleInterval `json:"interval"`
+	From          time.Time             `json:"from"`
+	To            time.Time             `json:"to"`
+	Candles       []Candle              `json:"candles"`
+}
+
+// Параметры запроса свечей (инструмент — по uid, figi или тикеру)
+type CandlesQuery struct {
+	InstrumentUid string
+	Figi          string
+	Ticker        string
+	Interval      models.CandleInterval
+	From          *time.Time
+	To            *time.Time
+}
+
+// Непрерывный загруженный в БД период свечей инструмента
+type CandleCoverage struct {
+	InstrumentUid string
+	Interval      models.CandleInterval
+	From          time.Time
+	To            time.Time
+}
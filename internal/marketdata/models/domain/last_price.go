@@ -0,0 +1,7 @@
+package domain
+
+// Последняя цена инструмента (для облигаций — в % номинала)
+type LastPrice struct {
+	InstrumentUid string  `json:"instrumentUid"`
+	Price         float64 `json:"price"`
+}
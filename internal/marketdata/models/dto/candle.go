@@ -0,0 +1,17 @@
+package dto
+
+import assetsDto "invest-mate/internal/assets/models/dto"
+
+type HistoricCandle struct {
+	Open       assetsDto.Quotation `json:"open"`
+	High       assetsDto.Quotation `json:"high"`
+	Low        assetsDto.Quotation `json:"low"`
+	Close      assetsDto.Quotation `json:"close"`
+	Volume     string              `json:"volume"`
+	Time       string              `json:"time"`
+	IsComplete bool                `json:"isComplete"`
+}
+
+type GetCandlesResponse struct {
+	Candles []HistoricCandle `json:"candles"`
+}
@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+type Candle struct {
+	InstrumentUid string    `gorm:"size:255;primaryKey"`
+	Interval      string    `gorm:"column:candle_interval;size:16;primaryKey"`
+	Time          time.Time `gorm:"column:opened_at;primaryKey"`
+	Open          float64   `gorm:"type:double precision"`
+	High          float64   `gorm:"type:double precision"`
+	Low           float64   `gorm:"type:double precision"`
+	Close         float64   `gorm:"type:double precision"`
+	Volume        int64
+	IsComplete    bool
+	FetchedAt     time.Time `gorm:"not null"`
+}
+
+// Загруженный период свечей инструмента
+type CandleCoverage struct {
+	InstrumentUid string    `gorm:"size:255;primaryKey"`
+	Interval      string    `gorm:"column:candle_interval;size:16;primaryKey"`
+	From          time.Time `gorm:"column:covered_from;not null"`
+	To            time.Time `gorm:"column:covered_to;not null"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime"`
+}
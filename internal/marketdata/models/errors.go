@@ -0,0 +1,12 @@
+package models
+
+import (
+	"errors"
+)
+
+var (
+	ErrInvalidRequest        = errors.New("Некорректный запрос")
+	ErrInstrumentNotFound    = errors.New("Инструмент не найден")
+	ErrRangeTooLarge         = errors.New("Слишком большой период для загрузки свечей")
+	ErrMarketDataUnavailable = errors.New("Не удалось получить рыночные данные")
+)
@@ -0,0 +1,63 @@
+package marketdata
+
+import (
+	"sync"
+
+	"gorm.io/gorm"
+
+	assetsRepository "invest-mate/internal/assets/repository"
+	"invest-mate/internal/assets/storage"
+	"invest-mate/internal/marketdata/api"
+	"invest-mate/internal/marketdata/handlers"
+	"invest-mate/internal/marketdata/migrations"
+	"invest-mate/internal/marketdata/repository"
+	"invest-mate/internal/marketdata/services"
+	"invest-mate/internal/marketdata/stream"
+	"invest-mate/internal/shared/config"
+)
+
+var (
+	candlesOnce     sync.Once
+	candlesInstance services.CandlesService
+	candlesErr      error
+)
+
+type Module struct {
+	marketDataHandler *handlers.MarketDataHandler
+	priceHub          *stream.PriceHub
+}
+
+// Инициализация модуля
+func InitModule(db *gorm.DB, cfg *config.Config) (*Module, error) {
+	candlesService, err := GetCandlesService(db)
+	if err != nil {
+		return nil, err
+	}
+
+	tinkoffStorage := storage.GetInstance(assetsRepository.NewAssetRepository(db))
+
+	pricesService := services.NewPricesService(tinkoffStorage, tinkoffStorage)
+	priceHub := stream.GetInstance(tinkoffStorage, cfg.PriceStreamInterval)
+	marketDataHandler := handlers.NewMarketDataHandler(candlesService, pricesService, priceHub)
+
+	return &Module{
+		marketDataHandler: marketDataHandler,
+		priceHub:          priceHub,
+	}, nil
+}
+
+// Получение общего для всех модулей сервиса свечей: блокировки догрузки
+// по инструменту работают только внутри одного экземпляра (таблицы создаются при первом вызове)
+func GetCandlesService(db *gorm.DB) (services.CandlesService, error) {
+	candlesOnce.Do(func() {
+		marketDataMigrator := migrations.NewMarketDataMigrator()
+		if candlesErr = marketDataMigrator.Migrate(db); candlesErr != nil {
+			return
+		}
+
+		tinkoffStorage := storage.GetInstance(assetsRepository.NewAssetRepository(db))
+		candlesInstance = services.NewCandlesService(repository.NewCandlesRepository(db), tinkoffStorage, api.NewCandleSource())
+	})
+
+	return candlesInstance, candlesErr
+}
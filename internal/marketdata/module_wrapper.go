@@ -0,0 +1,39 @@
+package marketdata
+
+import (
+	"invest-mate/internal/shared/config"
+
+	"gorm.io/gorm"
+)
+
+type ModuleWrapper struct {
+	module *Module
+}
+
+func (mw *ModuleWrapper) Initialize(db *gorm.DB, cfg *config.Config) error {
+	module, err := InitModule(db, cfg)
+
+	if err != nil {
+		return err
+	}
+
+	mw.module = module
+
+	return nil
+}
+
+func (mw *ModuleWrapper) GetHandler() interface{} {
+	if mw.module == nil {
+		return nil
+	}
+
+	return mw.module.marketDataHandler
+}
+
+func (mw *ModuleWrapper) Close() error {
+	if mw.module != nil {
+		mw.module.priceHub.Stop()
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"invest-mate/internal/marketdata/mappers"
+	"invest-mate/internal/marketdata/models"
+	"invest-mate/internal/marketdata/models/domain"
+	"invest-mate/internal/marketdata/models/entity"
+)
+
+const candlesBatchSize = 500
+
+type CandlesRepository interface {
+	GetCandles(ctx context.Context, instrumentUid string, interval models.CandleInterval, from, to time.Time) ([]domain.Candle, error)
+	SaveCandles(ctx context.Context, instrumentUid string, interval models.CandleInterval, candles []domain.Candle) error
+	GetFirstIncomplete(ctx context.Context, instrumentUid string, interval models.CandleInterval) (*time.Time, error)
+	GetCoverage(ctx context.Context, instrumentUid string, interval models.CandleInterval) (*domain.CandleCoverage, error)
+	SaveCoverage(ctx context.Context, coverage *domain.CandleCoverage) error
+}
+
+type candlesRepository struct {
+	db *gorm.DB
+}
+
+// Создание нового репозитория свечей
+func NewCandlesRepository(db *gorm.DB) CandlesRepository {
+	return &candlesRepository{db: db}
+}
+
+// Получить свечи инструмента за период в хронологическом порядке из БД
+func (r *candlesRepository) GetCandles(ctx context.Context, instrumentUid string, interval models.CandleInterval, from, to time.Time) ([]domain.Candle, error) {
+	var entities []entity.Candle
+
+	err := r.db.WithContext(ctx).
+		Where("instrument_uid = ? AND candle_interval = ? AND opened_at >= ? AND opened_at <= ?", instrumentUid, string(interval), from, to).
+		Order("opened_at").
+		Find(&entities).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return mappers.FromCandleEntityToDomainSlice(entities), nil
+}
+
+// Сохранение свечей с заменой ранее загруженных в БД
+func (r *candlesRepository) SaveCandles(ctx context.Context, instrumentUid string, interval models.CandleInterval, candles []domain.Candle) error {
+	if len(candles) == 0 {
+		return nil
+	}
+
+	fetchedAt := time.Now()
+
+	entities := make([]entity.Candle, 0, len(candles))
+	for _, candle := range candles {
+		entities = append(entities, mappers.FromCandleDomainToEntity(instrumentUid, interval, candle, fetchedAt))
+	}
+
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{UpdateAll: true}).
+		CreateInBatches(&entities, candlesBatchSize).Error
+}
+
+// Получить время самой ранней незавершённой свечи из БД
+func (r *candlesRepository) GetFirstIncomplete(ctx context.Context, instrumentUid string, interval models.CandleInterval) (*time.Time, error) {
+	var candle entity.Candle
+
+	err := r.db.WithContext(ctx).
+		Where("instrument_uid = ? AND candle_interval = ? AND is_complete = ?", instrumentUid, string(interval), false).
+		Order("opened_at").
+		First(&candle).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	moment := candle.Time.UTC()
+
+	return &moment, nil
+}
+
+// Получить загруженный период свечей из БД
+func (r *candlesRepository) GetCoverage(ctx context.Context, instrumentUid string, interval models.CandleInterval) (*domain.CandleCoverage, error) {
+	var coverage entity.CandleCoverage
+
+	err := r.db.WithContext(ctx).
+		Where("instrument_uid = ? AND candle_interval = ?", instrumentUid, string(interval)).
+		First(&coverage).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return mappers.FromCoverageEntityToDomain(coverage), nil
+}
+
+// Сохранение загруженного периода свечей в БД
+func (r *candlesRepository) SaveCoverage(ctx context.Context, coverage *domain.CandleCoverage) error {
+	entityCoverage := mappers.FromCoverageDomainToEntity(coverage)
+
+	return r.db.WithContext(ctx).Save(&entityCoverage).Error
+}
@@ -0,0 +1,294 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	assetsDomain "invest-mate/internal/assets/models/domain"
+	"invest-mate/internal/marketdata/models"
+	"invest-mate/internal/marketdata/models/domain"
+	"invest-mate/internal/marketdata/repository"
+	sharedInstruments "invest-mate/internal/shared/instruments"
+	"invest-mate/pkg/logger"
+)
+
+const (
+	// Наибольшее число запросов к API для одной выборки
+	maxBackfillChunks = 200
+	// Пауза между запросами при загрузке по частям (лимит MarketDataService — 600 запросов в минуту)
+	candleRequestDelay = 100 * time.Millisecond
+)
+
+// Поиск инструмента (реализуется хранилищем модуля активов)
+type InstrumentResolver interface {
+	FindInstrument(ctx context.Context, fieldName string, fieldValue string) (*assetsDomain.Instrument, error)
+}
+
+// Источник исторических свечей
+type CandleSource interface {
+	GetCandles(ctx context.Context, instrumentUid string, interval models.CandleInterval, from, to time.Time) ([]domain.Candle, error)
+}
+
+type CandlesService interface {
+	GetCandles(ctx context.Context, query *domain.CandlesQuery) (*domain.CandleSeries, error)
+}
+
+type candlesService struct {
+	candlesRepo repository.CandlesRepository
+	instruments InstrumentResolver
+	source      CandleSource
+
+	// Блокировки загрузки по инструменту и интервалу
+	locks sync.Map
+}
+
+// Создание нового сервиса свечей
+func NewCandlesService(
+	candlesRepo repository.CandlesRepository,
+	instruments InstrumentResolver,
+	source CandleSource,
+) CandlesService {
+	return &candlesService{
+		candlesRepo: candlesRepo,
+		instruments: instruments,
+		source:      source,
+	}
+}
+
+// Получение свечей инструмента за период.
+// Недостающие в БД свечи догружаются из API частями, незавершённые — перезапрашиваются
+func (s *candlesService) GetCandles(ctx context.Context, query *domain.CandlesQuery) (*domain.CandleSeries, error) {
+	interval := models.CandleInterval(strings.ToUpper(string(query.Interval)))
+	if interval == "" {
+		interval = models.CandleIntervalDay
+	}
+	if !interval.IsValid() {
+		return nil, fmt.Errorf("%w: interval must be 1_MIN, 5_MIN, 15_MIN, HOUR, DAY, WEEK or MONTH", models.ErrInvalidRequest)
+	}
+
+	instrument, err := sharedInstruments.Resolve(ctx, s.instruments, query.InstrumentUid, query.Figi, query.Ticker, models.ErrInvalidRequest, models.ErrInstrumentNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+
+	to := now
+	if query.To != nil && query.To.Before(now) {
+		to = query.To.UTC()
+	}
+
+	from := to.Add(-interval.DefaultPeriod())
+	if query.From != nil {
+		from = query.From.UTC()
+	}
+
+	if from.After(to) {
+		return nil, fmt.Errorf("%w: from must be before to", models.ErrInvalidRequest)
+	}
+
+	// Раньше первой свечи инструмента данных нет
+	if first, ok := firstCandleDate(instrument, interval); ok && from.Before(first) {
+		from = first
+	}
+
+	series := &domain.CandleSeries{
+		InstrumentUid: instrument.Uid,
+		Ticker:        instrument.Ticker,
+		Interval:      interval,
+		From:          from,
+		To:            to,
+		Candles:       make([]domain.Candle, 0),
+	}
+
+	if from.After(to) {
+		return series, nil
+	}
+
+	if err := s.sync(ctx, instrument.Uid, interval, from, to); err != nil {
+		return nil, err
+	}
+
+	candles, err := s.candlesRepo.GetCandles(ctx, instrument.Uid, interval, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	series.Candles = candles
+
+	return series, nil
+}
+
+// Догрузка свечей за период. В БД хранится непрерывный загруженный период:
+// он расширяется назад и вперёд, после каждой части сохраняется достигнутая граница
+func (s *candlesService) sync(ctx context.Context, instrumentUid string, interval models.CandleInterval, from, to time.Time) error {
+	lock, _ := s.locks.LoadOrStore(instrumentUid+"/"+string(interval), &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	coverage, err := s.candlesRepo.GetCoverage(ctx, instrumentUid, interval)
+	if err != nil {
+		return err
+	}
+
+	if coverage == nil {
+		if err := s.checkChunks(interval, to.Sub(from)); err != nil {
+			return err
+		}
+
+		coverage = &domain.CandleCoverage{InstrumentUid: instrumentUid, Interval: interval, From: from, To: from}
+
+		return s.fetchForward(ctx, coverage, from, to)
+	}
+
+	// Незавершённые свечи (текущий день, час) запрашиваются повторно
+	forwardFrom := coverage.To
+	if incomplete, err := s.candlesRepo.GetFirstIncomplete(ctx, instrumentUid, interval); err != nil {
+		return err
+	} else if incomplete != nil && incomplete.Before(forwardFrom) && !incomplete.After(to) {
+		forwardFrom = *incomplete
+	}
+
+	forwardTo := coverage.To
+	if to.After(forwardTo) {
+		forwardTo = to
+	}
+
+	needsBackward := from.Before(coverage.From)
+	needsForward := forwardTo.After(forwardFrom)
+
+	period := time.Duration(0)
+	if needsBackward {
+		period += coverage.From.Sub(from)
+	}
+	if needsForward {
+		period += forwardTo.Sub(forwardFrom)
+	}
+
+	if err := s.checkChunks(interval, period); err != nil {
+		return err
+	}
+
+	if needsBackward {
+		if err := s.fetchBackward(ctx, coverage, from); err != nil {
+			return err
+		}
+	}
+
+	if needsForward {
+		return s.fetchForward(ctx, coverage, forwardFrom, forwardTo)
+	}
+
+	return nil
+}
+
+// Загрузка частями от from до to с продвижением правой границы периода
+func (s *candlesService) fetchForward(ctx context.Context, coverage *domain.CandleCoverage, from, to time.Time) error {
+	step := coverage.Interval.MaxRequestPeriod()
+
+	for start, first := from, true; start.Before(to); first = false {
+		end := start.Add(step)
+		if end.After(to) {
+			end = to
+		}
+
+		if err := s.fetchChunk(ctx, coverage, start, end, first); err != nil {
+			return err
+		}
+
+		if end.After(coverage.To) {
+			coverage.To = end
+		}
+
+		if err := s.candlesRepo.SaveCoverage(ctx, coverage); err != nil {
+			return err
+		}
+
+		start = end
+	}
+
+	return nil
+}
+
+// Загрузка частями от левой границы периода назад до from
+func (s *candlesService) fetchBackward(ctx context.Context, coverage *domain.CandleCoverage, from time.Time) error {
+	step := coverage.Interval.MaxRequestPeriod()
+
+	for end, first := coverage.From, true; end.After(from); first = false {
+		start := end.Add(-step)
+		if start.Before(from) {
+			start = from
+		}
+
+		if err := s.fetchChunk(ctx, coverage, start, end, first); err != nil {
+			return err
+		}
+
+		coverage.From = start
+
+		if err := s.candlesRepo.SaveCoverage(ctx, coverage); err != nil {
+			return err
+		}
+
+		end = start
+	}
+
+	return nil
+}
+
+// Запрос одной части свечей и сохранение в БД
+func (s *candlesService) fetchChunk(ctx context.Context, coverage *domain.CandleCoverage, from, to time.Time, first bool) error {
+	if !first {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(candleRequestDelay):
+		}
+	}
+
+	candles, err := s.source.GetCandles(ctx, coverage.InstrumentUid, coverage.Interval, from, to)
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return err
+		}
+
+		logger.ErrorLog("Failed to load %s candles of %s: %v", coverage.Interval, coverage.InstrumentUid, err)
+		return models.ErrMarketDataUnavailable
+	}
+
+	return s.candlesRepo.SaveCandles(ctx, coverage.InstrumentUid, coverage.Interval, candles)
+}
+
+// Проверка числа запросов, нужных для загрузки периода
+func (s *candlesService) checkChunks(interval models.CandleInterval, period time.Duration) error {
+	step := interval.MaxRequestPeriod()
+
+	if chunks := (period + step - 1) / step; chunks > maxBackfillChunks {
+		return fmt.Errorf("%w: %d requests needed, at most %d allowed", models.ErrRangeTooLarge, chunks, maxBackfillChunks)
+	}
+
+	return nil
+}
+
+// Дата первой свечи инструмента для интервала
+func firstCandleDate(instrument *assetsDomain.Instrument, interval models.CandleInterval) (time.Time, bool) {
+	value := instrument.First1dayCandleDate
+	if interval.IsIntraday() {
+		value = instrument.First1minCandleDate
+	}
+
+	if value == "" {
+		return time.Time{}, false
+	}
+
+	parsed, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil || parsed.Year() <= 1970 {
+		return time.Time{}, false
+	}
+
+	return parsed.UTC(), true
+}
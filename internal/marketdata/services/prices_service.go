@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	assetsDomain "invest-mate/internal/assets/models/domain"
+	"invest-mate/internal/marketdata/models"
+	"invest-mate/internal/marketdata/models/domain"
+	"invest-mate/pkg/logger"
+)
+
+// Наибольшее число инструментов в одном запросе цен
+const MaxLastPricesUids = 100
+
+// Источник последних цен с кэшем (реализуется хранилищем модуля активов)
+type LastPriceSource interface {
+	GetLastPrices(ctx context.Context, uids []string) (map[string]float64, error)
+}
+
+// Справочник инструментов (реализуется хранилищем модуля активов)
+type InstrumentLookup interface {
+	GetInstrumentsByUids(ctx context.Context, uids []string) (map[string]assetsDomain.Instrument, error)
+}
+
+type PricesService interface {
+	GetLastPrices(ctx context.Context, uids []string) ([]*domain.LastPrice, error)
+	ValidateUids(ctx context.Context, uids []string) error
+}
+
+type pricesService struct {
+	prices      LastPriceSource
+	instruments InstrumentLookup
+}
+
+// Создание нового сервиса последних цен
+func NewPricesService(prices LastPriceSource, instruments InstrumentLookup) PricesService {
+	return &pricesService{prices: prices, instruments: instruments}
+}
+
+// Проверка набора uid: число инструментов в пределах лимита, все есть в справочнике.
+// Неизвестный uid отклоняется до запроса к API, иначе он срывает запрос цен всего набора
+func (s *pricesService) ValidateUids(ctx context.Context, uids []string) error {
+	if len(uids) == 0 {
+		return fmt.Errorf("%w: uid is required", models.ErrInvalidRequest)
+	}
+	if len(uids) > MaxLastPricesUids {
+		return fmt.Errorf("%w: at most %d uids allowed", models.ErrInvalidRequest, MaxLastPricesUids)
+	}
+
+	instruments, err := s.instruments.GetInstrumentsByUids(ctx, uids)
+	if err != nil {
+		logger.ErrorLog("Failed to load instruments: %v", err)
+		return models.ErrMarketDataUnavailable
+	}
+
+	for _, uid := range uids {
+		if _, ok := instruments[uid]; !ok {
+			return fmt.Errorf("%w: %s", models.ErrInstrumentNotFound, uid)
+		}
+	}
+
+	return nil
+}
+
+// Получение последних цен инструментов по uid (инструменты без цены пропускаются)
+func (s *pricesService) GetLastPrices(ctx context.Context, uids []string) ([]*domain.LastPrice, error) {
+	if err := s.ValidateUids(ctx, uids); err != nil {
+		return nil, err
+	}
+
+	prices, err := s.prices.GetLastPrices(ctx, uids)
+	if err != nil {
+		logger.ErrorLog("Failed to load last prices: %v", err)
+		return nil, models.ErrMarketDataUnavailable
+	}
+
+	result := make([]*domain.LastPrice, 0, len(prices))
+	for _, uid := range uids {
+		if price, ok := prices[uid]; ok {
+			result = append(result, &domain.LastPrice{InstrumentUid: uid, Price: price})
+			delete(prices, uid)
+		}
+	}
+
+	return result, nil
+}
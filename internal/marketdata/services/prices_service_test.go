@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	assetsDomain "invest-mate/internal/assets/models/domain"
+	"invest-mate/internal/marketdata/models"
+)
+
+// Справочник и источник цен в памяти; источник запоминает, был ли запрос
+type memoryMarket struct {
+	prices    map[string]float64
+	requested bool
+}
+
+func (m *memoryMarket) GetInstrumentsByUids(ctx context.Context, uids []string) (map[string]assetsDomain.Instrument, error) {
+	result := make(map[string]assetsDomain.Instrument)
+	for _, uid := range uids {
+		if _, ok := m.prices[uid]; ok {
+			result[uid] = assetsDomain.Instrument{Uid: uid}
+		}
+	}
+	return result, nil
+}
+
+func (m *memoryMarket) GetLastPrices(ctx context.Context, uids []string) (map[string]float64, error) {
+	m.requested = true
+
+	result := make(map[string]float64)
+	for _, uid := range uids {
+		result[uid] = m.prices[uid]
+	}
+	return result, nil
+}
+
+func TestGetLastPricesRejectsUnknownUid(t *testing.T) {
+	market := &memoryMarket{prices: map[string]float64{"sber": 300}}
+	service := NewPricesService(market, market)
+
+	_, err := service.GetLastPrices(t.Context(), []string{"sber", "bogus"})
+	if !errors.Is(err, models.ErrInstrumentNotFound) {
+		t.Fatalf("error = %v, want ErrInstrumentNotFound", err)
+	}
+	if market.requested {
+		t.Error("prices were requested for a batch with an unknown uid")
+	}
+
+	prices, err := service.GetLastPrices(t.Context(), []string{"sber"})
+	if err != nil {
+		t.Fatalf("GetLastPrices: %v", err)
+	}
+	if len(prices) != 1 || prices[0].Price != 300 {
+		t.Errorf("prices = %+v, want sber 300", prices)
+	}
+}
+
+func TestValidateUidsLimits(t *testing.T) {
+	service := NewPricesService(&memoryMarket{}, &memoryMarket{})
+
+	if err := service.ValidateUids(t.Context(), nil); !errors.Is(err, models.ErrInvalidRequest) {
+		t.Errorf("empty uids: error = %v, want ErrInvalidRequest", err)
+	}
+
+	tooMany := make([]string, MaxLastPricesUids+1)
+	if err := service.ValidateUids(t.Context(), tooMany); !errors.Is(err, models.ErrInvalidRequest) {
+		t.Errorf("too many uids: error = %v, want ErrInvalidRequest", err)
+	}
+}
@@ -0,0 +1,76 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Тестовый источник: отдаёт заданные цены без обращения к API и считает опросы
+type FakePriceSource struct {
+	mu     sync.Mutex
+	prices map[string]float64
+	failed map[string]bool
+	polls  int
+	polled chan []string
+}
+
+func NewFakePriceSource() *FakePriceSource {
+	return &FakePriceSource{
+		prices: make(map[string]float64),
+		failed: make(map[string]bool),
+		polled: make(chan []string, 64),
+	}
+}
+
+// Установка цены инструмента
+func (s *FakePriceSource) SetPrice(uid string, price float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prices[uid] = price
+}
+
+// Запрос с этим инструментом завершается ошибкой, как для неизвестного API uid
+func (s *FakePriceSource) Fail(uid string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failed[uid] = true
+}
+
+// Число выполненных опросов
+func (s *FakePriceSource) Polls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.polls
+}
+
+// Цены запрошенных инструментов, для которых они заданы
+func (s *FakePriceSource) RefreshLastPrices(ctx context.Context, uids []string) (map[string]float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.polls++
+
+	select {
+	case s.polled <- uids:
+	default:
+	}
+
+	for _, uid := range uids {
+		if s.failed[uid] {
+			return nil, fmt.Errorf("instrument %s not found", uid)
+		}
+	}
+
+	result := make(map[string]float64, len(uids))
+	for _, uid := range uids {
+		if price, ok := s.prices[uid]; ok {
+			result[uid] = price
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,308 @@
+package stream
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"invest-mate/pkg/logger"
+)
+
+const (
+	defaultPollInterval = 5 * time.Second
+	// Размер буфера подписки: при переполнении тики медленного клиента пропускаются
+	subscriptionBuffer = 256
+)
+
+// Изменение цены инструмента
+type Tick struct {
+	InstrumentUid string    `json:"instrumentUid"`
+	Price         float64   `json:"price"`
+	Time          time.Time `json:"time"`
+}
+
+// Подписка на цены набора инструментов
+type Subscription struct {
+	hub   *PriceHub
+	uids  map[string]struct{}
+	ticks chan Tick
+	once  sync.Once
+}
+
+// Канал тиков подписки (закрывается при отписке)
+func (s *Subscription) Ticks() <-chan Tick {
+	return s.ticks
+}
+
+// Отписка
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.hub.unsubscribe(s)
+	})
+}
+
+// Рассылка цен подписчикам. Источник опрашивается один раз за интервал
+// по объединению инструментов всех подписок, клиентам уходят только изменившиеся цены.
+// Опрос идёт, пока есть хотя бы одна подписка
+type PriceHub struct {
+	source   PriceSource
+	interval time.Duration
+
+	mu          sync.Mutex
+	subscribers map[*Subscription]struct{}
+	// Последние цены инструментов, на которые есть подписки
+	last map[string]Tick
+	// Сигнал внеочередного опроса; у каждого запуска опроса свой канал
+	wake   chan struct{}
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+var (
+	instance *PriceHub
+	once     sync.Once
+)
+
+// Создание хаба с источником и интервалом опроса
+func NewPriceHub(source PriceSource, interval time.Duration) *PriceHub {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	return &PriceHub{
+		source:      source,
+		interval:    interval,
+		subscribers: make(map[*Subscription]struct{}),
+		last:        make(map[string]Tick),
+	}
+}
+
+// Получение общего для всех модулей хаба
+func GetInstance(source PriceSource, interval time.Duration) *PriceHub {
+	once.Do(func() {
+		instance = NewPriceHub(source, interval)
+	})
+
+	return instance
+}
+
+// Подписка на цены инструментов; известные цены отправляются сразу
+func (h *PriceHub) Subscribe(uids []string) *Subscription {
+	subscription := &Subscription{
+		hub:   h,
+		uids:  make(map[string]struct{}, len(uids)),
+		ticks: make(chan Tick, subscriptionBuffer),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, uid := range uids {
+		subscription.uids[uid] = struct{}{}
+
+		if tick, ok := h.last[uid]; ok {
+			subscription.send(tick)
+		}
+	}
+
+	h.subscribers[subscription] = struct{}{}
+
+	if h.cancel == nil {
+		h.start()
+	}
+
+	// Цены новых инструментов запрашиваются, не дожидаясь очередного опроса
+	select {
+	case h.wake <- struct{}{}:
+	default:
+	}
+
+	return subscription
+}
+
+// Остановка хаба с закрытием всех подписок
+func (h *PriceHub) Stop() {
+	h.mu.Lock()
+	for subscription := range h.subscribers {
+		delete(h.subscribers, subscription)
+		close(subscription.ticks)
+	}
+	cancel := h.cancel
+	h.cancel = nil
+	h.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		h.wg.Wait()
+	}
+}
+
+// Удаление подписки; опрос останавливается вместе с последней подпиской
+func (h *PriceHub) unsubscribe(subscription *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.subscribers[subscription]; !ok {
+		return
+	}
+
+	delete(h.subscribers, subscription)
+	close(subscription.ticks)
+
+	// Цены инструментов без подписок устаревают и новым подписчикам не отправляются
+	for uid := range subscription.uids {
+		if !h.subscribedLocked(uid) {
+			delete(h.last, uid)
+		}
+	}
+
+	if len(h.subscribers) == 0 && h.cancel != nil {
+		h.cancel()
+		h.cancel = nil
+		h.wake = nil
+	}
+}
+
+// Запуск опроса источника (вызывается под блокировкой)
+func (h *PriceHub) start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	h.cancel = cancel
+
+	// Завершающийся прежний опрос не перехватит сигнал, адресованный новому
+	wake := make(chan struct{}, 1)
+	h.wake = wake
+
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+
+		ticker := time.NewTicker(h.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case <-wake:
+			}
+
+			if ctx.Err() != nil {
+				return
+			}
+
+			h.poll(ctx)
+		}
+	}()
+
+	logger.InfoLog("Price stream started with interval %s", h.interval)
+}
+
+// Один опрос источника и рассылка изменившихся цен
+func (h *PriceHub) poll(ctx context.Context) {
+	uids := h.subscribedUids()
+	if len(uids) == 0 {
+		return
+	}
+
+	prices, err := h.source.RefreshLastPrices(ctx, uids)
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+
+		logger.ErrorLog("Price stream poll failed: %v", err)
+		prices = h.pollEach(ctx, uids)
+	}
+
+	now := time.Now()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for uid, price := range prices {
+		// Подписка на инструмент могла закончиться во время опроса
+		if !h.subscribedLocked(uid) {
+			continue
+		}
+
+		if previous, ok := h.last[uid]; ok && previous.Price == price {
+			continue
+		}
+
+		tick := Tick{InstrumentUid: uid, Price: price, Time: now}
+		h.last[uid] = tick
+
+		for subscription := range h.subscribers {
+			if _, ok := subscription.uids[uid]; ok {
+				subscription.send(tick)
+			}
+		}
+	}
+}
+
+// Опрос инструментов по одному после ошибки общего запроса,
+// чтобы инструмент с ошибкой не останавливал поток остальных
+func (h *PriceHub) pollEach(ctx context.Context, uids []string) map[string]float64 {
+	prices := make(map[string]float64, len(uids))
+
+	if len(uids) < 2 {
+		return prices
+	}
+
+	for _, uid := range uids {
+		price, err := h.source.RefreshLastPrices(ctx, []string{uid})
+		if ctx.Err() != nil {
+			return prices
+		}
+		if err != nil {
+			logger.ErrorLog("Price stream poll of %s failed: %v", uid, err)
+			continue
+		}
+
+		for priceUid, value := range price {
+			prices[priceUid] = value
+		}
+	}
+
+	return prices
+}
+
+// Есть ли подписка на инструмент (вызывается под блокировкой)
+func (h *PriceHub) subscribedLocked(uid string) bool {
+	for subscription := range h.subscribers {
+		if _, ok := subscription.uids[uid]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Объединение инструментов всех подписок
+func (h *PriceHub) subscribedUids() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	seen := make(map[string]struct{})
+	uids := make([]string, 0)
+
+	for subscription := range h.subscribers {
+		for uid := range subscription.uids {
+			if _, ok := seen[uid]; !ok {
+				seen[uid] = struct{}{}
+				uids = append(uids, uid)
+			}
+		}
+	}
+
+	return uids
+}
+
+// Отправка тика без ожидания (вызывается под блокировкой хаба)
+func (s *Subscription) send(tick Tick) {
+	select {
+	case s.ticks <- tick:
+	default:
+	}
+}
@@ -0,0 +1,203 @@
+package stream
+
+import (
+	"testing"
+	"time"
+)
+
+const (
+	testInterval = 10 * time.Millisecond
+	testTimeout  = time.Second
+)
+
+// Ожидание тика подписки
+func nextTick(t *testing.T, subscription *Subscription) Tick {
+	t.Helper()
+
+	select {
+	case tick, ok := <-subscription.Ticks():
+		if !ok {
+			t.Fatal("subscription closed unexpectedly")
+		}
+		return tick
+	case <-time.After(testTimeout):
+		t.Fatal("no tick received")
+		return Tick{}
+	}
+}
+
+// Проверка, что за несколько опросов подписке не пришло тиков
+func expectNoTick(t *testing.T, subscription *Subscription) {
+	t.Helper()
+
+	select {
+	case tick, ok := <-subscription.Ticks():
+		if ok {
+			t.Fatalf("unexpected tick %+v", tick)
+		}
+	case <-time.After(5 * testInterval):
+	}
+}
+
+// Ожидание опроса источника с подходящим набором инструментов
+func waitForPoll(t *testing.T, source *FakePriceSource, match func(uids []string) bool) {
+	t.Helper()
+
+	deadline := time.After(testTimeout)
+
+	for {
+		select {
+		case uids := <-source.polled:
+			if match(uids) {
+				return
+			}
+		case <-deadline:
+			t.Fatal("source was not polled with the expected instruments")
+		}
+	}
+}
+
+func TestPriceHubFanOut(t *testing.T) {
+	source := NewFakePriceSource()
+	source.SetPrice("sber", 300)
+	source.SetPrice("gazp", 150)
+
+	hub := NewPriceHub(source, testInterval)
+	t.Cleanup(hub.Stop)
+
+	both := hub.Subscribe([]string{"sber", "gazp"})
+	onlySber := hub.Subscribe([]string{"sber"})
+
+	received := map[string]float64{}
+	for len(received) < 2 {
+		tick := nextTick(t, both)
+		received[tick.InstrumentUid] = tick.Price
+	}
+	if received["sber"] != 300 || received["gazp"] != 150 {
+		t.Errorf("first subscriber got %v", received)
+	}
+
+	if tick := nextTick(t, onlySber); tick.InstrumentUid != "sber" || tick.Price != 300 {
+		t.Errorf("second subscriber got %+v, want sber 300", tick)
+	}
+
+	// Неизменившаяся цена повторно не рассылается, изменившаяся уходит всем подписчикам инструмента
+	source.SetPrice("sber", 301.5)
+
+	if tick := nextTick(t, both); tick.InstrumentUid != "sber" || tick.Price != 301.5 {
+		t.Errorf("first subscriber got %+v, want sber 301.5", tick)
+	}
+	if tick := nextTick(t, onlySber); tick.InstrumentUid != "sber" || tick.Price != 301.5 {
+		t.Errorf("second subscriber got %+v, want sber 301.5", tick)
+	}
+
+	expectNoTick(t, onlySber)
+}
+
+func TestPriceHubSendsKnownPriceOnSubscribe(t *testing.T) {
+	source := NewFakePriceSource()
+	source.SetPrice("sber", 300)
+
+	hub := NewPriceHub(source, testInterval)
+	t.Cleanup(hub.Stop)
+
+	first := hub.Subscribe([]string{"sber"})
+	nextTick(t, first)
+
+	// Последняя известная цена отправляется новой подписке сразу, до очередного опроса
+	late := hub.Subscribe([]string{"sber"})
+	select {
+	case tick := <-late.Ticks():
+		if tick.Price != 300 {
+			t.Errorf("late subscriber got %+v, want 300", tick)
+		}
+	default:
+		t.Fatal("late subscriber did not get the last known price")
+	}
+}
+
+func TestPriceHubUnsubscribe(t *testing.T) {
+	source := NewFakePriceSource()
+	source.SetPrice("sber", 300)
+	source.SetPrice("gazp", 150)
+
+	hub := NewPriceHub(source, testInterval)
+	t.Cleanup(hub.Stop)
+
+	leaving := hub.Subscribe([]string{"gazp"})
+	staying := hub.Subscribe([]string{"sber"})
+
+	nextTick(t, leaving)
+	nextTick(t, staying)
+
+	leaving.Close()
+	leaving.Close()
+
+	if _, ok := <-leaving.Ticks(); ok {
+		t.Fatal("ticks channel is not closed after unsubscribe")
+	}
+
+	// Инструменты отписавшегося клиента больше не опрашиваются
+	waitForPoll(t, source, func(uids []string) bool {
+		return len(uids) == 1 && uids[0] == "sber"
+	})
+
+	source.SetPrice("sber", 302)
+	if tick := nextTick(t, staying); tick.Price != 302 {
+		t.Errorf("remaining subscriber got %+v, want 302", tick)
+	}
+
+	// С последней подпиской опрос останавливается
+	staying.Close()
+	time.Sleep(2 * testInterval)
+
+	polls := source.Polls()
+	time.Sleep(5 * testInterval)
+
+	if source.Polls() != polls {
+		t.Errorf("hub kept polling after the last unsubscribe: %d -> %d", polls, source.Polls())
+	}
+}
+
+func TestPriceHubIsolatesFailingInstrument(t *testing.T) {
+	source := NewFakePriceSource()
+	source.SetPrice("sber", 300)
+	source.Fail("bogus")
+
+	hub := NewPriceHub(source, testInterval)
+	t.Cleanup(hub.Stop)
+
+	healthy := hub.Subscribe([]string{"sber"})
+	hub.Subscribe([]string{"bogus"})
+
+	// Общий запрос падает из-за чужого инструмента, но цена приходит из опроса по одному
+	if tick := nextTick(t, healthy); tick.InstrumentUid != "sber" || tick.Price != 300 {
+		t.Errorf("healthy subscriber got %+v, want sber 300", tick)
+	}
+
+	source.SetPrice("sber", 305)
+	if tick := nextTick(t, healthy); tick.Price != 305 {
+		t.Errorf("healthy subscriber got %+v, want 305", tick)
+	}
+}
+
+func TestPriceHubForgetsUnsubscribedPrices(t *testing.T) {
+	source := NewFakePriceSource()
+	source.SetPrice("sber", 300)
+
+	hub := NewPriceHub(source, time.Hour)
+	t.Cleanup(hub.Stop)
+
+	first := hub.Subscribe([]string{"sber"})
+	nextTick(t, first)
+	first.Close()
+
+	source.SetPrice("sber", 310)
+
+	// Цена без подписок забыта: новой подписке не уходит устаревший тик,
+	// а внеочередной опрос после перезапуска сразу приносит актуальную цену
+	second := hub.Subscribe([]string{"sber"})
+	if tick := nextTick(t, second); tick.Price != 310 {
+		t.Errorf("resubscribed client got %+v, want fresh 310", tick)
+	}
+}
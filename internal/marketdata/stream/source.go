@@ -0,0 +1,10 @@
+package stream
+
+import (
+	"context"
+)
+
+// Источник цен для потока: последние цены, запрошенные в обход кэша
+type PriceSource interface {
+	RefreshLastPrices(ctx context.Context, uids []string) (map[string]float64, error)
+}
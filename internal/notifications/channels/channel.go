@@ -0,0 +1,63 @@
+package channels
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"invest-mate/internal/notifications/models"
+)
+
+// Наибольшая длина тела ответа получателя в тексте ошибки
+const maxErrorBodyLength = 200
+
+// Сообщение для отправки в канал
+type Message struct {
+	DeliveryID string
+	Target     string
+	Secret     string
+	Event      models.EventType
+	Title      string
+	Text       string
+	Data       any
+	CreatedAt  time.Time
+}
+
+// Канал доставки уведомлений.
+// Ошибки с models.ErrDeliveryRejected не повторяются
+type Channel interface {
+	Type() models.ChannelType
+	// Проверка адреса получателя
+	ValidateTarget(target string) error
+	Send(ctx context.Context, message *Message) error
+}
+
+// Ошибка, после которой повторная отправка бессмысленна
+func rejected(format string, v ...any) error {
+	return fmt.Errorf("%w: %s", models.ErrDeliveryRejected, fmt.Sprintf(format, v...))
+}
+
+// Ошибка, после которой отправку можно повторить
+func unavailable(format string, v ...any) error {
+	return fmt.Errorf("%w: %s", models.ErrDeliveryUnavailable, fmt.Sprintf(format, v...))
+}
+
+// Ошибка по ответу HTTP: 4xx (кроме 408 и 429) не повторяются
+func responseError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyLength))
+
+	message := fmt.Sprintf("status %d", resp.StatusCode)
+	if len(body) > 0 {
+		message = fmt.Sprintf("status %d: %s", resp.StatusCode, body)
+	}
+
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+		resp.StatusCode != http.StatusRequestTimeout &&
+		resp.StatusCode != http.StatusTooManyRequests {
+		return rejected("%s", message)
+	}
+
+	return unavailable("%s", message)
+}
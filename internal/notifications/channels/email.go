@@ -0,0 +1,155 @@
+package channels
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"invest-mate/internal/notifications/models"
+)
+
+// Параметры SMTP-сервера
+type SMTPOptions struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	Timeout  time.Duration
+}
+
+// Письма через SMTP (STARTTLS — если сервер его поддерживает)
+type EmailChannel struct {
+	options SMTPOptions
+}
+
+// Создание канала писем
+func NewEmailChannel(options SMTPOptions) *EmailChannel {
+	return &EmailChannel{options: options}
+}
+
+func (ch *EmailChannel) Type() models.ChannelType {
+	return models.ChannelEmail
+}
+
+// Адрес получателя — e-mail без имени
+func (ch *EmailChannel) ValidateTarget(target string) error {
+	address, err := mail.ParseAddress(target)
+	if err != nil || address.Address != target {
+		return fmt.Errorf("%w: email target must be a plain e-mail address", models.ErrInvalidRequest)
+	}
+
+	return nil
+}
+
+func (ch *EmailChannel) Send(ctx context.Context, message *Message) error {
+	addr := net.JoinHostPort(ch.options.Host, strconv.Itoa(ch.options.Port))
+
+	dialer := net.Dialer{Timeout: ch.options.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return unavailable("dial %s: %v", addr, err)
+	}
+
+	deadline := time.Now().Add(ch.options.Timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	_ = conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, ch.options.Host)
+	if err != nil {
+		conn.Close()
+		return unavailable("smtp handshake: %v", err)
+	}
+	defer client.Close()
+
+	if err := ch.send(client, message); err != nil {
+		return smtpError(err)
+	}
+
+	return nil
+}
+
+// Диалог с SMTP-сервером
+func (ch *EmailChannel) send(client *smtp.Client, message *Message) error {
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: ch.options.Host}); err != nil {
+			return err
+		}
+	}
+
+	if ch.options.Username != "" {
+		auth := smtp.PlainAuth("", ch.options.Username, ch.options.Password, ch.options.Host)
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(ch.options.From); err != nil {
+		return err
+	}
+
+	if err := client.Rcpt(message.Target); err != nil {
+		return err
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err := writer.Write(ch.buildMessage(message)); err != nil {
+		writer.Close()
+		return err
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// Письмо в формате RFC 5322 (text/plain, UTF-8)
+func (ch *EmailChannel) buildMessage(message *Message) []byte {
+	subject := strings.NewReplacer("\r", " ", "\n", " ").Replace(message.Title)
+
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "From: %s\r\n", ch.options.From)
+	fmt.Fprintf(&buf, "To: %s\r\n", message.Target)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	if message.DeliveryID != "" {
+		fmt.Fprintf(&buf, "Message-ID: <%s@invest-mate>\r\n", message.DeliveryID)
+	}
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.ReplaceAll(strings.ReplaceAll(message.Text, "\r\n", "\n"), "\n", "\r\n"))
+	buf.WriteString("\r\n")
+
+	return buf.Bytes()
+}
+
+// Постоянные ошибки SMTP (коды 5xx) не повторяются
+func smtpError(err error) error {
+	var protocolErr *textproto.Error
+	if errors.As(err, &protocolErr) && protocolErr.Code >= 500 {
+		return rejected("smtp: %v", err)
+	}
+
+	return unavailable("smtp: %v", err)
+}
@@ -0,0 +1,106 @@
+package channels
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"invest-mate/internal/notifications/models"
+)
+
+const defaultTelegramAPIURL = "https://api.telegram.org"
+
+// Ответ Bot API
+type telegramResponse struct {
+	OK          bool   `json:"ok"`
+	Description string `json:"description"`
+}
+
+// Сообщения через Bot API в стиле Telegram: POST <api>/bot<token>/sendMessage
+type TelegramChannel struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// Создание канала бота с адресом API (пустой — api.telegram.org), токеном и таймаутом запроса
+func NewTelegramChannel(baseURL, token string, timeout time.Duration) *TelegramChannel {
+	if baseURL == "" {
+		baseURL = defaultTelegramAPIURL
+	}
+
+	return &TelegramChannel{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		token:   token,
+		httpClient: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+func (ch *TelegramChannel) Type() models.ChannelType {
+	return models.ChannelTelegram
+}
+
+// Адрес получателя — числовой идентификатор чата или @имя канала
+func (ch *TelegramChannel) ValidateTarget(target string) error {
+	if strings.HasPrefix(target, "@") && len(target) > 1 {
+		return nil
+	}
+
+	if _, err := strconv.ParseInt(target, 10, 64); err != nil {
+		return fmt.Errorf("%w: telegram target must be a chat id or @channel", models.ErrInvalidRequest)
+	}
+
+	return nil
+}
+
+func (ch *TelegramChannel) Send(ctx context.Context, message *Message) error {
+	text := message.Text
+	if message.Title != "" {
+		text = message.Title + "\n\n" + message.Text
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"chat_id": message.Target,
+		"text":    text,
+	})
+	if err != nil {
+		return rejected("marshal request: %v", err)
+	}
+
+	url := fmt.Sprintf("%s/bot%s/sendMessage", ch.baseURL, ch.token)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return rejected("create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := ch.httpClient.Do(req)
+	if err != nil {
+		// Токен входит в адрес запроса и не должен попасть в журнал
+		return unavailable("do request: %v", strings.ReplaceAll(err.Error(), ch.token, "***"))
+	}
+	defer resp.Body.Close()
+
+	var result telegramResponse
+	_ = json.NewDecoder(resp.Body).Decode(&result)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 && result.OK {
+		return nil
+	}
+
+	details := fmt.Sprintf("status %d: %s", resp.StatusCode, result.Description)
+
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+		return rejected("%s", details)
+	}
+
+	return unavailable("%s", details)
+}
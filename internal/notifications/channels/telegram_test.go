@@ -0,0 +1,101 @@
+package channels
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"invest-mate/internal/notifications/models"
+)
+
+func TestTelegramSendPostsMessage(t *testing.T) {
+	var path string
+	var request map[string]string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		_, _ = w.Write([]byte(`{"ok":true,"result":{}}`))
+	}))
+	defer server.Close()
+
+	channel := NewTelegramChannel(server.URL+"/", "123:token", time.Second)
+
+	err := channel.Send(t.Context(), &Message{Target: "@invest", Title: "SBER", Text: "Цена выше 300"})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if path != "/bot123:token/sendMessage" {
+		t.Errorf("path = %q, want /bot123:token/sendMessage", path)
+	}
+	if request["chat_id"] != "@invest" {
+		t.Errorf("chat_id = %q, want @invest", request["chat_id"])
+	}
+	if request["text"] != "SBER\n\nЦена выше 300" {
+		t.Errorf("text = %q", request["text"])
+	}
+}
+
+func TestTelegramSendClassifiesErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   error
+	}{
+		{"unknown chat is rejected", http.StatusBadRequest, `{"ok":false,"description":"Bad Request: chat not found"}`, models.ErrDeliveryRejected},
+		{"flood limit is retried", http.StatusTooManyRequests, `{"ok":false,"description":"Too Many Requests"}`, models.ErrDeliveryUnavailable},
+		{"server error is retried", http.StatusBadGateway, ``, models.ErrDeliveryUnavailable},
+		{"not ok response is retried", http.StatusOK, `{"ok":false}`, models.ErrDeliveryUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			err := NewTelegramChannel(server.URL, "123:token", time.Second).Send(t.Context(), &Message{Target: "42", Text: "test"})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Send() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTelegramSendHidesTokenInErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	err := NewTelegramChannel(url, "123:secret-token", time.Second).Send(t.Context(), &Message{Target: "42", Text: "test"})
+	if !errors.Is(err, models.ErrDeliveryUnavailable) {
+		t.Fatalf("Send() error = %v, want ErrDeliveryUnavailable", err)
+	}
+	if strings.Contains(err.Error(), "secret-token") {
+		t.Errorf("error exposes token: %v", err)
+	}
+}
+
+func TestTelegramValidateTarget(t *testing.T) {
+	channel := NewTelegramChannel("", "token", time.Second)
+
+	for _, target := range []string{"42", "-1001234567890", "@invest"} {
+		if err := channel.ValidateTarget(target); err != nil {
+			t.Errorf("ValidateTarget(%q) error = %v", target, err)
+		}
+	}
+
+	for _, target := range []string{"", "@", "chat"} {
+		if err := channel.ValidateTarget(target); !errors.Is(err, models.ErrInvalidRequest) {
+			t.Errorf("ValidateTarget(%q) error = %v, want ErrInvalidRequest", target, err)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package channels
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	"invest-mate/internal/notifications/models"
+)
+
+const (
+	// Заголовки запроса вебхука
+	WebhookSignatureHeader = "X-Invest-Mate-Signature"
+	WebhookTimestampHeader = "X-Invest-Mate-Timestamp"
+	WebhookDeliveryHeader  = "X-Invest-Mate-Delivery"
+	WebhookEventHeader     = "X-Invest-Mate-Event"
+)
+
+// Тело запроса вебхука
+type WebhookPayload struct {
+	ID        string           `json:"id"`
+	Event     models.EventType `json:"event"`
+	Title     string           `json:"title"`
+	Message   string           `json:"message"`
+	Data      any              `json:"data,omitempty"`
+	CreatedAt time.Time        `json:"createdAt"`
+}
+
+// Время на разрешение имени хоста при проверке адреса
+const webhookResolveTimeout = 5 * time.Second
+
+// Адрес получателя во внутренней сети
+var errForbiddenAddress = errors.New("webhook address is not public")
+
+// Диапазоны, недоступные для вебхуков помимо частных, локальных и служебных адресов
+var forbiddenNetworks = []*net.IPNet{
+	mustParseCIDR("0.0.0.0/8"),
+	mustParseCIDR("100.64.0.0/10"),
+	mustParseCIDR("192.0.0.0/24"),
+	mustParseCIDR("198.18.0.0/15"),
+	mustParseCIDR("240.0.0.0/4"),
+	mustParseCIDR("64:ff9b::/96"),
+}
+
+// Вебхук: POST с JSON, подписанным HMAC-SHA256 от "<timestamp>.<body>" ключом канала.
+// Запросы во внутреннюю сеть запрещены и при проверке адреса, и при подключении,
+// чтобы смена DNS-записи после проверки не открывала доступ к внутренним сервисам
+type WebhookChannel struct {
+	httpClient *http.Client
+	resolver   *net.Resolver
+	allowIP    func(ip net.IP) bool
+}
+
+// Создание канала вебхуков с таймаутом запроса. allowPrivate разрешает адреса
+// локальной и частных сетей (для получателей в доверенной сети и тестов)
+func NewWebhookChannel(timeout time.Duration, allowPrivate bool) *WebhookChannel {
+	ch := &WebhookChannel{
+		resolver: net.DefaultResolver,
+		allowIP:  isPublicIP,
+	}
+
+	if allowPrivate {
+		ch.allowIP = func(ip net.IP) bool { return true }
+	}
+
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		// Адрес проверяется после разрешения имени, непосредственно перед подключением
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !ch.allowIP(ip) {
+				return fmt.Errorf("%w: %s", errForbiddenAddress, host)
+			}
+			return nil
+		},
+	}
+
+	ch.httpClient = &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		// Перенаправления не выполняются: подпись относится к указанному адресу
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	return ch
+}
+
+func (ch *WebhookChannel) Type() models.ChannelType {
+	return models.ChannelWebhook
+}
+
+// Адрес вебхука — абсолютный URL http или https с хостом в публичной сети
+func (ch *WebhookChannel) ValidateTarget(target string) error {
+	parsed, err := url.Parse(target)
+	if err != nil || parsed.Hostname() == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return fmt.Errorf("%w: webhook target must be an absolute http or https URL", models.ErrInvalidRequest)
+	}
+
+	host := parsed.Hostname()
+
+	ips := []net.IP{net.ParseIP(host)}
+	if ips[0] == nil {
+		ctx, cancel := context.WithTimeout(context.Background(), webhookResolveTimeout)
+		defer cancel()
+
+		addresses, err := ch.resolver.LookupIPAddr(ctx, host)
+		if err != nil || len(addresses) == 0 {
+			return fmt.Errorf("%w: webhook host %q cannot be resolved", models.ErrInvalidRequest, host)
+		}
+
+		ips = ips[:0]
+		for _, address := range addresses {
+			ips = append(ips, address.IP)
+		}
+	}
+
+	for _, ip := range ips {
+		if !ch.allowIP(ip) {
+			return fmt.Errorf("%w: webhook host %q points to a non-public address", models.ErrInvalidRequest, host)
+		}
+	}
+
+	return nil
+}
+
+func (ch *WebhookChannel) Send(ctx context.Context, message *Message) error {
+	body, err := json.Marshal(WebhookPayload{
+		ID:        message.DeliveryID,
+		Event:     message.Event,
+		Title:     message.Title,
+		Message:   message.Text,
+		Data:      message.Data,
+		CreatedAt: message.CreatedAt,
+	})
+	if err != nil {
+		return rejected("marshal payload: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, message.Target, bytes.NewReader(body))
+	if err != nil {
+		return rejected("create request: %v", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	req.Header.Set(WebhookSignatureHeader, "sha256="+SignWebhook(message.Secret, timestamp, body))
+	req.Header.Set(WebhookDeliveryHeader, message.DeliveryID)
+	req.Header.Set(WebhookEventHeader, string(message.Event))
+
+	resp, err := ch.httpClient.Do(req)
+	if errors.Is(err, errForbiddenAddress) {
+		return rejected("do request: %v", err)
+	}
+	if err != nil {
+		return unavailable("do request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return responseError(resp)
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return nil
+}
+
+// Адрес в публичной сети: не локальный, не частный, не служебный
+func isPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+
+	for _, network := range forbiddenNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func mustParseCIDR(cidr string) *net.IPNet {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+
+	return network
+}
+
+// Подпись тела вебхука (hex HMAC-SHA256 от "<timestamp>.<body>")
+func SignWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
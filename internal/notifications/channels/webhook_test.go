@@ -0,0 +1,150 @@
+package channels
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"invest-mate/internal/notifications/models"
+)
+
+func TestWebhookSendSignsPayload(t *testing.T) {
+	type captured struct {
+		header http.Header
+		body   []byte
+	}
+	requests := make(chan captured, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- captured{header: r.Header.Clone(), body: body}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	channel := NewWebhookChannel(time.Second, true)
+
+	err := channel.Send(t.Context(), &Message{
+		DeliveryID: "delivery-1",
+		Target:     server.URL,
+		Secret:     "secret",
+		Event:      models.EventAlertTriggered,
+		Title:      "SBER",
+		Text:       "Цена выше 300",
+		Data:       map[string]any{"price": 301.5},
+		CreatedAt:  time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	request := <-requests
+
+	timestamp := request.header.Get(WebhookTimestampHeader)
+	want := "sha256=" + SignWebhook("secret", timestamp, request.body)
+	if got := request.header.Get(WebhookSignatureHeader); got != want {
+		t.Errorf("signature = %q, want %q", got, want)
+	}
+	if got := request.header.Get(WebhookDeliveryHeader); got != "delivery-1" {
+		t.Errorf("delivery header = %q, want delivery-1", got)
+	}
+	if got := request.header.Get(WebhookEventHeader); got != string(models.EventAlertTriggered) {
+		t.Errorf("event header = %q, want %s", got, models.EventAlertTriggered)
+	}
+
+	var payload WebhookPayload
+	if err := json.Unmarshal(request.body, &payload); err != nil {
+		t.Fatalf("payload is not JSON: %v", err)
+	}
+	if payload.ID != "delivery-1" || payload.Message != "Цена выше 300" || payload.Title != "SBER" {
+		t.Errorf("payload = %+v", payload)
+	}
+}
+
+func TestWebhookSendClassifiesResponses(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		want   error
+	}{
+		{"server error is retried", http.StatusServiceUnavailable, models.ErrDeliveryUnavailable},
+		{"rate limit is retried", http.StatusTooManyRequests, models.ErrDeliveryUnavailable},
+		{"client error is rejected", http.StatusBadRequest, models.ErrDeliveryRejected},
+		{"redirect is not followed", http.StatusFound, models.ErrDeliveryUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.status == http.StatusFound {
+					w.Header().Set("Location", "http://169.254.169.254/")
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			err := NewWebhookChannel(time.Second, true).Send(t.Context(), &Message{Target: server.URL, Secret: "secret"})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Send() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhookValidateTargetRejectsPrivateAddresses(t *testing.T) {
+	channel := NewWebhookChannel(time.Second, false)
+
+	targets := []string{
+		"http://127.0.0.1:8080/hook",
+		"http://localhost/hook",
+		"http://10.1.2.3/hook",
+		"http://172.16.0.1/hook",
+		"http://192.168.1.1/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://100.64.0.1/hook",
+		"http://0.0.0.0/hook",
+		"http://[::1]/hook",
+		"http://[fe80::1]/hook",
+		"http://[fd00::1]/hook",
+		"http://[::ffff:127.0.0.1]/hook",
+		"ftp://93.184.216.34/hook",
+		"/relative",
+	}
+
+	for _, target := range targets {
+		if err := channel.ValidateTarget(target); !errors.Is(err, models.ErrInvalidRequest) {
+			t.Errorf("ValidateTarget(%q) error = %v, want ErrInvalidRequest", target, err)
+		}
+	}
+
+	for _, target := range []string{"https://93.184.216.34/hook", "http://[2606:4700::1111]/hook"} {
+		if err := channel.ValidateTarget(target); err != nil {
+			t.Errorf("ValidateTarget(%q) error = %v, want nil", target, err)
+		}
+	}
+}
+
+func TestWebhookSendRefusesPrivateAddressAtDial(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	// Адрес мог пройти проверку раньше и смениться после (DNS rebinding)
+	err := NewWebhookChannel(time.Second, false).Send(t.Context(), &Message{Target: server.URL, Secret: "secret"})
+	if !errors.Is(err, models.ErrDeliveryRejected) {
+		t.Errorf("Send() error = %v, want ErrDeliveryRejected", err)
+	}
+	if !strings.Contains(err.Error(), "not public") {
+		t.Errorf("Send() error = %v, want address error", err)
+	}
+	if requests != 0 {
+		t.Errorf("server got %d requests, want 0", requests)
+	}
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"invest-mate/internal/notifications/models"
+	"invest-mate/internal/notifications/models/domain"
+	"invest-mate/internal/notifications/services"
+	"invest-mate/pkg/handlers"
+	middleware "invest-mate/pkg/middlewares"
+)
+
+type NotificationsHandler struct {
+	notificationsService services.NotificationsService
+}
+
+// Создание нового хендлера
+func NewNotificationsHandler(notificationsService services.NotificationsService) *NotificationsHandler {
+	return &NotificationsHandler{
+		notificationsService: notificationsService,
+	}
+}
+
+// Регистрация маршрутов
+func (h *NotificationsHandler) RegisterRoutes(router *gin.RouterGroup) {
+	notifications := router.Group("/notifications")
+	notifications.Use(middleware.AuthMiddleware())
+	{
+		notifications.GET("/channels", h.GetChannels)
+		notifications.PUT("/channels/:channel", h.SetChannel)
+		notifications.DELETE("/channels/:channel", h.DeleteChannel)
+		notifications.POST("/test", h.SendTest)
+		notifications.GET("/deliveries", h.GetDeliveries)
+	}
+}
+
+// Обработчик получения настроек каналов
+func (h *NotificationsHandler) GetChannels(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	preferences, err := h.notificationsService.GetChannels(c.Request.Context(), userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(preferences))
+}
+
+// Обработчик подключения или изменения канала
+func (h *NotificationsHandler) SetChannel(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.SetChannelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	preference, err := h.notificationsService.SetChannel(c.Request.Context(), userID, models.ChannelType(c.Param("channel")), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(preference))
+}
+
+// Обработчик отключения канала
+func (h *NotificationsHandler) DeleteChannel(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.notificationsService.DeleteChannel(c.Request.Context(), userID, models.ChannelType(c.Param("channel")))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(result))
+}
+
+// Обработчик отправки проверочного уведомления
+func (h *NotificationsHandler) SendTest(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	deliveries, err := h.notificationsService.SendTest(c.Request.Context(), userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, handlers.BuildResponse(deliveries))
+}
+
+// Обработчик получения журнала доставки (channel, status, пагинация)
+func (h *NotificationsHandler) GetDeliveries(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	page, limit := handlers.ParsePaginationParams(c)
+	filter := &domain.DeliveriesFilter{
+		Channel: models.ChannelType(c.Query("channel")),
+		Status:  models.DeliveryStatus(c.Query("status")),
+	}
+
+	deliveries, total, err := h.notificationsService.GetDeliveries(c.Request.Context(), userID, filter, page, limit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildListResponse(deliveries, total, page, limit))
+}
+
+// Формирование ответа с ошибкой
+func respondError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, models.ErrPreferenceNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, models.ErrChannelUnavailable),
+		errors.Is(err, models.ErrNoChannels):
+		status = http.StatusUnprocessableEntity
+	case errors.Is(err, models.ErrInvalidRequest):
+		status = http.StatusBadRequest
+	case errors.Is(err, models.ErrDispatcherClosed):
+		status = http.StatusServiceUnavailable
+	}
+
+	c.JSON(status, gin.H{"error": err.Error()})
+}
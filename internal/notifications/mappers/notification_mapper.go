@@ -0,0 +1,133 @@
+package mappers
+
+import (
+	"encoding/json"
+	"strings"
+
+	"invest-mate/internal/notifications/models"
+	"invest-mate/internal/notifications/models/domain"
+	"invest-mate/internal/notifications/models/entity"
+)
+
+func FromPreferenceEntityToDomain(entity entity.ChannelPreference) *domain.ChannelPreference {
+	return &domain.ChannelPreference{
+		ID:        entity.ID,
+		UserID:    entity.UserID,
+		Channel:   models.ChannelType(entity.Channel),
+		Target:    entity.Target,
+		Secret:    entity.Secret,
+		Events:    splitEvents(entity.Events),
+		IsEnabled: entity.IsEnabled,
+		CreatedAt: entity.CreatedAt,
+		UpdatedAt: entity.UpdatedAt,
+	}
+}
+
+func FromPreferenceEntityToDomainSlice(entitySlice []entity.ChannelPreference) []*domain.ChannelPreference {
+	domainSlice := make([]*domain.ChannelPreference, len(entitySlice))
+
+	for index, entity := range entitySlice {
+		domainSlice[index] = FromPreferenceEntityToDomain(entity)
+	}
+
+	return domainSlice
+}
+
+func FromPreferenceDomainToEntity(domain *domain.ChannelPreference) entity.ChannelPreference {
+	return entity.ChannelPreference{
+		ID:        domain.ID,
+		UserID:    domain.UserID,
+		Channel:   string(domain.Channel),
+		Target:    domain.Target,
+		Secret:    domain.Secret,
+		Events:    joinEvents(domain.Events),
+		IsEnabled: domain.IsEnabled,
+		CreatedAt: domain.CreatedAt,
+		UpdatedAt: domain.UpdatedAt,
+	}
+}
+
+func FromDeliveryEntityToDomain(entity entity.Delivery) *domain.Delivery {
+	return &domain.Delivery{
+		ID:          entity.ID,
+		UserID:      entity.UserID,
+		Channel:     models.ChannelType(entity.Channel),
+		Target:      entity.Target,
+		Event:       models.EventType(entity.Event),
+		Title:       entity.Title,
+		Message:     entity.Message,
+		Data:        dataFromString(entity.Data),
+		Status:      models.DeliveryStatus(entity.Status),
+		Attempts:    entity.Attempts,
+		LastError:   entity.LastError,
+		NextRetryAt: entity.NextRetryAt,
+		SentAt:      entity.SentAt,
+		CreatedAt:   entity.CreatedAt,
+		UpdatedAt:   entity.UpdatedAt,
+	}
+}
+
+func FromDeliveryEntityToDomainSlice(entitySlice []entity.Delivery) []*domain.Delivery {
+	domainSlice := make([]*domain.Delivery, len(entitySlice))
+
+	for index, entity := range entitySlice {
+		domainSlice[index] = FromDeliveryEntityToDomain(entity)
+	}
+
+	return domainSlice
+}
+
+func FromDeliveryDomainToEntity(domain *domain.Delivery) entity.Delivery {
+	return entity.Delivery{
+		ID:          domain.ID,
+		UserID:      domain.UserID,
+		Channel:     string(domain.Channel),
+		Target:      domain.Target,
+		Event:       string(domain.Event),
+		Title:       domain.Title,
+		Message:     domain.Message,
+		Data:        string(domain.Data),
+		Status:      string(domain.Status),
+		Attempts:    domain.Attempts,
+		LastError:   domain.LastError,
+		NextRetryAt: domain.NextRetryAt,
+		SentAt:      domain.SentAt,
+		CreatedAt:   domain.CreatedAt,
+		UpdatedAt:   domain.UpdatedAt,
+	}
+}
+
+// Данные события хранятся текстом JSON (пустая строка — данных нет)
+func dataFromString(value string) json.RawMessage {
+	if value == "" {
+		return nil
+	}
+
+	return json.RawMessage(value)
+}
+
+// События хранятся строкой через запятую
+func splitEvents(value string) []models.EventType {
+	if value == "" {
+		return []models.EventType{}
+	}
+
+	parts := strings.Split(value, ",")
+	events := make([]models.EventType, len(parts))
+
+	for index, part := range parts {
+		events[index] = models.EventType(part)
+	}
+
+	return events
+}
+
+func joinEvents(events []models.EventType) string {
+	parts := make([]string, len(events))
+
+	for index, event := range events {
+		parts[index] = string(event)
+	}
+
+	return strings.Join(parts, ",")
+}
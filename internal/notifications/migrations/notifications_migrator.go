@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+
+	"invest-mate/internal/notifications/models/entity"
+)
+
+type NotificationsMigrator struct{}
+
+func NewNotificationsMigrator() *NotificationsMigrator {
+	return &NotificationsMigrator{}
+}
+
+func (m *NotificationsMigrator) Migrate(db *gorm.DB) error {
+	return db.AutoMigrate(
+		&entity.ChannelPreference{},
+		&entity.Delivery{},
+	)
+}
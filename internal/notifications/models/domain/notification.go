@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"invest-mate/internal/notifications/models"
+)
+
+// Уведомление пользователю; рассылается во все включённые каналы,
+// подписанные на событие
+type Notification struct {
+	UserID  string
+	Event   models.EventType
+	Title   string
+	Message string
+	// Дополнительные данные события (передаются в вебхук)
+	Data any
+}
+
+// Настройка канала уведомлений пользователя
+type ChannelPreference struct {
+	ID      string             `json:"id"`
+	UserID  string             `json:"userId"`
+	Channel models.ChannelType `json:"channel"`
+	// Адрес получателя: e-mail, URL вебхука или идентификатор чата
+	Target string `json:"target"`
+	// Ключ подписи вебхука (выпускается при подключении канала)
+	Secret    string             `json:"secret,omitempty"`
+	Events    []models.EventType `json:"events"`
+	IsEnabled bool               `json:"isEnabled"`
+	CreatedAt time.Time          `json:"createdAt"`
+	UpdatedAt time.Time          `json:"updatedAt"`
+}
+
+// Подписка канала на событие (без списка событий — на все)
+func (p *ChannelPreference) Accepts(event models.EventType) bool {
+	if event == models.EventTest || len(p.Events) == 0 {
+		return true
+	}
+
+	for _, subscribed := range p.Events {
+		if subscribed == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Запись журнала доставки уведомления
+type Delivery struct {
+	ID      string             `json:"id"`
+	UserID  string             `json:"userId"`
+	Channel models.ChannelType `json:"channel"`
+	Target  string             `json:"target"`
+	Event   models.EventType   `json:"event"`
+	Title   string             `json:"title"`
+	Message string             `json:"message"`
+	// Данные события в JSON (нужны для повторной отправки после перезапуска)
+	Data        json.RawMessage       `json:"-"`
+	Status      models.DeliveryStatus `json:"status"`
+	Attempts    int                   `json:"attempts"`
+	LastError   string                `json:"lastError,omitempty"`
+	NextRetryAt *time.Time            `json:"nextRetryAt,omitempty"`
+	SentAt      *time.Time            `json:"sentAt,omitempty"`
+	CreatedAt   time.Time             `json:"createdAt"`
+	UpdatedAt   time.Time             `json:"updatedAt"`
+}
+
+// Фильтр журнала доставки
+type DeliveriesFilter struct {
+	Channel models.ChannelType
+	Status  models.DeliveryStatus
+}
+
+type SetChannelRequest struct {
+	Target    string             `json:"target"`
+	Events    []models.EventType `json:"events"`
+	IsEnabled *bool              `json:"isEnabled"`
+	// Выпустить новый ключ подписи вебхука
+	RotateSecret bool `json:"rotateSecret"`
+}
+
+// Копия записи журнала (исходная изменяется при фоновой отправке)
+func (d *Delivery) Copy() *Delivery {
+	copied := *d
+	return &copied
+}
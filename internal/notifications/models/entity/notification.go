@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"time"
+)
+
+// Настройки каналов уведомлений пользователей
+type ChannelPreference struct {
+	ID        string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	UserID    string    `gorm:"not null;uniqueIndex:idx_channel_preference_user_channel"`
+	Channel   string    `gorm:"size:32;not null;uniqueIndex:idx_channel_preference_user_channel"`
+	Target    string    `gorm:"size:512;not null"`
+	Secret    string    `gorm:"size:128"`
+	Events    string    `gorm:"type:text"`
+	IsEnabled bool      `gorm:"not null;default:true"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+// Журнал доставки уведомлений
+type Delivery struct {
+	ID          string     `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	UserID      string     `gorm:"not null;index"`
+	Channel     string     `gorm:"size:32;not null"`
+	Target      string     `gorm:"size:512;not null"`
+	Event       string     `gorm:"size:32;not null"`
+	Title       string     `gorm:"size:255"`
+	Message     string     `gorm:"type:text"`
+	Data        string     `gorm:"type:text"`
+	Status      string     `gorm:"size:16;not null;index"`
+	Attempts    int        `gorm:"not null;default:0"`
+	LastError   string     `gorm:"type:text"`
+	NextRetryAt *time.Time `gorm:"index"`
+	SentAt      *time.Time
+	CreatedAt   time.Time `gorm:"autoCreateTime;index"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+}
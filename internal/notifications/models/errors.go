@@ -0,0 +1,15 @@
+package models
+
+import (
+	"errors"
+)
+
+var (
+	ErrPreferenceNotFound  = errors.New("Канал уведомлений не подключён")
+	ErrChannelUnavailable  = errors.New("Канал уведомлений отключён на сервере")
+	ErrNoChannels          = errors.New("Нет включённых каналов уведомлений")
+	ErrInvalidRequest      = errors.New("Некорректный запрос")
+	ErrDispatcherClosed    = errors.New("Отправка уведомлений остановлена")
+	ErrDeliveryRejected    = errors.New("Получатель отклонил уведомление")
+	ErrDeliveryUnavailable = errors.New("Получатель уведомления недоступен")
+)
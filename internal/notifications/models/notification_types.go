@@ -0,0 +1,65 @@
+package models
+
+// Канал доставки уведомлений
+type ChannelType string
+
+const (
+	// Письмо через SMTP
+	ChannelEmail ChannelType = "EMAIL"
+	// HTTP-запрос с подписью HMAC-SHA256
+	ChannelWebhook ChannelType = "WEBHOOK"
+	// Сообщение через Bot API в стиле Telegram
+	ChannelTelegram ChannelType = "TELEGRAM"
+)
+
+// Проверка канала на валидность
+func (t ChannelType) IsValid() bool {
+	switch t {
+	case ChannelEmail, ChannelWebhook, ChannelTelegram:
+		return true
+	default:
+		return false
+	}
+}
+
+// Событие, о котором уведомляется пользователь
+type EventType string
+
+const (
+	// Срабатывание оповещения по цене или доходности
+	EventAlertTriggered EventType = "ALERT_TRIGGERED"
+	// Проверочное уведомление (отправляется во все включённые каналы)
+	EventTest EventType = "TEST"
+)
+
+// Проверка события на валидность
+func (t EventType) IsValid() bool {
+	switch t {
+	case EventAlertTriggered, EventTest:
+		return true
+	default:
+		return false
+	}
+}
+
+// Состояние доставки уведомления
+type DeliveryStatus string
+
+const (
+	// Ожидает отправки или повторной попытки
+	DeliveryPending DeliveryStatus = "PENDING"
+	// Доставлено
+	DeliverySent DeliveryStatus = "SENT"
+	// Не доставлено после всех попыток
+	DeliveryFailed DeliveryStatus = "FAILED"
+)
+
+// Проверка состояния на валидность
+func (s DeliveryStatus) IsValid() bool {
+	switch s {
+	case DeliveryPending, DeliverySent, DeliveryFailed:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,98 @@
+package notifications
+
+import (
+	"context"
+	"sync"
+
+	"gorm.io/gorm"
+
+	"invest-mate/internal/notifications/channels"
+	"invest-mate/internal/notifications/handlers"
+	"invest-mate/internal/notifications/migrations"
+	"invest-mate/internal/notifications/repository"
+	"invest-mate/internal/notifications/services"
+	"invest-mate/internal/shared/config"
+	"invest-mate/pkg/logger"
+)
+
+var (
+	dispatcherOnce     sync.Once
+	dispatcherInstance services.Dispatcher
+	dispatcherErr      error
+)
+
+type Module struct {
+	notificationsHandler *handlers.NotificationsHandler
+	dispatcher           services.Dispatcher
+}
+
+// Инициализация модуля
+func InitModule(db *gorm.DB, cfg *config.Config) (*Module, error) {
+	dispatcher, err := GetDispatcher(db, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	notificationsService := services.NewNotificationsService(
+		repository.NewPreferencesRepository(db),
+		repository.NewDeliveriesRepository(db),
+		dispatcher,
+	)
+	notificationsHandler := handlers.NewNotificationsHandler(notificationsService)
+
+	return &Module{
+		notificationsHandler: notificationsHandler,
+		dispatcher:           dispatcher,
+	}, nil
+}
+
+// Получение общего для всех модулей диспетчера уведомлений
+// (таблицы уведомлений создаются при первом вызове)
+func GetDispatcher(db *gorm.DB, cfg *config.Config) (services.Dispatcher, error) {
+	dispatcherOnce.Do(func() {
+		notificationsMigrator := migrations.NewNotificationsMigrator()
+		if dispatcherErr = notificationsMigrator.Migrate(db); dispatcherErr != nil {
+			return
+		}
+
+		enabled := []channels.Channel{
+			channels.NewWebhookChannel(cfg.NotifyTimeout, cfg.WebhookAllowPrivate),
+		}
+
+		if cfg.SMTPHost != "" {
+			enabled = append(enabled, channels.NewEmailChannel(channels.SMTPOptions{
+				Host:     cfg.SMTPHost,
+				Port:     cfg.SMTPPort,
+				Username: cfg.SMTPUsername,
+				Password: cfg.SMTPPassword,
+				From:     cfg.SMTPFrom,
+				Timeout:  cfg.NotifyTimeout,
+			}))
+		} else {
+			logger.InfoLog("SMTP_HOST is not set, email notifications are disabled")
+		}
+
+		if cfg.TelegramBotToken != "" {
+			enabled = append(enabled, channels.NewTelegramChannel(cfg.TelegramAPIURL, cfg.TelegramBotToken, cfg.NotifyTimeout))
+		} else {
+			logger.InfoLog("TELEGRAM_BOT_TOKEN is not set, telegram notifications are disabled")
+		}
+
+		dispatcherInstance = services.NewDispatcher(
+			repository.NewPreferencesRepository(db),
+			repository.NewDeliveriesRepository(db),
+			services.RetryPolicy{
+				MaxAttempts: cfg.NotifyMaxAttempts,
+				BaseDelay:   cfg.NotifyRetryDelay,
+			},
+			enabled...,
+		)
+
+		// Доставки, прерванные прошлой остановкой сервера, продолжаются с сохранённой попытки
+		if _, err := dispatcherInstance.Resume(context.Background()); err != nil {
+			logger.ErrorLog("Failed to resume pending notification deliveries: %v", err)
+		}
+	})
+
+	return dispatcherInstance, dispatcherErr
+}
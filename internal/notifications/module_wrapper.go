@@ -0,0 +1,39 @@
+package notifications
+
+import (
+	"invest-mate/internal/shared/config"
+
+	"gorm.io/gorm"
+)
+
+type ModuleWrapper struct {
+	module *Module
+}
+
+func (mw *ModuleWrapper) Initialize(db *gorm.DB, cfg *config.Config) error {
+	module, err := InitModule(db, cfg)
+
+	if err != nil {
+		return err
+	}
+
+	mw.module = module
+
+	return nil
+}
+
+func (mw *ModuleWrapper) GetHandler() interface{} {
+	if mw.module == nil {
+		return nil
+	}
+
+	return mw.module.notificationsHandler
+}
+
+func (mw *ModuleWrapper) Close() error {
+	if mw.module != nil {
+		mw.module.dispatcher.Close()
+	}
+
+	return nil
+}
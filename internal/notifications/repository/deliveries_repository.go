@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"invest-mate/internal/notifications/mappers"
+	"invest-mate/internal/notifications/models"
+	"invest-mate/internal/notifications/models/domain"
+	"invest-mate/internal/notifications/models/entity"
+)
+
+type DeliveriesRepository interface {
+	Create(ctx context.Context, delivery *domain.Delivery) error
+	Update(ctx context.Context, delivery *domain.Delivery) error
+	GetListByUser(ctx context.Context, userID string, filter *domain.DeliveriesFilter, limit, offset int) ([]*domain.Delivery, error)
+	CountByUser(ctx context.Context, userID string, filter *domain.DeliveriesFilter) (int64, error)
+	GetPending(ctx context.Context) ([]*domain.Delivery, error)
+}
+
+type deliveriesRepository struct {
+	db *gorm.DB
+}
+
+// Создание нового репозитория журнала доставки
+func NewDeliveriesRepository(db *gorm.DB) DeliveriesRepository {
+	return &deliveriesRepository{db: db}
+}
+
+// Создание записи о доставке в БД
+func (r *deliveriesRepository) Create(ctx context.Context, delivery *domain.Delivery) error {
+	entityDelivery := mappers.FromDeliveryDomainToEntity(delivery)
+
+	if err := r.db.WithContext(ctx).Create(&entityDelivery).Error; err != nil {
+		return err
+	}
+
+	delivery.ID = entityDelivery.ID
+	delivery.CreatedAt = entityDelivery.CreatedAt
+	delivery.UpdatedAt = entityDelivery.UpdatedAt
+
+	return nil
+}
+
+// Обновить запись о доставке в БД
+func (r *deliveriesRepository) Update(ctx context.Context, delivery *domain.Delivery) error {
+	entityDelivery := mappers.FromDeliveryDomainToEntity(delivery)
+
+	if err := r.db.WithContext(ctx).Save(&entityDelivery).Error; err != nil {
+		return err
+	}
+
+	delivery.UpdatedAt = entityDelivery.UpdatedAt
+
+	return nil
+}
+
+// Получить журнал доставки пользователя, начиная с последних, из БД
+func (r *deliveriesRepository) GetListByUser(ctx context.Context, userID string, filter *domain.DeliveriesFilter, limit, offset int) ([]*domain.Delivery, error) {
+	var entityDeliveries []entity.Delivery
+
+	query := r.filtered(ctx, userID, filter).Order("created_at DESC")
+
+	if limit > 0 {
+		query = query.Limit(limit).Offset(offset)
+	}
+
+	if err := query.Find(&entityDeliveries).Error; err != nil {
+		return nil, err
+	}
+
+	return mappers.FromDeliveryEntityToDomainSlice(entityDeliveries), nil
+}
+
+// Подсчёт записей журнала доставки пользователя в БД
+func (r *deliveriesRepository) CountByUser(ctx context.Context, userID string, filter *domain.DeliveriesFilter) (int64, error) {
+	var count int64
+
+	if err := r.filtered(ctx, userID, filter).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// Получить неотправленные доставки всех пользователей по времени следующей попытки из БД
+func (r *deliveriesRepository) GetPending(ctx context.Context) ([]*domain.Delivery, error) {
+	var entityDeliveries []entity.Delivery
+
+	err := r.db.WithContext(ctx).
+		Where("status = ?", string(models.DeliveryPending)).
+		Order("next_retry_at NULLS FIRST, created_at").
+		Find(&entityDeliveries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return mappers.FromDeliveryEntityToDomainSlice(entityDeliveries), nil
+}
+
+func (r *deliveriesRepository) filtered(ctx context.Context, userID string, filter *domain.DeliveriesFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&entity.Delivery{}).Where("user_id = ?", userID)
+
+	if filter == nil {
+		return query
+	}
+
+	if filter.Channel != "" {
+		query = query.Where("channel = ?", string(filter.Channel))
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", string(filter.Status))
+	}
+
+	return query
+}
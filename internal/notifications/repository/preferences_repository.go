@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"invest-mate/internal/notifications/mappers"
+	"invest-mate/internal/notifications/models"
+	"invest-mate/internal/notifications/models/domain"
+	"invest-mate/internal/notifications/models/entity"
+)
+
+type PreferencesRepository interface {
+	GetByUser(ctx context.Context, userID string) ([]*domain.ChannelPreference, error)
+	FindByChannel(ctx context.Context, userID string, channel models.ChannelType) (*domain.ChannelPreference, error)
+	Save(ctx context.Context, preference *domain.ChannelPreference) error
+	Delete(ctx context.Context, userID string, channel models.ChannelType) (bool, error)
+}
+
+type preferencesRepository struct {
+	db *gorm.DB
+}
+
+// Создание нового репозитория настроек каналов
+func NewPreferencesRepository(db *gorm.DB) PreferencesRepository {
+	return &preferencesRepository{db: db}
+}
+
+// Получить настройки каналов пользователя из БД
+func (r *preferencesRepository) GetByUser(ctx context.Context, userID string) ([]*domain.ChannelPreference, error) {
+	var entityPreferences []entity.ChannelPreference
+
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("channel").Find(&entityPreferences).Error; err != nil {
+		return nil, err
+	}
+
+	return mappers.FromPreferenceEntityToDomainSlice(entityPreferences), nil
+}
+
+// Найти настройку канала пользователя в БД
+func (r *preferencesRepository) FindByChannel(ctx context.Context, userID string, channel models.ChannelType) (*domain.ChannelPreference, error) {
+	var entityPreference entity.ChannelPreference
+
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND channel = ?", userID, string(channel)).
+		First(&entityPreference).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.ErrPreferenceNotFound
+		}
+		return nil, err
+	}
+
+	return mappers.FromPreferenceEntityToDomain(entityPreference), nil
+}
+
+// Создание или обновление настройки канала в БД
+func (r *preferencesRepository) Save(ctx context.Context, preference *domain.ChannelPreference) error {
+	entityPreference := mappers.FromPreferenceDomainToEntity(preference)
+
+	if err := r.db.WithContext(ctx).Save(&entityPreference).Error; err != nil {
+		return err
+	}
+
+	preference.ID = entityPreference.ID
+	preference.CreatedAt = entityPreference.CreatedAt
+	preference.UpdatedAt = entityPreference.UpdatedAt
+
+	return nil
+}
+
+// Удаление настройки канала из БД
+func (r *preferencesRepository) Delete(ctx context.Context, userID string, channel models.ChannelType) (bool, error) {
+	result := r.db.WithContext(ctx).Delete(&entity.ChannelPreference{}, "user_id = ? AND channel = ?", userID, string(channel))
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,310 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"invest-mate/internal/notifications/channels"
+	"invest-mate/internal/notifications/models"
+	"invest-mate/internal/notifications/models/domain"
+	"invest-mate/internal/notifications/repository"
+	"invest-mate/pkg/logger"
+)
+
+// Наибольшая пауза между попытками доставки
+const maxRetryDelay = 10 * time.Minute
+
+// Повторные попытки доставки с экспоненциальной паузой
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+}
+
+// Пауза перед попыткой с номером attempt+1: BaseDelay * 2^(attempt-1), не больше 10 минут
+func (p RetryPolicy) Delay(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+
+	return delay
+}
+
+// Рассылка уведомлений по каналам пользователя
+type Dispatcher interface {
+	// Запись доставок в журнал и фоновая отправка; возвращает созданные доставки
+	Notify(ctx context.Context, notification *domain.Notification) ([]*domain.Delivery, error)
+	// Канал, включённый на сервере
+	Channel(channelType models.ChannelType) (channels.Channel, bool)
+	// Возобновление доставок в статусе PENDING, прерванных остановкой сервера;
+	// возвращает число возобновлённых доставок
+	Resume(ctx context.Context) (int, error)
+	// Остановка: текущие попытки прерываются, неотправленные доставки остаются в статусе PENDING
+	Close()
+}
+
+type dispatcher struct {
+	preferencesRepo repository.PreferencesRepository
+	deliveriesRepo  repository.DeliveriesRepository
+	channels        map[models.ChannelType]channels.Channel
+	retry           RetryPolicy
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	mu     sync.RWMutex
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// Создание нового диспетчера уведомлений с включёнными каналами
+func NewDispatcher(
+	preferencesRepo repository.PreferencesRepository,
+	deliveriesRepo repository.DeliveriesRepository,
+	retry RetryPolicy,
+	enabled ...channels.Channel,
+) Dispatcher {
+	if retry.MaxAttempts < 1 {
+		retry.MaxAttempts = 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	d := &dispatcher{
+		preferencesRepo: preferencesRepo,
+		deliveriesRepo:  deliveriesRepo,
+		channels:        make(map[models.ChannelType]channels.Channel, len(enabled)),
+		retry:           retry,
+		ctx:             ctx,
+		cancel:          cancel,
+	}
+
+	for _, channel := range enabled {
+		d.channels[channel.Type()] = channel
+	}
+
+	return d
+}
+
+func (d *dispatcher) Channel(channelType models.ChannelType) (channels.Channel, bool) {
+	channel, ok := d.channels[channelType]
+	return channel, ok
+}
+
+// Рассылка уведомления во все включённые каналы пользователя, подписанные на событие
+func (d *dispatcher) Notify(ctx context.Context, notification *domain.Notification) ([]*domain.Delivery, error) {
+	preferences, err := d.preferencesRepo.GetByUser(ctx, notification.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return nil, models.ErrDispatcherClosed
+	}
+
+	var data json.RawMessage
+	if notification.Data != nil {
+		if data, err = json.Marshal(notification.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	deliveries := make([]*domain.Delivery, 0, len(preferences))
+
+	for _, preference := range preferences {
+		channel, ok := d.channels[preference.Channel]
+		if !ok || !preference.IsEnabled || !preference.Accepts(notification.Event) {
+			continue
+		}
+
+		delivery := &domain.Delivery{
+			UserID:  notification.UserID,
+			Channel: preference.Channel,
+			Target:  preference.Target,
+			Event:   notification.Event,
+			Title:   notification.Title,
+			Message: notification.Message,
+			Data:    data,
+			Status:  models.DeliveryPending,
+		}
+
+		if err := d.deliveriesRepo.Create(ctx, delivery); err != nil {
+			logger.ErrorLog("Failed to log %s delivery for user %s: %v", preference.Channel, notification.UserID, err)
+			continue
+		}
+
+		message := &channels.Message{
+			DeliveryID: delivery.ID,
+			Target:     preference.Target,
+			Secret:     preference.Secret,
+			Event:      notification.Event,
+			Title:      notification.Title,
+			Text:       notification.Message,
+			Data:       notification.Data,
+			CreatedAt:  delivery.CreatedAt,
+		}
+
+		deliveries = append(deliveries, delivery.Copy())
+
+		d.wg.Add(1)
+		go d.deliver(channel, delivery, message)
+	}
+
+	return deliveries, nil
+}
+
+// Возобновление неотправленных доставок: очередная попытка выполняется в момент,
+// назначенный до остановки, ключ подписи берётся из текущих настроек канала
+func (d *dispatcher) Resume(ctx context.Context) (int, error) {
+	pending, err := d.deliveriesRepo.GetPending(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return 0, models.ErrDispatcherClosed
+	}
+
+	resumed := 0
+
+	for _, delivery := range pending {
+		channel, ok := d.channels[delivery.Channel]
+
+		var preference *domain.ChannelPreference
+		if ok {
+			preference, err = d.preferencesRepo.FindByChannel(ctx, delivery.UserID, delivery.Channel)
+			if err != nil && !errors.Is(err, models.ErrPreferenceNotFound) {
+				return resumed, err
+			}
+		}
+
+		if !ok || preference == nil || !preference.IsEnabled || preference.Target != delivery.Target {
+			delivery.Status = models.DeliveryFailed
+			delivery.NextRetryAt = nil
+			delivery.LastError = models.ErrChannelUnavailable.Error()
+
+			if err := d.deliveriesRepo.Update(ctx, delivery); err != nil {
+				logger.ErrorLog("Failed to update delivery %s: %v", delivery.ID, err)
+			}
+			continue
+		}
+
+		message := &channels.Message{
+			DeliveryID: delivery.ID,
+			Target:     delivery.Target,
+			Secret:     preference.Secret,
+			Event:      delivery.Event,
+			Title:      delivery.Title,
+			Text:       delivery.Message,
+			CreatedAt:  delivery.CreatedAt,
+		}
+		if delivery.Data != nil {
+			message.Data = delivery.Data
+		}
+
+		resumed++
+
+		d.wg.Add(1)
+		go d.deliver(channel, delivery, message)
+	}
+
+	if resumed > 0 {
+		logger.InfoLog("Resumed %d pending notification deliveries", resumed)
+	}
+
+	return resumed, nil
+}
+
+// Отправка с повторами; каждая попытка отражается в журнале
+func (d *dispatcher) deliver(channel channels.Channel, delivery *domain.Delivery, message *channels.Message) {
+	defer d.wg.Done()
+
+	// Запись в журнал не прерывается остановкой диспетчера
+	saveCtx := context.WithoutCancel(d.ctx)
+
+	// Возобновлённая доставка ждёт попытки, назначенной до остановки
+	if delivery.NextRetryAt != nil && !d.wait(time.Until(*delivery.NextRetryAt)) {
+		return
+	}
+
+	for attempt := delivery.Attempts + 1; ; attempt++ {
+		err := channel.Send(d.ctx, message)
+		now := time.Now()
+
+		delivery.Attempts = attempt
+		delivery.NextRetryAt = nil
+
+		switch {
+		case err == nil:
+			delivery.Status = models.DeliverySent
+			delivery.SentAt = &now
+			delivery.LastError = ""
+		case errors.Is(err, models.ErrDeliveryRejected) || attempt >= d.retry.MaxAttempts:
+			delivery.Status = models.DeliveryFailed
+			delivery.LastError = err.Error()
+		default:
+			next := now.Add(d.retry.Delay(attempt))
+			delivery.NextRetryAt = &next
+			delivery.LastError = err.Error()
+		}
+
+		if saveErr := d.deliveriesRepo.Update(saveCtx, delivery); saveErr != nil {
+			logger.ErrorLog("Failed to update delivery %s: %v", delivery.ID, saveErr)
+		}
+
+		if delivery.Status != models.DeliveryPending {
+			if err != nil {
+				logger.ErrorLog("Notification %s via %s failed after %d attempts: %v", delivery.ID, delivery.Channel, attempt, err)
+			}
+			return
+		}
+
+		if !d.wait(time.Until(*delivery.NextRetryAt)) {
+			return
+		}
+	}
+}
+
+// Пауза перед попыткой; false, если диспетчер остановлен
+func (d *dispatcher) wait(delay time.Duration) bool {
+	if delay <= 0 {
+		return d.ctx.Err() == nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-d.ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func (d *dispatcher) Close() {
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		return
+	}
+	d.closed = true
+	d.mu.Unlock()
+
+	d.cancel()
+	d.wg.Wait()
+
+	logger.InfoLog("Notification dispatcher stopped")
+}
@@ -0,0 +1,313 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"invest-mate/internal/notifications/channels"
+	"invest-mate/internal/notifications/models"
+	"invest-mate/internal/notifications/models/domain"
+)
+
+// Настройки каналов в памяти
+type memoryPreferences struct {
+	preferences []*domain.ChannelPreference
+}
+
+func (r *memoryPreferences) GetByUser(ctx context.Context, userID string) ([]*domain.ChannelPreference, error) {
+	result := make([]*domain.ChannelPreference, 0)
+	for _, preference := range r.preferences {
+		if preference.UserID == userID {
+			result = append(result, preference)
+		}
+	}
+	return result, nil
+}
+
+func (r *memoryPreferences) FindByChannel(ctx context.Context, userID string, channel models.ChannelType) (*domain.ChannelPreference, error) {
+	for _, preference := range r.preferences {
+		if preference.UserID == userID && preference.Channel == channel {
+			return preference, nil
+		}
+	}
+	return nil, models.ErrPreferenceNotFound
+}
+
+func (r *memoryPreferences) Save(ctx context.Context, preference *domain.ChannelPreference) error {
+	return nil
+}
+
+func (r *memoryPreferences) Delete(ctx context.Context, userID string, channel models.ChannelType) (bool, error) {
+	return false, nil
+}
+
+// Журнал доставки в памяти
+type memoryDeliveries struct {
+	mu         sync.Mutex
+	deliveries map[string]*domain.Delivery
+	next       int
+}
+
+func newMemoryDeliveries() *memoryDeliveries {
+	return &memoryDeliveries{deliveries: make(map[string]*domain.Delivery)}
+}
+
+func (r *memoryDeliveries) Create(ctx context.Context, delivery *domain.Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.next++
+	delivery.ID = fmt.Sprintf("delivery-%d", r.next)
+	delivery.CreatedAt = time.Now()
+	r.deliveries[delivery.ID] = delivery.Copy()
+	return nil
+}
+
+func (r *memoryDeliveries) Update(ctx context.Context, delivery *domain.Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deliveries[delivery.ID] = delivery.Copy()
+	return nil
+}
+
+func (r *memoryDeliveries) GetListByUser(ctx context.Context, userID string, filter *domain.DeliveriesFilter, limit, offset int) ([]*domain.Delivery, error) {
+	return nil, nil
+}
+
+func (r *memoryDeliveries) CountByUser(ctx context.Context, userID string, filter *domain.DeliveriesFilter) (int64, error) {
+	return 0, nil
+}
+
+func (r *memoryDeliveries) GetPending(ctx context.Context) ([]*domain.Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]*domain.Delivery, 0)
+	for _, delivery := range r.deliveries {
+		if delivery.Status == models.DeliveryPending {
+			result = append(result, delivery.Copy())
+		}
+	}
+	return result, nil
+}
+
+func (r *memoryDeliveries) get(id string) *domain.Delivery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.deliveries[id].Copy()
+}
+
+// Ожидание завершения доставки
+func waitDelivery(t *testing.T, repo *memoryDeliveries, id string) *domain.Delivery {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if delivery := repo.get(id); delivery.Status != models.DeliveryPending {
+			return delivery
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	t.Fatalf("delivery %s is still pending", id)
+	return nil
+}
+
+// Сервер вебхука, отвечающий заданными статусами по очереди и проверяющий подпись
+type webhookServer struct {
+	*httptest.Server
+	requests atomic.Int32
+	bodies   chan []byte
+}
+
+func newWebhookServer(t *testing.T, secret string, statuses ...int) *webhookServer {
+	server := &webhookServer{bodies: make(chan []byte, 10)}
+
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		signature := "sha256=" + channels.SignWebhook(secret, r.Header.Get(channels.WebhookTimestampHeader), body)
+		if r.Header.Get(channels.WebhookSignatureHeader) != signature {
+			t.Errorf("request has invalid signature")
+		}
+
+		index := int(server.requests.Add(1)) - 1
+		server.bodies <- body
+
+		status := http.StatusOK
+		if index < len(statuses) {
+			status = statuses[index]
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newTestDispatcher(preferences *memoryPreferences, deliveries *memoryDeliveries, attempts int) Dispatcher {
+	return NewDispatcher(
+		preferences,
+		deliveries,
+		RetryPolicy{MaxAttempts: attempts, BaseDelay: 10 * time.Millisecond},
+		channels.NewWebhookChannel(time.Second, true),
+	)
+}
+
+func webhookPreference(target string) *domain.ChannelPreference {
+	return &domain.ChannelPreference{
+		UserID:    "user-1",
+		Channel:   models.ChannelWebhook,
+		Target:    target,
+		Secret:    "secret",
+		IsEnabled: true,
+	}
+}
+
+func TestDispatcherRetriesUntilDelivered(t *testing.T) {
+	server := newWebhookServer(t, "secret", http.StatusServiceUnavailable, http.StatusInternalServerError)
+	deliveries := newMemoryDeliveries()
+	dispatcher := newTestDispatcher(&memoryPreferences{preferences: []*domain.ChannelPreference{webhookPreference(server.URL)}}, deliveries, 5)
+	defer dispatcher.Close()
+
+	created, err := dispatcher.Notify(t.Context(), &domain.Notification{UserID: "user-1", Event: models.EventTest, Title: "Test", Message: "Hello"})
+	if err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if len(created) != 1 {
+		t.Fatalf("Notify() created %d deliveries, want 1", len(created))
+	}
+
+	delivery := waitDelivery(t, deliveries, created[0].ID)
+
+	if delivery.Status != models.DeliverySent {
+		t.Errorf("status = %s, want SENT (last error %q)", delivery.Status, delivery.LastError)
+	}
+	if delivery.Attempts != 3 {
+		t.Errorf("attempts = %d, want 3", delivery.Attempts)
+	}
+	if got := server.requests.Load(); got != 3 {
+		t.Errorf("server got %d requests, want 3", got)
+	}
+}
+
+func TestDispatcherDoesNotRetryRejectedDelivery(t *testing.T) {
+	server := newWebhookServer(t, "secret", http.StatusGone)
+	deliveries := newMemoryDeliveries()
+	dispatcher := newTestDispatcher(&memoryPreferences{preferences: []*domain.ChannelPreference{webhookPreference(server.URL)}}, deliveries, 5)
+	defer dispatcher.Close()
+
+	created, err := dispatcher.Notify(t.Context(), &domain.Notification{UserID: "user-1", Event: models.EventTest, Message: "Hello"})
+	if err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	delivery := waitDelivery(t, deliveries, created[0].ID)
+
+	if delivery.Status != models.DeliveryFailed || delivery.Attempts != 1 {
+		t.Errorf("delivery = %s after %d attempts, want FAILED after 1", delivery.Status, delivery.Attempts)
+	}
+	if !strings.Contains(delivery.LastError, "410") {
+		t.Errorf("last error = %q, want status 410", delivery.LastError)
+	}
+}
+
+func TestDispatcherGivesUpAfterMaxAttempts(t *testing.T) {
+	server := newWebhookServer(t, "secret", 503, 503, 503, 503)
+	deliveries := newMemoryDeliveries()
+	dispatcher := newTestDispatcher(&memoryPreferences{preferences: []*domain.ChannelPreference{webhookPreference(server.URL)}}, deliveries, 2)
+	defer dispatcher.Close()
+
+	created, err := dispatcher.Notify(t.Context(), &domain.Notification{UserID: "user-1", Event: models.EventTest, Message: "Hello"})
+	if err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	delivery := waitDelivery(t, deliveries, created[0].ID)
+
+	if delivery.Status != models.DeliveryFailed || delivery.Attempts != 2 {
+		t.Errorf("delivery = %s after %d attempts, want FAILED after 2", delivery.Status, delivery.Attempts)
+	}
+}
+
+func TestDispatcherResumesPendingDeliveries(t *testing.T) {
+	server := newWebhookServer(t, "secret")
+	deliveries := newMemoryDeliveries()
+	preferences := &memoryPreferences{preferences: []*domain.ChannelPreference{webhookPreference(server.URL)}}
+
+	// Доставка, прерванная остановкой сервера после двух попыток
+	past := time.Now().Add(-time.Minute)
+	interrupted := &domain.Delivery{
+		UserID:      "user-1",
+		Channel:     models.ChannelWebhook,
+		Target:      server.URL,
+		Event:       models.EventAlertTriggered,
+		Title:       "SBER",
+		Message:     "Цена выше 300",
+		Data:        json.RawMessage(`{"alertId":"alert-1"}`),
+		Status:      models.DeliveryPending,
+		Attempts:    2,
+		NextRetryAt: &past,
+	}
+	_ = deliveries.Create(t.Context(), interrupted)
+
+	// Доставка в канал, который пользователь с тех пор отключил
+	orphaned := &domain.Delivery{UserID: "user-2", Channel: models.ChannelWebhook, Target: server.URL, Status: models.DeliveryPending}
+	_ = deliveries.Create(t.Context(), orphaned)
+
+	dispatcher := newTestDispatcher(preferences, deliveries, 5)
+	defer dispatcher.Close()
+
+	resumed, err := dispatcher.Resume(t.Context())
+	if err != nil {
+		t.Fatalf("Resume() error = %v", err)
+	}
+	if resumed != 1 {
+		t.Errorf("Resume() = %d, want 1", resumed)
+	}
+
+	delivery := waitDelivery(t, deliveries, interrupted.ID)
+	if delivery.Status != models.DeliverySent || delivery.Attempts != 3 {
+		t.Errorf("delivery = %s after %d attempts, want SENT after 3", delivery.Status, delivery.Attempts)
+	}
+
+	var payload channels.WebhookPayload
+	if err := json.Unmarshal(<-server.bodies, &payload); err != nil {
+		t.Fatalf("payload is not JSON: %v", err)
+	}
+	if data, _ := payload.Data.(map[string]any); data["alertId"] != "alert-1" {
+		t.Errorf("payload data = %v, want saved alert data", payload.Data)
+	}
+
+	if got := deliveries.get(orphaned.ID); got.Status != models.DeliveryFailed {
+		t.Errorf("orphaned delivery status = %s, want FAILED", got.Status)
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 20, BaseDelay: time.Second}
+
+	tests := map[int]time.Duration{
+		1:  time.Second,
+		2:  2 * time.Second,
+		4:  8 * time.Second,
+		15: maxRetryDelay,
+	}
+
+	for attempt, want := range tests {
+		if got := policy.Delay(attempt); got != want {
+			t.Errorf("Delay(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"invest-mate/internal/notifications/models"
+	"invest-mate/internal/notifications/models/domain"
+	"invest-mate/internal/notifications/repository"
+	"invest-mate/pkg/logger"
+	"invest-mate/pkg/services"
+)
+
+// Длина ключа подписи вебхука в байтах
+const webhookSecretBytes = 32
+
+type NotificationsService interface {
+	GetChannels(ctx context.Context, userID string) ([]*domain.ChannelPreference, error)
+	SetChannel(ctx context.Context, userID string, channel models.ChannelType, req *domain.SetChannelRequest) (*domain.ChannelPreference, error)
+	DeleteChannel(ctx context.Context, userID string, channel models.ChannelType) (bool, error)
+	SendTest(ctx context.Context, userID string) ([]*domain.Delivery, error)
+	GetDeliveries(ctx context.Context, userID string, filter *domain.DeliveriesFilter, page, limit int) ([]*domain.Delivery, int64, error)
+}
+
+type notificationsService struct {
+	preferencesRepo repository.PreferencesRepository
+	deliveriesRepo  repository.DeliveriesRepository
+	dispatcher      Dispatcher
+}
+
+// Создание нового сервиса уведомлений
+func NewNotificationsService(
+	preferencesRepo repository.PreferencesRepository,
+	deliveriesRepo repository.DeliveriesRepository,
+	dispatcher Dispatcher,
+) NotificationsService {
+	return &notificationsService{
+		preferencesRepo: preferencesRepo,
+		deliveriesRepo:  deliveriesRepo,
+		dispatcher:      dispatcher,
+	}
+}
+
+// Получение настроек каналов пользователя
+func (s *notificationsService) GetChannels(ctx context.Context, userID string) ([]*domain.ChannelPreference, error) {
+	return s.preferencesRepo.GetByUser(ctx, userID)
+}
+
+// Подключение или изменение канала пользователя
+func (s *notificationsService) SetChannel(ctx context.Context, userID string, channelType models.ChannelType, req *domain.SetChannelRequest) (*domain.ChannelPreference, error) {
+	channelType = models.ChannelType(strings.ToUpper(string(channelType)))
+	if !channelType.IsValid() {
+		return nil, fmt.Errorf("%w: channel must be EMAIL, WEBHOOK or TELEGRAM", models.ErrInvalidRequest)
+	}
+
+	channel, ok := s.dispatcher.Channel(channelType)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", models.ErrChannelUnavailable, channelType)
+	}
+
+	events, err := normalizeEvents(req.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	preference, err := s.preferencesRepo.FindByChannel(ctx, userID, channelType)
+	if err != nil {
+		if !errors.Is(err, models.ErrPreferenceNotFound) {
+			return nil, err
+		}
+
+		preference = &domain.ChannelPreference{
+			ID:        uuid.New().String(),
+			UserID:    userID,
+			Channel:   channelType,
+			IsEnabled: true,
+		}
+	}
+
+	target := strings.TrimSpace(req.Target)
+	if target == "" {
+		target = preference.Target
+	}
+
+	if err := channel.ValidateTarget(target); err != nil {
+		return nil, err
+	}
+
+	preference.Target = target
+	preference.Events = events
+
+	if req.IsEnabled != nil {
+		preference.IsEnabled = *req.IsEnabled
+	}
+
+	if channelType == models.ChannelWebhook && (preference.Secret == "" || req.RotateSecret) {
+		secret, err := generateSecret()
+		if err != nil {
+			return nil, err
+		}
+		preference.Secret = secret
+	}
+
+	if err := s.preferencesRepo.Save(ctx, preference); err != nil {
+		return nil, err
+	}
+
+	logger.InfoLog("Notification channel %s saved for user %s", channelType, userID)
+
+	return preference, nil
+}
+
+// Отключение канала пользователя
+func (s *notificationsService) DeleteChannel(ctx context.Context, userID string, channelType models.ChannelType) (bool, error) {
+	channelType = models.ChannelType(strings.ToUpper(string(channelType)))
+	if !channelType.IsValid() {
+		return false, fmt.Errorf("%w: channel must be EMAIL, WEBHOOK or TELEGRAM", models.ErrInvalidRequest)
+	}
+
+	return s.preferencesRepo.Delete(ctx, userID, channelType)
+}
+
+// Проверочное уведомление во все включённые каналы пользователя
+func (s *notificationsService) SendTest(ctx context.Context, userID string) ([]*domain.Delivery, error) {
+	deliveries, err := s.dispatcher.Notify(ctx, &domain.Notification{
+		UserID:  userID,
+		Event:   models.EventTest,
+		Title:   "Invest Mate: проверка уведомлений",
+		Message: "Канал уведомлений настроен и работает.",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(deliveries) == 0 {
+		return nil, models.ErrNoChannels
+	}
+
+	return deliveries, nil
+}
+
+// Получение журнала доставки уведомлений пользователя
+func (s *notificationsService) GetDeliveries(ctx context.Context, userID string, filter *domain.DeliveriesFilter, page, limit int) ([]*domain.Delivery, int64, error) {
+	filter.Channel = models.ChannelType(strings.ToUpper(string(filter.Channel)))
+	if filter.Channel != "" && !filter.Channel.IsValid() {
+		return nil, 0, fmt.Errorf("%w: channel must be EMAIL, WEBHOOK or TELEGRAM", models.ErrInvalidRequest)
+	}
+
+	filter.Status = models.DeliveryStatus(strings.ToUpper(string(filter.Status)))
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, 0, fmt.Errorf("%w: status must be PENDING, SENT or FAILED", models.ErrInvalidRequest)
+	}
+
+	limit, offset := services.PageOffset(page, limit)
+
+	deliveries, err := s.deliveriesRepo.GetListByUser(ctx, userID, filter, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.deliveriesRepo.CountByUser(ctx, userID, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return deliveries, total, nil
+}
+
+// Проверка событий подписки и удаление повторов
+func normalizeEvents(events []models.EventType) ([]models.EventType, error) {
+	result := make([]models.EventType, 0, len(events))
+	seen := make(map[models.EventType]struct{}, len(events))
+
+	for _, event := range events {
+		event = models.EventType(strings.ToUpper(string(event)))
+		if !event.IsValid() {
+			return nil, fmt.Errorf("%w: unknown event %s", models.ErrInvalidRequest, event)
+		}
+
+		if _, ok := seen[event]; ok {
+			continue
+		}
+
+		seen[event] = struct{}{}
+		result = append(result, event)
+	}
+
+	return result, nil
+}
+
+// Случайный ключ подписи вебхука
+func generateSecret() (string, error) {
+	secret := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,48 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+
+	"invest-mate/internal/portfolios/models/domain"
+)
+
+// Метка порядка байтов, по которой Excel распознаёт UTF-8
+var utf8Bom = []byte{0xEF, 0xBB, 0xBF}
+
+// Запись таблицы в CSV построчно по мере обхода строк
+func WriteCsv(w io.Writer, table *domain.ExportTable, format Format) error {
+	format = format.withDefaults()
+
+	if _, err := w.Write(utf8Bom); err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = format.Delimiter
+	writer.UseCRLF = true
+
+	record := make([]string, len(table.Columns))
+
+	for i, column := range table.Columns {
+		record[i] = column.Title
+	}
+	if err := writer.Write(record); err != nil {
+		return err
+	}
+
+	err := table.Rows(func(row []any) error {
+		for i, value := range row {
+			record[i] = format.text(value)
+		}
+
+		return writer.Write(record)
+	})
+	if err != nil {
+		return err
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
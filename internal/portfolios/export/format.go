@@ -0,0 +1,98 @@
+package export
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Символы, с которых Excel и другие табличные редакторы начинают формулу
+const formulaPrefixes = "=+-@\t\r"
+
+// Формат даты по умолчанию
+const DefaultDateFormat = "2006-01-02"
+
+// Оформление значений в выгрузке
+type Format struct {
+	// Десятичная запятая вместо точки
+	DecimalComma bool
+	// Формат даты в нотации Go
+	DateFormat string
+	// Часовой пояс дат
+	Location *time.Location
+	// Разделитель полей CSV
+	Delimiter rune
+}
+
+// Оформление с подставленными значениями по умолчанию
+func (f Format) withDefaults() Format {
+	if f.DateFormat == "" {
+		f.DateFormat = DefaultDateFormat
+	}
+
+	if f.Location == nil {
+		f.Location = time.UTC
+	}
+
+	if f.Delimiter == 0 {
+		// При десятичной запятой поля разделяются точкой с запятой, как в русской локали Excel
+		f.Delimiter = ','
+		if f.DecimalComma {
+			f.Delimiter = ';'
+		}
+	}
+
+	return f
+}
+
+// Число без экспоненты и лишних нулей
+func (f Format) number(value float64) string {
+	text := strconv.FormatFloat(value, 'f', -1, 64)
+	if f.DecimalComma {
+		text = strings.Replace(text, ".", ",", 1)
+	}
+
+	return text
+}
+
+// Дата в выбранном формате и часовом поясе (пустая строка для нулевой даты)
+func (f Format) date(value time.Time) string {
+	if value.IsZero() {
+		return ""
+	}
+
+	return value.In(f.Location).Format(f.DateFormat)
+}
+
+// Текстовое представление значения ячейки
+func (f Format) text(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return escapeFormula(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return f.number(v)
+	case *float64:
+		if v == nil {
+			return ""
+		}
+		return f.number(*v)
+	case time.Time:
+		return f.date(v)
+	default:
+		return ""
+	}
+}
+
+// Защита от подстановки формул: текст, начинающийся с символа формулы,
+// предваряется апострофом и открывается в редакторе как обычная строка
+func escapeFormula(value string) string {
+	if value != "" && strings.IndexByte(formulaPrefixes, value[0]) >= 0 {
+		return "'" + value
+	}
+
+	return value
+}
@@ -0,0 +1,280 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+)
+
+// Названия листов по наборам данных
+var sheetNames = map[models.ExportDataset]string{
+	models.ExportDatasetPositions:    "Позиции",
+	models.ExportDatasetTransactions: "Операции",
+	models.ExportDatasetIncome:       "Доход",
+	models.ExportDatasetAnalytics:    "Аналитика",
+}
+
+// Стили ячеек из styles.xml
+const (
+	styleHeader = 1
+	styleDate   = 2
+)
+
+// Начало отсчёта дат Excel
+var excelEpoch = time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+
+const xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>
+</Types>`
+
+const xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+
+const xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+</Relationships>`
+
+const xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`
+
+const xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<numFmts count="1"><numFmt numFmtId="164" formatCode="%s"/></numFmts>
+<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>
+<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="3"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/><xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/></cellXfs>
+</styleSheet>`
+
+// Запись таблицы в книгу XLSX с одним листом. Числа и даты сохраняются значениями,
+// поэтому десятичный разделитель задаёт локаль Excel; формат даты переводится в формат ячеек
+func WriteXlsx(w io.Writer, table *domain.ExportTable, format Format) error {
+	format = format.withDefaults()
+
+	// Даты пишутся текстом, если формат нельзя выразить средствами Excel
+	dateCode, datesAsNumbers := excelDateFormat(format.DateFormat)
+
+	archive := zip.NewWriter(w)
+
+	sheetName := sheetNames[table.Dataset]
+	if sheetName == "" {
+		sheetName = "Лист1"
+	}
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/workbook.xml", strings.Replace(xlsxWorkbook, "%s", escapeXml(sheetName), 1)},
+		{"xl/styles.xml", strings.Replace(xlsxStyles, "%s", escapeXml(dateCode), 1)},
+	}
+
+	for _, part := range parts {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(file, part.content); err != nil {
+			return err
+		}
+	}
+
+	file, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+
+	sheet := bufio.NewWriter(file)
+
+	sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	sheet.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	sheet.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	sheet.WriteString(`<sheetData>`)
+
+	sheet.WriteString(`<row r="1">`)
+	for i, column := range table.Columns {
+		writeStringCell(sheet, cellRef(i, 1), column.Title, styleHeader)
+	}
+	sheet.WriteString(`</row>`)
+
+	line := 1
+
+	err = table.Rows(func(row []any) error {
+		line++
+
+		sheet.WriteString(`<row r="` + strconv.Itoa(line) + `">`)
+		for i, value := range row {
+			ref := cellRef(i, line)
+
+			switch v := value.(type) {
+			case int64:
+				writeNumberCell(sheet, ref, strconv.FormatInt(v, 10), 0)
+			case float64:
+				writeFloatCell(sheet, ref, v, format)
+			case *float64:
+				if v != nil {
+					writeFloatCell(sheet, ref, *v, format)
+				}
+			case time.Time:
+				switch {
+				case v.IsZero():
+				case datesAsNumbers:
+					writeNumberCell(sheet, ref, strconv.FormatFloat(excelSerial(v.In(format.Location)), 'f', -1, 64), styleDate)
+				default:
+					writeStringCell(sheet, ref, format.date(v), 0)
+				}
+			default:
+				if text := format.text(v); text != "" {
+					writeStringCell(sheet, ref, text, 0)
+				}
+			}
+		}
+		_, err := sheet.WriteString(`</row>`)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	sheet.WriteString(`</sheetData></worksheet>`)
+
+	if err := sheet.Flush(); err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+// Число с плавающей точкой (бесконечность и NaN пишутся текстом)
+func writeFloatCell(sheet *bufio.Writer, ref string, value float64, format Format) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		writeStringCell(sheet, ref, format.number(value), 0)
+		return
+	}
+
+	writeNumberCell(sheet, ref, strconv.FormatFloat(value, 'f', -1, 64), 0)
+}
+
+func writeNumberCell(sheet *bufio.Writer, ref, value string, style int) {
+	sheet.WriteString(`<c r="` + ref + `"`)
+	if style != 0 {
+		sheet.WriteString(` s="` + strconv.Itoa(style) + `"`)
+	}
+	sheet.WriteString(`><v>` + value + `</v></c>`)
+}
+
+func writeStringCell(sheet *bufio.Writer, ref, value string, style int) {
+	sheet.WriteString(`<c r="` + ref + `" t="inlineStr"`)
+	if style != 0 {
+		sheet.WriteString(` s="` + strconv.Itoa(style) + `"`)
+	}
+	sheet.WriteString(`><is><t xml:space="preserve">` + escapeXml(value) + `</t></is></c>`)
+}
+
+// Адрес ячейки по номеру колонки (с нуля) и строки (с единицы), например AB12
+func cellRef(column, row int) string {
+	name := ""
+	for column++; column > 0; column = (column - 1) / 26 {
+		name = string(rune('A'+(column-1)%26)) + name
+	}
+
+	return name + strconv.Itoa(row)
+}
+
+// Дата и время в виде дней от начала отсчёта Excel по часам часового пояса даты
+func excelSerial(value time.Time) float64 {
+	wall := time.Date(value.Year(), value.Month(), value.Day(), value.Hour(), value.Minute(), value.Second(), value.Nanosecond(), time.UTC)
+
+	return wall.Sub(excelEpoch).Hours() / 24
+}
+
+// Перевод формата даты Go в формат ячеек Excel; false, если в формате есть
+// элементы без аналога (12-часовое время, дни недели, часовой пояс, доли секунды)
+func excelDateFormat(layout string) (string, bool) {
+	tokens := []struct {
+		golang string
+		excel  string
+	}{
+		{"January", "mmmm"},
+		{"2006", "yyyy"},
+		{"Jan", "mmm"},
+		{"01", "mm"},
+		{"02", "dd"},
+		{"15", "hh"},
+		{"04", "mm"},
+		{"05", "ss"},
+		{"06", "yy"},
+		{"1", "m"},
+		{"2", "d"},
+	}
+
+	var code strings.Builder
+
+	for layout != "" {
+		matched := false
+
+		for _, token := range tokens {
+			if strings.HasPrefix(layout, token.golang) {
+				code.WriteString(token.excel)
+				layout = layout[len(token.golang):]
+				matched = true
+				break
+			}
+		}
+
+		if matched {
+			continue
+		}
+
+		char := layout[0]
+		switch {
+		case char >= '0' && char <= '9', char == '_', char >= 0x80:
+			return "", false
+		case strings.HasPrefix(layout, "Mon"), strings.HasPrefix(layout, "MST"),
+			strings.HasPrefix(layout, "PM"), strings.HasPrefix(layout, "pm"), char == 'Z':
+			return "", false
+		case strings.IndexByte(" .,:/-", char) >= 0:
+			code.WriteByte(char)
+		default:
+			// Прочие символы экранируются
+			code.WriteByte('\\')
+			code.WriteByte(char)
+		}
+
+		layout = layout[1:]
+	}
+
+	return code.String(), true
+}
+
+// Экранирование текста для XML
+func escapeXml(value string) string {
+	var escaped strings.Builder
+	xml.EscapeText(&escaped, []byte(value))
+
+	return escaped.String()
+}
@@ -0,0 +1,55 @@
+package export
+
+import "testing"
+
+func TestExcelDateFormat(t *testing.T) {
+	tests := []struct {
+		layout string
+		want   string
+	}{
+		{DefaultDateFormat, "yyyy-mm-dd"},
+		{"02.01.2006", "dd.mm.yyyy"},
+		{"02.01.2006 15:04:05", "dd.mm.yyyy hh:mm:ss"},
+		{"1/2/06", "m/d/yy"},
+		{"Jan 2, 2006", "mmm d, yyyy"},
+		{"January 2006", "mmmm yyyy"},
+		// Буквы без аналога в Go экранируются, чтобы Excel не принял их за коды формата
+		{"2006-01-02T15:04", `yyyy-mm-dd\Thh:mm`},
+		{"2006 'year'", `yyyy \'\y\e\a\r\'`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.layout, func(t *testing.T) {
+			got, ok := excelDateFormat(tt.layout)
+			if !ok {
+				t.Fatalf("excelDateFormat(%q) is not supported", tt.layout)
+			}
+			if got != tt.want {
+				t.Errorf("excelDateFormat(%q) = %q, want %q", tt.layout, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExcelDateFormatUnsupported(t *testing.T) {
+	// Такие даты записываются в ячейки строками в формате Go
+	layouts := []string{
+		"3:04PM",
+		"03:04 pm",
+		"Mon 02.01.2006",
+		"Monday",
+		"2006-01-02 MST",
+		"2006-01-02T15:04:05Z07:00",
+		"15:04:05.000",
+		"_2.01.2006",
+		"02.01.2006 г.",
+	}
+
+	for _, layout := range layouts {
+		t.Run(layout, func(t *testing.T) {
+			if code, ok := excelDateFormat(layout); ok {
+				t.Errorf("excelDateFormat(%q) = %q, want unsupported", layout, code)
+			}
+		})
+	}
+}
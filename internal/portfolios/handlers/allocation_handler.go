@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/pkg/handlers"
+)
+
+// Обработчик получения целевого распределения портфеля
+func (h *PortfoliosHandler) GetTargets(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	targets, err := h.allocationService.GetTargets(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(targets))
+}
+
+// Обработчик замены целевого распределения портфеля
+func (h *PortfoliosHandler) SetTargets(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.SetTargetAllocationsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	targets, err := h.allocationService.SetTargets(c.Request.Context(), userID, c.Param("id"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(targets))
+}
+
+// Обработчик удаления целевого распределения портфеля
+func (h *PortfoliosHandler) DeleteTargets(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.allocationService.DeleteTargets(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(result))
+}
+
+// Обработчик расчёта ребалансировки портфеля
+func (h *PortfoliosHandler) GetRebalancePlan(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	cash := 0.0
+	if value := c.Query("cash"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			respondError(c, fmt.Errorf("%w: cash must be a number", models.ErrInvalidRequest))
+			return
+		}
+		cash = parsed
+	}
+
+	plan, err := h.allocationService.GetRebalancePlan(c.Request.Context(), userID, c.Param("id"), c.Query("currency"), cash)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(plan))
+}
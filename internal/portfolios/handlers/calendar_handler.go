@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"invest-mate/pkg/handlers"
+)
+
+// Обработчик получения календаря выплат по бумагам пользователя
+func (h *PortfoliosHandler) GetCalendar(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	from, err := parseTimeQuery(c, "from")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	to, err := parseTimeQuery(c, "to")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	calendar, err := h.calendarService.GetCalendar(c.Request.Context(), userID, c.Query("portfolioId"), from, to)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(calendar))
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/pkg/handlers"
+)
+
+// Обработчик получения дочерних портфелей
+func (h *PortfoliosHandler) GetChildren(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	children, err := h.compositeService.GetChildren(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(children))
+}
+
+// Обработчик добавления дочернего портфеля
+func (h *PortfoliosHandler) AttachChild(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.AttachChildRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	children, err := h.compositeService.AttachChild(c.Request.Context(), userID, c.Param("id"), req.ChildID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(children))
+}
+
+// Обработчик исключения дочернего портфеля
+func (h *PortfoliosHandler) DetachChild(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.compositeService.DetachChild(c.Request.Context(), userID, c.Param("id"), c.Param("childId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(result))
+}
+
+// Обработчик получения сводных данных по дереву портфелей
+func (h *PortfoliosHandler) GetAggregate(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	aggregate, err := h.compositeService.GetAggregate(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(aggregate))
+}
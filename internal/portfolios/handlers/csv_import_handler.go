@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/pkg/handlers"
+)
+
+// Наибольший размер файла выписки (5 МБ)
+const maxCsvFileSize = 5 << 20
+
+// Обработчик предпросмотра загрузки выписки из CSV
+func (h *PortfoliosHandler) PreviewCsvImport(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	content, options, ok := readCsvUpload(c)
+	if !ok {
+		return
+	}
+
+	preview, err := h.csvImportService.PreviewCsv(c.Request.Context(), userID, c.Param("id"), content, options)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(preview))
+}
+
+// Обработчик загрузки операций из CSV-выписки в портфель
+func (h *PortfoliosHandler) ImportCsv(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	content, options, ok := readCsvUpload(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.csvImportService.ImportCsv(c.Request.Context(), userID, c.Param("id"), content, options)
+	if errors.Is(err, models.ErrImportRowsInvalid) {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error(), "data": result})
+		return
+	}
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(result))
+}
+
+// Чтение файла выписки (поле file) и настроек разбора (поле options в JSON)
+func readCsvUpload(c *gin.Context) ([]byte, *domain.CsvImportOptions, bool) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxCsvFileSize+1<<20)
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File is required"})
+		return nil, nil, false
+	}
+
+	if header.Size > maxCsvFileSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "File is too large"})
+		return nil, nil, false
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file"})
+		return nil, nil, false
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file"})
+		return nil, nil, false
+	}
+
+	options := &domain.CsvImportOptions{}
+	if raw := c.PostForm("options"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), options); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid import options"})
+			return nil, nil, false
+		}
+	}
+
+	return content, options, true
+}
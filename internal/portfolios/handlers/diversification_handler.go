@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/pkg/handlers"
+)
+
+// Обработчик получения структуры портфеля по измерению
+func (h *PortfoliosHandler) GetDiversification(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	top := 0
+	if value := c.Query("top"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			respondError(c, fmt.Errorf("%w: top must be a number", models.ErrInvalidRequest))
+			return
+		}
+		top = parsed
+	}
+
+	dimension := models.AllocationDimension(c.Query("dimension"))
+
+	diversification, err := h.diversificationService.GetDiversification(c.Request.Context(), userID, c.Param("id"), dimension, c.Query("currency"), top)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(diversification))
+}
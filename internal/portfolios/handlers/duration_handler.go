@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"invest-mate/pkg/handlers"
+)
+
+// Обработчик получения дюрации облигационной части портфеля
+func (h *PortfoliosHandler) GetDuration(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	duration, err := h.durationService.GetDuration(c.Request.Context(), userID, c.Param("id"), c.Query("currency"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(duration))
+}
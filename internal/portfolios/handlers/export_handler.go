@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"invest-mate/internal/portfolios/export"
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/pkg/handlers"
+	"invest-mate/pkg/logger"
+)
+
+// Обработчик выгрузки позиций, операций, дохода или аналитики портфеля в CSV или XLSX
+func (h *PortfoliosHandler) ExportPortfolio(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	exportFormat := models.ExportFormat(strings.ToUpper(c.DefaultQuery("format", string(models.ExportFormatCsv))))
+	if !exportFormat.IsValid() {
+		respondError(c, fmt.Errorf("%w: format must be csv or xlsx", models.ErrInvalidRequest))
+		return
+	}
+
+	format, err := parseExportFormat(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	options, err := parseExportOptions(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	dataset := models.ExportDataset(strings.ToUpper(c.Param("dataset")))
+
+	table, err := h.exportService.Export(c.Request.Context(), userID, c.Param("id"), dataset, options)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	fileName := fmt.Sprintf("portfolio-%s-%s", strings.ToLower(string(table.Dataset)), time.Now().Format("20060102"))
+
+	write, contentType := export.WriteCsv, "text/csv; charset=utf-8"
+	if exportFormat == models.ExportFormatXlsx {
+		write, contentType = export.WriteXlsx, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, fileName, strings.ToLower(string(exportFormat))))
+	c.Header("Cache-Control", "no-store")
+	c.Status(http.StatusOK)
+
+	// Заголовки уже отправлены, остаётся только записать ошибку в лог
+	if err := write(c.Writer, table, format); err != nil {
+		logger.ErrorLog("Failed to export portfolio %s: %v", c.Param("id"), err)
+	}
+}
+
+// Разбор оформления выгрузки: decimalComma, dateFormat, timezone и delimiter
+func parseExportFormat(c *gin.Context) (export.Format, error) {
+	format := export.Format{
+		DateFormat: c.Query("dateFormat"),
+	}
+
+	if value := c.Query("decimalComma"); value != "" {
+		decimalComma, err := strconv.ParseBool(value)
+		if err != nil {
+			return format, fmt.Errorf("%w: decimalComma must be true or false", models.ErrInvalidRequest)
+		}
+		format.DecimalComma = decimalComma
+	}
+
+	if value := c.Query("timezone"); value != "" {
+		location, err := time.LoadLocation(value)
+		if err != nil {
+			return format, fmt.Errorf("%w: unknown timezone %q", models.ErrInvalidRequest, value)
+		}
+		format.Location = location
+	}
+
+	switch value := c.Query("delimiter"); value {
+	case "":
+	case "tab", "\t":
+		format.Delimiter = '\t'
+	case ",", ";", "|":
+		format.Delimiter = rune(value[0])
+	default:
+		return format, fmt.Errorf("%w: delimiter must be one of , ; | or tab", models.ErrInvalidRequest)
+	}
+
+	if format.DecimalComma && format.Delimiter == ',' {
+		return format, fmt.Errorf("%w: comma delimiter cannot be used with decimal comma", models.ErrInvalidRequest)
+	}
+
+	return format, nil
+}
+
+// Разбор состава выгрузки: колонки, валюта, период и отбор операций
+func parseExportOptions(c *gin.Context) (*domain.ExportOptions, error) {
+	filter, err := parseTransactionFilter(c)
+	if err != nil {
+		return nil, err
+	}
+
+	options := &domain.ExportOptions{
+		Currency: c.Query("currency"),
+		From:     filter.From,
+		To:       filter.To,
+		Filter:   filter,
+	}
+
+	if value := c.Query("columns"); value != "" {
+		options.Columns = strings.Split(value, ",")
+	}
+
+	return options, nil
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/pkg/handlers"
+)
+
+// Обработчик получения счетов пользователя у брокера
+func (h *PortfoliosHandler) GetBrokerAccounts(c *gin.Context) {
+	if _, ok := handlers.GetUserID(c); !ok {
+		return
+	}
+
+	var req domain.BrokerAccountsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	accounts, err := h.importService.GetBrokerAccounts(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(accounts))
+}
+
+// Обработчик загрузки позиций брокерского счёта в портфель
+func (h *PortfoliosHandler) ImportFromBroker(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.ImportPortfolioRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	result, err := h.importService.ImportFromBroker(c.Request.Context(), userID, c.Param("id"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(result))
+}
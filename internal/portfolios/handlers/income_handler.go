@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/pkg/handlers"
+)
+
+// Обработчик получения дохода по дивидендам и купонам
+func (h *PortfoliosHandler) GetIncome(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	from, err := parseTimeQuery(c, "from")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	to, err := parseTimeQuery(c, "to")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	report, err := h.incomeService.GetIncome(c.Request.Context(), userID, c.Param("id"), from, to)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(report))
+}
+
+// Обработчик получения ставок налога по странам
+func (h *PortfoliosHandler) GetTaxRates(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	rates, err := h.incomeService.GetTaxRates(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(rates))
+}
+
+// Обработчик установки ставки налога для страны
+func (h *PortfoliosHandler) SetTaxRate(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.SetDividendTaxRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	rate, err := h.incomeService.SetTaxRate(c.Request.Context(), userID, c.Param("id"), c.Param("country"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(rate))
+}
+
+// Обработчик удаления ставки налога для страны
+func (h *PortfoliosHandler) DeleteTaxRate(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.incomeService.DeleteTaxRate(c.Request.Context(), userID, c.Param("id"), c.Param("country"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(result))
+}
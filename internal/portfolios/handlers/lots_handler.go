@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/pkg/handlers"
+)
+
+// Обработчик получения открытых партий портфеля
+func (h *PortfoliosHandler) GetOpenLots(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	method, err := parseCostMethod(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	report, err := h.lotsService.GetOpenLots(c.Request.Context(), userID, c.Param("id"), method, c.Query("instrumentUid"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(report))
+}
+
+// Обработчик получения реализованного финансового результата
+func (h *PortfoliosHandler) GetRealizedReport(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	method, err := parseCostMethod(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	from, err := parseTimeQuery(c, "from")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	to, err := parseTimeQuery(c, "to")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	report, err := h.lotsService.GetRealizedReport(c.Request.Context(), userID, c.Param("id"), method, c.Query("instrumentUid"), from, to)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(report))
+}
+
+// Разбор способа списания партий (по умолчанию FIFO)
+func parseCostMethod(c *gin.Context) (models.CostMethod, error) {
+	value := c.Query("method")
+	if value == "" {
+		return models.CostMethodFifo, nil
+	}
+
+	method := models.CostMethod(strings.ToUpper(value))
+	if !method.IsValid() {
+		return "", fmt.Errorf("%w: unknown method %s", models.ErrInvalidRequest, value)
+	}
+
+	return method, nil
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"invest-mate/pkg/handlers"
+)
+
+// Обработчик получения доходности портфеля (TWR и XIRR)
+func (h *PortfoliosHandler) GetPerformance(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	from, err := parseTimeQuery(c, "from")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	to, err := parseTimeQuery(c, "to")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	performance, err := h.performanceService.GetPerformance(c.Request.Context(), userID, c.Param("id"), from, to)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(performance))
+}
@@ -1,21 +1,300 @@
 package handlers
 
 import (
+	"errors"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
 	"invest-mate/internal/portfolios/services"
+	"invest-mate/pkg/handlers"
+	middleware "invest-mate/pkg/middlewares"
 )
 
 type PortfoliosHandler struct {
-	portfoliosService services.PortfoliosService
+	portfoliosService      services.PortfoliosService
+	positionsService       services.PositionsService
+	compositeService       services.CompositeService
+	importService          services.ImportService
+	transactionsService    services.TransactionsService
+	lotsService            services.LotsService
+	incomeService          services.IncomeService
+	valuationService       services.ValuationService
+	performanceService     services.PerformanceService
+	snapshotsService       services.SnapshotsService
+	shareService           services.ShareService
+	allocationService      services.AllocationService
+	calendarService        services.CalendarService
+	durationService        services.DurationService
+	diversificationService services.DiversificationService
+	streamService          services.StreamService
+	csvImportService       services.CsvImportService
+	exportService          services.ExportService
 }
 
 // Создание нового хендлера
-func NewPortfoliosHandler(portfoliosService services.PortfoliosService) *PortfoliosHandler {
-	return &PortfoliosHandler{portfoliosService: portfoliosService}
+func NewPortfoliosHandler(
+	portfoliosService services.PortfoliosService,
+	positionsService services.PositionsService,
+	compositeService services.CompositeService,
+	importService services.ImportService,
+	transactionsService services.TransactionsService,
+	lotsService services.LotsService,
+	incomeService services.IncomeService,
+	valuationService services.ValuationService,
+	performanceService services.PerformanceService,
+	snapshotsService services.SnapshotsService,
+	shareService services.ShareService,
+	allocationService services.AllocationService,
+	calendarService services.CalendarService,
+	durationService services.DurationService,
+	diversificationService services.DiversificationService,
+	streamService services.StreamService,
+	csvImportService services.CsvImportService,
+	exportService services.ExportService,
+) *PortfoliosHandler {
+	return &PortfoliosHandler{
+		portfoliosService:      portfoliosService,
+		positionsService:       positionsService,
+		compositeService:       compositeService,
+		importService:          importService,
+		transactionsService:    transactionsService,
+		lotsService:            lotsService,
+		incomeService:          incomeService,
+		valuationService:       valuationService,
+		performanceService:     performanceService,
+		snapshotsService:       snapshotsService,
+		shareService:           shareService,
+		allocationService:      allocationService,
+		calendarService:        calendarService,
+		durationService:        durationService,
+		diversificationService: diversificationService,
+		streamService:          streamService,
+		csvImportService:       csvImportService,
+		exportService:          exportService,
+	}
 }
 
 // Регистрация маршрутов
 func (h *PortfoliosHandler) RegisterRoutes(router *gin.RouterGroup) {
+	portfolios := router.Group("/portfolios")
+	portfolios.Use(middleware.AuthMiddleware())
+	{
+		portfolios.GET("", h.GetPortfolios)
+		portfolios.POST("", h.CreatePortfolio)
+		portfolios.GET("/calendar", h.GetCalendar)
+		portfolios.GET("/:id", h.GetPortfolio)
+		portfolios.PUT("/:id", h.UpdatePortfolio)
+		portfolios.PATCH("/:id/hidden", h.SetPortfolioHidden)
+		portfolios.DELETE("/:id", h.DeletePortfolio)
+
+		portfolios.GET("/:id/positions", h.GetPositions)
+		portfolios.POST("/:id/positions", h.AddPosition)
+		portfolios.GET("/:id/positions/:positionId", h.GetPosition)
+		portfolios.PUT("/:id/positions/:positionId", h.UpdatePosition)
+		portfolios.DELETE("/:id/positions/:positionId", h.DeletePosition)
+
+		portfolios.GET("/:id/children", h.GetChildren)
+		portfolios.POST("/:id/children", h.AttachChild)
+		portfolios.DELETE("/:id/children/:childId", h.DetachChild)
+		portfolios.GET("/:id/aggregate", h.GetAggregate)
+		portfolios.GET("/:id/valuation", h.GetValuation)
+		portfolios.GET("/:id/performance", h.GetPerformance)
+		portfolios.GET("/:id/duration", h.GetDuration)
+		portfolios.GET("/:id/diversification", h.GetDiversification)
+		portfolios.GET("/:id/stream", h.StreamPortfolio)
+		portfolios.GET("/:id/history", h.GetHistory)
+		portfolios.POST("/:id/snapshots", h.TakeSnapshot)
+
+		portfolios.GET("/:id/share", h.GetShare)
+		portfolios.POST("/:id/share", h.SharePortfolio)
+		portfolios.DELETE("/:id/share", h.RevokeShare)
+
+		portfolios.GET("/:id/targets", h.GetTargets)
+		portfolios.PUT("/:id/targets", h.SetTargets)
+		portfolios.DELETE("/:id/targets", h.DeleteTargets)
+		portfolios.GET("/:id/rebalance", h.GetRebalancePlan)
+
+		portfolios.POST("/broker/accounts", h.GetBrokerAccounts)
+		portfolios.POST("/:id/import", h.ImportFromBroker)
+		portfolios.POST("/:id/import/csv/preview", h.PreviewCsvImport)
+		portfolios.POST("/:id/import/csv", h.ImportCsv)
+		portfolios.GET("/:id/export/:dataset", h.ExportPortfolio)
+
+		portfolios.GET("/:id/transactions", h.GetTransactions)
+		portfolios.POST("/:id/transactions", h.CreateTransaction)
+		portfolios.POST("/:id/transactions/recalculate", h.RecalculatePositions)
+		portfolios.GET("/:id/transactions/:transactionId", h.GetTransaction)
+		portfolios.PUT("/:id/transactions/:transactionId", h.UpdateTransaction)
+		portfolios.DELETE("/:id/transactions/:transactionId", h.DeleteTransaction)
+
+		portfolios.GET("/:id/lots", h.GetOpenLots)
+		portfolios.GET("/:id/realized", h.GetRealizedReport)
+
+		portfolios.GET("/:id/income", h.GetIncome)
+		portfolios.GET("/:id/tax-rates", h.GetTaxRates)
+		portfolios.PUT("/:id/tax-rates/:country", h.SetTaxRate)
+		portfolios.DELETE("/:id/tax-rates/:country", h.DeleteTaxRate)
+	}
+
+	// Публичные маршруты без авторизации
+	public := router.Group("/public/portfolios")
+	{
+		public.GET("/:token", h.GetPublicPortfolio)
+	}
+}
+
+// Обработчик создания портфеля
+func (h *PortfoliosHandler) CreatePortfolio(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.CreatePortfolioRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	portfolio, err := h.portfoliosService.CreatePortfolio(c.Request.Context(), userID, &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, handlers.BuildResponse(portfolio))
+}
+
+// Обработчик получения списка портфелей пользователя
+func (h *PortfoliosHandler) GetPortfolios(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	page, limit := handlers.ParsePaginationParams(c)
+	includeHidden := c.Query("includeHidden") == "true"
+
+	portfolios, total, err := h.portfoliosService.GetPortfolios(c.Request.Context(), userID, includeHidden, page, limit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildListResponse(portfolios, total, page, limit))
+}
+
+// Обработчик получения портфеля по идентификатору
+func (h *PortfoliosHandler) GetPortfolio(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	portfolio, err := h.portfoliosService.GetPortfolio(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(portfolio))
+}
+
+// Обработчик изменения портфеля
+func (h *PortfoliosHandler) UpdatePortfolio(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.UpdatePortfolioRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	portfolio, err := h.portfoliosService.UpdatePortfolio(c.Request.Context(), userID, c.Param("id"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(portfolio))
+}
+
+// Обработчик скрытия/отображения портфеля
+func (h *PortfoliosHandler) SetPortfolioHidden(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.HidePortfolioRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	portfolio, err := h.portfoliosService.SetPortfolioHidden(c.Request.Context(), userID, c.Param("id"), req.IsHidden)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(portfolio))
+}
+
+// Обработчик удаления портфеля
+func (h *PortfoliosHandler) DeletePortfolio(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.portfoliosService.DeletePortfolio(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(result))
+}
+
+// Формирование ответа с ошибкой
+func respondError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, models.ErrPortfolioNotFound),
+		errors.Is(err, models.ErrPositionNotFound),
+		errors.Is(err, models.ErrInstrumentNotFound),
+		errors.Is(err, models.ErrHierarchyLinkNotFound),
+		errors.Is(err, models.ErrTransactionNotFound),
+		errors.Is(err, models.ErrTaxRateNotFound),
+		errors.Is(err, models.ErrTargetsNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, models.ErrPositionAlreadyExists),
+		errors.Is(err, models.ErrHierarchyCycle),
+		errors.Is(err, models.ErrShareTokenTaken):
+		status = http.StatusConflict
+	case errors.Is(err, models.ErrNotCompositePortfolio),
+		errors.Is(err, models.ErrCompositePortfolio),
+		errors.Is(err, models.ErrInsufficientQuantity),
+		errors.Is(err, models.ErrInvalidLotSelection),
+		errors.Is(err, models.ErrExchangeRateNotFound),
+		errors.Is(err, models.ErrImportRowsInvalid):
+		status = http.StatusUnprocessableEntity
+	case errors.Is(err, models.ErrPortfolioAccessDenied):
+		status = http.StatusForbidden
+	case errors.Is(err, models.ErrInvalidRequest):
+		status = http.StatusBadRequest
+	case errors.Is(err, models.ErrBrokerUnavailable),
+		errors.Is(err, models.ErrMarketDataUnavailable):
+		status = http.StatusBadGateway
+	}
 
+	c.JSON(status, gin.H{"error": err.Error()})
 }
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/pkg/handlers"
+)
+
+// Обработчик получения позиций портфеля
+func (h *PortfoliosHandler) GetPositions(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	positions, err := h.positionsService.GetPositions(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(positions))
+}
+
+// Обработчик получения позиции портфеля
+func (h *PortfoliosHandler) GetPosition(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	position, err := h.positionsService.GetPosition(c.Request.Context(), userID, c.Param("id"), c.Param("positionId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(position))
+}
+
+// Обработчик добавления позиции
+func (h *PortfoliosHandler) AddPosition(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.AddPositionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	position, err := h.positionsService.AddPosition(c.Request.Context(), userID, c.Param("id"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, handlers.BuildResponse(position))
+}
+
+// Обработчик изменения позиции
+func (h *PortfoliosHandler) UpdatePosition(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.UpdatePositionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	position, err := h.positionsService.UpdatePosition(c.Request.Context(), userID, c.Param("id"), c.Param("positionId"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(position))
+}
+
+// Обработчик удаления позиции
+func (h *PortfoliosHandler) DeletePosition(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.positionsService.DeletePosition(c.Request.Context(), userID, c.Param("id"), c.Param("positionId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(result))
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/pkg/handlers"
+)
+
+// Обработчик получения состояния открытого доступа к портфелю
+func (h *PortfoliosHandler) GetShare(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	share, err := h.shareService.GetShare(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(share))
+}
+
+// Обработчик выпуска (и замены) токена открытого доступа
+func (h *PortfoliosHandler) SharePortfolio(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.SharePortfolioRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+	}
+
+	share, err := h.shareService.SharePortfolio(c.Request.Context(), userID, c.Param("id"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(share))
+}
+
+// Обработчик отзыва токена открытого доступа
+func (h *PortfoliosHandler) RevokeShare(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	share, err := h.shareService.RevokeShare(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(share))
+}
+
+// Обработчик публичного просмотра портфеля по токену (без авторизации)
+func (h *PortfoliosHandler) GetPublicPortfolio(c *gin.Context) {
+	portfolio, err := h.shareService.GetPublicPortfolio(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(portfolio))
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"invest-mate/pkg/handlers"
+)
+
+// Обработчик получения истории стоимости портфеля
+func (h *PortfoliosHandler) GetHistory(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	from, err := parseTimeQuery(c, "from")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	to, err := parseTimeQuery(c, "to")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	withPositions := c.Query("includePositions") == "true"
+
+	history, err := h.snapshotsService.GetHistory(c.Request.Context(), userID, c.Param("id"), c.Query("granularity"), from, to, withPositions)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(history))
+}
+
+// Обработчик сохранения снимка стоимости портфеля
+func (h *PortfoliosHandler) TakeSnapshot(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	snapshot, err := h.snapshotsService.TakeSnapshot(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, handlers.BuildResponse(snapshot))
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"invest-mate/internal/marketdata/stream"
+	"invest-mate/pkg/handlers"
+)
+
+// Обработчик потока переоценки позиций портфеля по SSE
+func (h *PortfoliosHandler) StreamPortfolio(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	subscription, err := h.streamService.SubscribePortfolio(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	defer subscription.Close()
+
+	handlers.StreamEvents(c, subscription.Ticks(), func(c *gin.Context, tick stream.Tick) {
+		c.SSEvent("price", tick)
+
+		for _, position := range subscription.Revalue(tick) {
+			c.SSEvent("position", position)
+		}
+	})
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/pkg/handlers"
+)
+
+// Обработчик получения операций портфеля
+func (h *PortfoliosHandler) GetTransactions(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	filter, err := parseTransactionFilter(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	page, limit := handlers.ParsePaginationParams(c)
+
+	transactions, total, err := h.transactionsService.GetTransactions(c.Request.Context(), userID, c.Param("id"), filter, page, limit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildListResponse(transactions, total, page, limit))
+}
+
+// Обработчик получения операции портфеля
+func (h *PortfoliosHandler) GetTransaction(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	transaction, err := h.transactionsService.GetTransaction(c.Request.Context(), userID, c.Param("id"), c.Param("transactionId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(transaction))
+}
+
+// Обработчик добавления операции
+func (h *PortfoliosHandler) CreateTransaction(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.CreateTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	transaction, err := h.transactionsService.CreateTransaction(c.Request.Context(), userID, c.Param("id"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, handlers.BuildResponse(transaction))
+}
+
+// Обработчик изменения операции
+func (h *PortfoliosHandler) UpdateTransaction(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.UpdateTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	transaction, err := h.transactionsService.UpdateTransaction(c.Request.Context(), userID, c.Param("id"), c.Param("transactionId"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(transaction))
+}
+
+// Обработчик удаления операции
+func (h *PortfoliosHandler) DeleteTransaction(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.transactionsService.DeleteTransaction(c.Request.Context(), userID, c.Param("id"), c.Param("transactionId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(result))
+}
+
+// Обработчик пересчёта позиций портфеля по журналу операций
+func (h *PortfoliosHandler) RecalculatePositions(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	positions, err := h.transactionsService.RecalculatePositions(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(positions))
+}
+
+// Разбор фильтра операций из параметров запроса
+func parseTransactionFilter(c *gin.Context) (*domain.TransactionFilter, error) {
+	filter := &domain.TransactionFilter{
+		InstrumentUid: c.Query("instrumentUid"),
+		Type:          models.TransactionType(strings.ToUpper(c.Query("type"))),
+	}
+
+	if filter.Type != "" && !filter.Type.IsValid() {
+		return nil, fmt.Errorf("%w: unsupported transaction type %q", models.ErrInvalidRequest, filter.Type)
+	}
+
+	var err error
+
+	if filter.From, err = parseTimeQuery(c, "from"); err != nil {
+		return nil, err
+	}
+	if filter.To, err = parseTimeQuery(c, "to"); err != nil {
+		return nil, err
+	}
+
+	return filter, nil
+}
+
+// Разбор даты из параметра запроса (YYYY-MM-DD или RFC3339)
+func parseTimeQuery(c *gin.Context, name string) (*time.Time, error) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return &parsed, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s must be a date (YYYY-MM-DD) or RFC3339 timestamp", models.ErrInvalidRequest, name)
+}
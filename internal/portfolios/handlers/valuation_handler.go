@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"invest-mate/pkg/handlers"
+)
+
+// Обработчик оценки портфеля в базовой валюте
+func (h *PortfoliosHandler) GetValuation(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	valuation, err := h.valuationService.GetValuation(c.Request.Context(), userID, c.Param("id"), c.Query("currency"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(valuation))
+}
@@ -0,0 +1,33 @@
+package mappers
+
+import (
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/internal/portfolios/models/entity"
+)
+
+func FromTargetEntityToDomain(entity entity.TargetAllocation) *domain.TargetAllocation {
+	return &domain.TargetAllocation{
+		Dimension:     entity.Dimension,
+		Key:           entity.Key,
+		WeightPercent: entity.WeightPercent,
+	}
+}
+
+func FromTargetEntityToDomainSlice(entitySlice []entity.TargetAllocation) []*domain.TargetAllocation {
+	domainSlice := make([]*domain.TargetAllocation, len(entitySlice))
+
+	for index, entity := range entitySlice {
+		domainSlice[index] = FromTargetEntityToDomain(entity)
+	}
+
+	return domainSlice
+}
+
+func FromTargetDomainToEntity(portfolioID string, domain *domain.TargetAllocation) entity.TargetAllocation {
+	return entity.TargetAllocation{
+		PortfolioID:   portfolioID,
+		Dimension:     domain.Dimension,
+		Key:           domain.Key,
+		WeightPercent: domain.WeightPercent,
+	}
+}
@@ -0,0 +1,56 @@
+package mappers
+
+import (
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/internal/portfolios/models/entity"
+)
+
+func FromEntityToDomain(entity entity.Portfolio) *domain.Portfolio {
+	return &domain.Portfolio{
+		ID:                        entity.ID,
+		UserId:                    entity.UserId,
+		Name:                      entity.Name,
+		IsComposite:               entity.IsComposite,
+		ApplyTaxesOnPaidDividends: entity.ApplyTaxesOnPaidDividends,
+		DividendTaxPercent:        entity.DividendTaxPercent,
+		CouponTaxPercent:          entity.CouponTaxPercent,
+		HasToken:                  entity.HasToken,
+		Token:                     entity.Token,
+		ShareAmounts:              entity.ShareAmounts,
+		Currency:                  entity.Currency,
+		Note:                      entity.Note,
+		IsHidden:                  entity.IsHidden,
+		CreatedAt:                 entity.CreatedAt,
+		UpdatedAt:                 entity.UpdatedAt,
+	}
+}
+
+func FromEntityToDomainSlice(entitySlice []entity.Portfolio) []*domain.Portfolio {
+	domainSlice := make([]*domain.Portfolio, len(entitySlice))
+
+	for index, entity := range entitySlice {
+		domainSlice[index] = FromEntityToDomain(entity)
+	}
+
+	return domainSlice
+}
+
+func FromDomainToEntity(domain *domain.Portfolio) entity.Portfolio {
+	return entity.Portfolio{
+		ID:                        domain.ID,
+		UserId:                    domain.UserId,
+		Name:                      domain.Name,
+		IsComposite:               domain.IsComposite,
+		ApplyTaxesOnPaidDividends: domain.ApplyTaxesOnPaidDividends,
+		DividendTaxPercent:        domain.DividendTaxPercent,
+		CouponTaxPercent:          domain.CouponTaxPercent,
+		HasToken:                  domain.HasToken,
+		Token:                     domain.Token,
+		ShareAmounts:              domain.ShareAmounts,
+		Currency:                  domain.Currency,
+		Note:                      domain.Note,
+		IsHidden:                  domain.IsHidden,
+		CreatedAt:                 domain.CreatedAt,
+		UpdatedAt:                 domain.UpdatedAt,
+	}
+}
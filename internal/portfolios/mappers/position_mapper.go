@@ -0,0 +1,112 @@
+package mappers
+
+import (
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/internal/portfolios/models/entity"
+	usersDomain "invest-mate/internal/users/models/domain"
+)
+
+func FromPositionEntityToDomain(entity entity.Position) *domain.Position {
+	return &domain.Position{
+		ID:                       entity.ID,
+		PortfolioID:              entity.PortfolioID,
+		Figi:                     entity.Figi,
+		IsCustomData:             entity.IsCustomData,
+		Broker:                   entity.Broker,
+		BrokerAccountID:          entity.BrokerAccountID,
+		Ticker:                   entity.Ticker,
+		PositionUid:              entity.PositionUid,
+		AveragePositionPrice:     entity.AveragePositionPrice,
+		AveragePositionPriceFifo: entity.AveragePositionPriceFifo,
+		AveragePositionPricePt:   entity.AveragePositionPricePt,
+		IsBlocked:                entity.IsBlocked,
+		BlockedLots:              entity.BlockedLots,
+		CurrentPrice:             entity.CurrentPrice,
+		DailyYield:               entity.DailyYield,
+		ExpectedYield:            entity.ExpectedYield,
+		ExpectedYieldFifo:        entity.ExpectedYieldFifo,
+		InstrumentUid:            entity.InstrumentUid,
+		Quantity:                 entity.Quantity,
+		QuantityLots:             entity.QuantityLots,
+		VarMargin:                entity.VarMargin,
+		CurrentNkd:               entity.CurrentNkd,
+		CreatedAt:                entity.CreatedAt,
+		UpdatedAt:                entity.UpdatedAt,
+	}
+}
+
+func FromPositionEntityToDomainSlice(entitySlice []entity.Position) []*domain.Position {
+	domainSlice := make([]*domain.Position, len(entitySlice))
+
+	for index, entity := range entitySlice {
+		domainSlice[index] = FromPositionEntityToDomain(entity)
+	}
+
+	return domainSlice
+}
+
+func FromPositionDomainToEntity(domain *domain.Position) entity.Position {
+	return entity.Position{
+		ID:                       domain.ID,
+		PortfolioID:              domain.PortfolioID,
+		Figi:                     domain.Figi,
+		IsCustomData:             domain.IsCustomData,
+		Broker:                   domain.Broker,
+		BrokerAccountID:          domain.BrokerAccountID,
+		Ticker:                   domain.Ticker,
+		PositionUid:              domain.PositionUid,
+		AveragePositionPrice:     domain.AveragePositionPrice,
+		AveragePositionPriceFifo: domain.AveragePositionPriceFifo,
+		AveragePositionPricePt:   domain.AveragePositionPricePt,
+		IsBlocked:                domain.IsBlocked,
+		BlockedLots:              domain.BlockedLots,
+		CurrentPrice:             domain.CurrentPrice,
+		DailyYield:               domain.DailyYield,
+		ExpectedYield:            domain.ExpectedYield,
+		ExpectedYieldFifo:        domain.ExpectedYieldFifo,
+		InstrumentUid:            domain.InstrumentUid,
+		Quantity:                 domain.Quantity,
+		QuantityLots:             domain.QuantityLots,
+		VarMargin:                domain.VarMargin,
+		CurrentNkd:               domain.CurrentNkd,
+		CreatedAt:                domain.CreatedAt,
+		UpdatedAt:                domain.UpdatedAt,
+	}
+}
+
+func FromPositionDomainToEntitySlice(domainSlice []*domain.Position) []entity.Position {
+	entitySlice := make([]entity.Position, len(domainSlice))
+
+	for index, domain := range domainSlice {
+		entitySlice[index] = FromPositionDomainToEntity(domain)
+	}
+
+	return entitySlice
+}
+
+func FromBrokerPositionToDomain(portfolioID, accountID string, broker *usersDomain.BrokerPosition) *domain.Position {
+	return &domain.Position{
+		PortfolioID:              portfolioID,
+		Figi:                     broker.Figi,
+		IsCustomData:             false,
+		Broker:                   models.BrokerTinkoff,
+		BrokerAccountID:          accountID,
+		Ticker:                   broker.Ticker,
+		PositionUid:              broker.PositionUid,
+		AveragePositionPrice:     broker.AveragePositionPrice,
+		AveragePositionPriceFifo: broker.AveragePositionPriceFifo,
+		AveragePositionPricePt:   broker.AveragePositionPricePt,
+		IsBlocked:                broker.Blocked,
+		BlockedLots:              int32(broker.BlockedLots),
+		CurrentPrice:             broker.CurrentPrice,
+		DailyYield:               broker.DailyYield,
+		ExpectedYield:            broker.ExpectedYield,
+		ExpectedYieldFifo:        broker.ExpectedYieldFifo,
+		InstrumentUid:            broker.InstrumentUid,
+		Quantity:                 int32(broker.Quantity),
+		QuantityLots:             int32(broker.QuantityLots),
+		VarMargin:                broker.VarMargin,
+		CurrentNkd:               broker.CurrentNkd,
+	}
+}
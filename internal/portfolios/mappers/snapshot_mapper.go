@@ -0,0 +1,75 @@
+package mappers
+
+import (
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/internal/portfolios/models/entity"
+)
+
+func FromSnapshotEntityToDomain(entity entity.PortfolioSnapshot) *domain.PortfolioSnapshot {
+	return &domain.PortfolioSnapshot{
+		ID:             entity.ID,
+		PortfolioID:    entity.PortfolioID,
+		Date:           entity.Date,
+		Currency:       entity.Currency,
+		TotalValue:     entity.TotalValue,
+		InvestedAmount: entity.InvestedAmount,
+		ExpectedYield:  entity.ExpectedYield,
+		YieldPercent:   entity.YieldPercent,
+		CreatedAt:      entity.CreatedAt,
+		UpdatedAt:      entity.UpdatedAt,
+	}
+}
+
+func FromSnapshotEntityToDomainSlice(entitySlice []entity.PortfolioSnapshot) []*domain.PortfolioSnapshot {
+	domainSlice := make([]*domain.PortfolioSnapshot, len(entitySlice))
+
+	for index, entity := range entitySlice {
+		domainSlice[index] = FromSnapshotEntityToDomain(entity)
+	}
+
+	return domainSlice
+}
+
+func FromSnapshotDomainToEntity(domain *domain.PortfolioSnapshot) entity.PortfolioSnapshot {
+	return entity.PortfolioSnapshot{
+		ID:             domain.ID,
+		PortfolioID:    domain.PortfolioID,
+		Date:           domain.Date,
+		Currency:       domain.Currency,
+		TotalValue:     domain.TotalValue,
+		InvestedAmount: domain.InvestedAmount,
+		ExpectedYield:  domain.ExpectedYield,
+		YieldPercent:   domain.YieldPercent,
+		CreatedAt:      domain.CreatedAt,
+		UpdatedAt:      domain.UpdatedAt,
+	}
+}
+
+func FromSnapshotPositionEntityToDomain(entity entity.PortfolioSnapshotPosition) *domain.SnapshotPosition {
+	return &domain.SnapshotPosition{
+		InstrumentUid: entity.InstrumentUid,
+		Ticker:        entity.Ticker,
+		Currency:      entity.Currency,
+		Quantity:      entity.Quantity,
+		Rate:          entity.Rate,
+		Value:         entity.Value,
+		ValueBase:     entity.ValueBase,
+		InvestedBase:  entity.InvestedBase,
+		ExpectedYield: entity.ExpectedYield,
+	}
+}
+
+func FromSnapshotPositionDomainToEntity(snapshotID string, domain *domain.SnapshotPosition) entity.PortfolioSnapshotPosition {
+	return entity.PortfolioSnapshotPosition{
+		SnapshotID:    snapshotID,
+		InstrumentUid: domain.InstrumentUid,
+		Ticker:        domain.Ticker,
+		Currency:      domain.Currency,
+		Quantity:      domain.Quantity,
+		Rate:          domain.Rate,
+		Value:         domain.Value,
+		ValueBase:     domain.ValueBase,
+		InvestedBase:  domain.InvestedBase,
+		ExpectedYield: domain.ExpectedYield,
+	}
+}
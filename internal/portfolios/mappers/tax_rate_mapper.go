@@ -0,0 +1,23 @@
+package mappers
+
+import (
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/internal/portfolios/models/entity"
+)
+
+func FromTaxRateEntityToDomain(entity entity.DividendTaxRate) *domain.DividendTaxRate {
+	return &domain.DividendTaxRate{
+		Country:    entity.Country,
+		TaxPercent: entity.TaxPercent,
+	}
+}
+
+func FromTaxRateEntityToDomainSlice(entitySlice []entity.DividendTaxRate) []*domain.DividendTaxRate {
+	domainSlice := make([]*domain.DividendTaxRate, len(entitySlice))
+
+	for index, entity := range entitySlice {
+		domainSlice[index] = FromTaxRateEntityToDomain(entity)
+	}
+
+	return domainSlice
+}
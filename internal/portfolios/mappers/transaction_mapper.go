@@ -0,0 +1,56 @@
+package mappers
+
+import (
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/internal/portfolios/models/entity"
+)
+
+func FromTransactionEntityToDomain(entity entity.Transaction) *domain.Transaction {
+	return &domain.Transaction{
+		ID:            entity.ID,
+		PortfolioID:   entity.PortfolioID,
+		Type:          entity.Type,
+		InstrumentUid: entity.InstrumentUid,
+		Figi:          entity.Figi,
+		Ticker:        entity.Ticker,
+		Quantity:      entity.Quantity,
+		Price:         entity.Price,
+		Amount:        entity.Amount,
+		Commission:    entity.Commission,
+		Currency:      entity.Currency,
+		Note:          entity.Note,
+		ExecutedAt:    entity.ExecutedAt,
+		CreatedAt:     entity.CreatedAt,
+		UpdatedAt:     entity.UpdatedAt,
+	}
+}
+
+func FromTransactionEntityToDomainSlice(entitySlice []entity.Transaction) []*domain.Transaction {
+	domainSlice := make([]*domain.Transaction, len(entitySlice))
+
+	for index, entity := range entitySlice {
+		domainSlice[index] = FromTransactionEntityToDomain(entity)
+	}
+
+	return domainSlice
+}
+
+func FromTransactionDomainToEntity(domain *domain.Transaction) entity.Transaction {
+	return entity.Transaction{
+		ID:            domain.ID,
+		PortfolioID:   domain.PortfolioID,
+		Type:          domain.Type,
+		InstrumentUid: domain.InstrumentUid,
+		Figi:          domain.Figi,
+		Ticker:        domain.Ticker,
+		Quantity:      domain.Quantity,
+		Price:         domain.Price,
+		Amount:        domain.Amount,
+		Commission:    domain.Commission,
+		Currency:      domain.Currency,
+		Note:          domain.Note,
+		ExecutedAt:    domain.ExecutedAt,
+		CreatedAt:     domain.CreatedAt,
+		UpdatedAt:     domain.UpdatedAt,
+	}
+}
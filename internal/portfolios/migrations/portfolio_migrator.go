@@ -3,9 +3,21 @@ package migrations
 import (
 	"gorm.io/gorm"
 
+	"invest-mate/internal/portfolios/models"
 	"invest-mate/internal/portfolios/models/entity"
 )
 
+// Глобальный уникальный индекс по positionUid не позволял держать
+// один инструмент в нескольких портфелях
+const legacyPositionUidIndex = "idx_positions_position_uid"
+
+// Неуникальный индекс по токену заменён частичным уникальным
+const legacyPortfolioTokenIndex = "idx_portfolios_token"
+
+// Индекс по счёту заменён составным индексом портфеля, брокера и счёта,
+// по которому загрузка выбирает позиции счёта
+const legacyPositionAccountIndex = "idx_positions_broker_account_id"
+
 type PortfoliosMigrator struct{}
 
 func NewPortfoliosMigrator() *PortfoliosMigrator {
@@ -13,9 +25,55 @@ func NewPortfoliosMigrator() *PortfoliosMigrator {
 }
 
 func (m *PortfoliosMigrator) Migrate(db *gorm.DB) error {
-	return db.AutoMigrate(
+	if err := dropLegacyIndexes(db); err != nil {
+		return err
+	}
+
+	err := db.AutoMigrate(
 		&entity.Portfolio{},
 		&entity.Position{},
 		&entity.PortfolioHierarchy{},
+		&entity.Transaction{},
+		&entity.TransactionLot{},
+		&entity.DividendTaxRate{},
+		&entity.PortfolioSnapshot{},
+		&entity.PortfolioSnapshotPosition{},
+		&entity.TargetAllocation{},
 	)
+	if err != nil {
+		return err
+	}
+
+	return backfillPositionBroker(db)
+}
+
+// Позиции, загруженные до появления колонки брокера, получены из Tinkoff;
+// счёт для них неизвестен и заполнится при следующей загрузке
+func backfillPositionBroker(db *gorm.DB) error {
+	return db.Model(&entity.Position{}).
+		Where("is_custom_data = ? AND broker = ?", false, "").
+		Update("broker", models.BrokerTinkoff).Error
+}
+
+// Удаление устаревших индексов
+func dropLegacyIndexes(db *gorm.DB) error {
+	migrator := db.Migrator()
+
+	if migrator.HasTable(&entity.Position{}) && migrator.HasIndex(&entity.Position{}, legacyPositionUidIndex) {
+		if err := migrator.DropIndex(&entity.Position{}, legacyPositionUidIndex); err != nil {
+			return err
+		}
+	}
+
+	if migrator.HasTable(&entity.Position{}) && migrator.HasIndex(&entity.Position{}, legacyPositionAccountIndex) {
+		if err := migrator.DropIndex(&entity.Position{}, legacyPositionAccountIndex); err != nil {
+			return err
+		}
+	}
+
+	if migrator.HasTable(&entity.Portfolio{}) && migrator.HasIndex(&entity.Portfolio{}, legacyPortfolioTokenIndex) {
+		return migrator.DropIndex(&entity.Portfolio{}, legacyPortfolioTokenIndex)
+	}
+
+	return nil
 }
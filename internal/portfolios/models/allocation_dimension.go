@@ -0,0 +1,26 @@
+package models
+
+type AllocationDimension string
+
+const (
+	AllocationDimensionInstrument     AllocationDimension = "INSTRUMENT"
+	AllocationDimensionSector         AllocationDimension = "SECTOR"
+	AllocationDimensionInstrumentType AllocationDimension = "INSTRUMENT_TYPE"
+	AllocationDimensionCurrency       AllocationDimension = "CURRENCY"
+	AllocationDimensionCountry        AllocationDimension = "COUNTRY"
+)
+
+// Проверка измерения целевого распределения на валидность
+func (d AllocationDimension) IsValid() bool {
+	switch d {
+	case AllocationDimensionInstrument, AllocationDimensionSector, AllocationDimensionInstrumentType, AllocationDimensionCurrency:
+		return true
+	default:
+		return false
+	}
+}
+
+// Проверка измерения структуры портфеля: кроме измерений целевого распределения доступна страна риска
+func (d AllocationDimension) IsValidForDiversification() bool {
+	return d.IsValid() || d == AllocationDimensionCountry
+}
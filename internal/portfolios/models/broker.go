@@ -0,0 +1,4 @@
+package models
+
+// Брокер, позиции которого загружаются по токену пользователя
+const BrokerTinkoff = "TINKOFF"
@@ -0,0 +1,13 @@
+package models
+
+type CalendarEventType string
+
+const (
+	CalendarEventTypeDividend     CalendarEventType = "DIVIDEND"
+	CalendarEventTypeCoupon       CalendarEventType = "COUPON"
+	CalendarEventTypeCall         CalendarEventType = "CALL"
+	CalendarEventTypeMaturity     CalendarEventType = "MATURITY"
+	CalendarEventTypeAmortization CalendarEventType = "AMORTIZATION"
+	CalendarEventTypeConversion   CalendarEventType = "CONVERSION"
+	CalendarEventTypeOther        CalendarEventType = "OTHER"
+)
@@ -0,0 +1,19 @@
+package models
+
+type CostMethod string
+
+const (
+	CostMethodFifo     CostMethod = "FIFO"
+	CostMethodAverage  CostMethod = "AVERAGE"
+	CostMethodSpecific CostMethod = "SPECIFIC"
+)
+
+// Проверка способа списания партий на валидность
+func (m CostMethod) IsValid() bool {
+	switch m {
+	case CostMethodFifo, CostMethodAverage, CostMethodSpecific:
+		return true
+	default:
+		return false
+	}
+}
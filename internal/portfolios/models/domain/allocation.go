@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"invest-mate/internal/portfolios/models"
+)
+
+// Целевая доля: ключ — uid инструмента, сектор, тип инструмента или валюта
+type TargetAllocation struct {
+	Dimension     models.AllocationDimension `json:"dimension"`
+	Key           string                     `json:"key"`
+	Ticker        string                     `json:"ticker,omitempty"`
+	WeightPercent float64                    `json:"weightPercent"`
+}
+
+type SetTargetAllocationsRequest struct {
+	Targets []*TargetAllocation `json:"targets"`
+}
+
+// Отклонение группы от целевой доли (суммы в базовой валюте)
+type AllocationDrift struct {
+	Key                  string  `json:"key"`
+	Ticker               string  `json:"ticker,omitempty"`
+	TargetPercent        float64 `json:"targetPercent"`
+	CurrentPercent       float64 `json:"currentPercent"`
+	DriftPercent         float64 `json:"driftPercent"`
+	CurrentValue         float64 `json:"currentValue"`
+	TargetValue          float64 `json:"targetValue"`
+	Difference           float64 `json:"difference"`
+	HasHoldings          bool    `json:"hasHoldings"`
+	PostRebalancePercent float64 `json:"postRebalancePercent"`
+}
+
+// Предлагаемая заявка на покупку или продажу целым числом лотов
+type RebalanceOrder struct {
+	InstrumentUid string                 `json:"instrumentUid"`
+	Ticker        string                 `json:"ticker"`
+	Direction     models.TransactionType `json:"direction"`
+	Lots          int32                  `json:"lots"`
+	Quantity      int32                  `json:"quantity"`
+	Price         float64                `json:"price"`
+	Currency      string                 `json:"currency"`
+	Amount        float64                `json:"amount"`
+	AmountBase    float64                `json:"amountBase"`
+}
+
+// План ребалансировки портфеля
+type RebalancePlan struct {
+	PortfolioID string                     `json:"portfolioId"`
+	Currency    string                     `json:"currency"`
+	Dimension   models.AllocationDimension `json:"dimension"`
+	TotalValue  float64                    `json:"totalValue"`
+	Cash        float64                    `json:"cash"`
+	Drift       []*AllocationDrift         `json:"drift"`
+	Orders      []*RebalanceOrder          `json:"orders"`
+	// Остаток денег после исполнения заявок (отрицательный — нужна доплата)
+	Residual float64  `json:"residual"`
+	Unpriced []string `json:"unpriced"`
+	// Группы с целевой долей без бумаг, по которым не составлено покупки
+	// (нет инструмента или лот дороже целевой суммы): покупку нужно выбрать вручную
+	Unfilled []string `json:"unfilled"`
+}
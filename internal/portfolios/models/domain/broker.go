@@ -0,0 +1,28 @@
+package domain
+
+type BrokerAccountsRequest struct {
+	Token string `json:"token"`
+}
+
+type ImportPortfolioRequest struct {
+	Token     string `json:"token"`
+	AccountID string `json:"accountId"`
+}
+
+// Итог загрузки позиций брокерского счёта в портфель
+type ImportResult struct {
+	AccountID string              `json:"accountId"`
+	Imported  int                 `json:"imported"`
+	Removed   int64               `json:"removed"`
+	Skipped   []string            `json:"skipped"`
+	Rejected  []*RejectedPosition `json:"rejected"`
+	Positions []*Position         `json:"positions"`
+}
+
+// Позиция брокера, не загруженная в портфель
+type RejectedPosition struct {
+	Figi     string  `json:"figi"`
+	Ticker   string  `json:"ticker"`
+	Quantity float64 `json:"quantity"`
+	Reason   string  `json:"reason"`
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"time"
+
+	"invest-mate/internal/portfolios/models"
+)
+
+// Предстоящая выплата или событие по бумаге из портфелей пользователя
+type CalendarEvent struct {
+	Date           time.Time                `json:"date"`
+	Type           models.CalendarEventType `json:"type"`
+	InstrumentUid  string                   `json:"instrumentUid"`
+	Ticker         string                   `json:"ticker"`
+	Name           string                   `json:"name"`
+	DeclaredDate   *time.Time               `json:"declaredDate,omitempty"`
+	RecordDate     *time.Time               `json:"recordDate,omitempty"`
+	AmountPerUnit  float64                  `json:"amountPerUnit"`
+	Currency       string                   `json:"currency"`
+	Quantity       int32                    `json:"quantity"`
+	ExpectedAmount float64                  `json:"expectedAmount"`
+	PortfolioIDs   []string                 `json:"portfolioIds"`
+}
+
+// Календарь выплат и событий за период
+type EventCalendar struct {
+	PortfolioID string           `json:"portfolioId,omitempty"`
+	From        time.Time        `json:"from"`
+	To          time.Time        `json:"to"`
+	Events      []*CalendarEvent `json:"events"`
+	// Ожидаемые выплаты по валютам
+	Totals      map[string]float64 `json:"totals"`
+	Unavailable []string           `json:"unavailable"`
+}
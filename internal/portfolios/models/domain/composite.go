@@ -0,0 +1,42 @@
+package domain
+
+import (
+	assetsDomain "invest-mate/internal/assets/models/domain"
+)
+
+type AttachChildRequest struct {
+	ChildID string `json:"childId"`
+}
+
+// Позиция, сведённая по инструменту по всем портфелям дерева
+type AggregatedPosition struct {
+	InstrumentUid        string   `json:"instrumentUid"`
+	Figi                 string   `json:"figi"`
+	Ticker               string   `json:"ticker"`
+	Currency             string   `json:"currency"`
+	Quantity             int32    `json:"quantity"`
+	AveragePositionPrice float64  `json:"averagePositionPrice"`
+	CurrentPrice         float64  `json:"currentPrice"`
+	CurrentNkd           float64  `json:"currentNkd"`
+	InvestedAmount       float64  `json:"investedAmount"`
+	Value                float64  `json:"value"`
+	ExpectedYield        float64  `json:"expectedYield"`
+	YieldPercent         float64  `json:"yieldPercent"`
+	PortfolioIDs         []string `json:"portfolioIds"`
+
+	Instrument *assetsDomain.Instrument `json:"instrument,omitempty"`
+}
+
+// Сводные данные по портфелю и всем вложенным портфелям.
+// Суммы позиций указаны в валюте инструмента, итоги — в валюте портфеля
+type PortfolioAggregate struct {
+	Portfolio      *Portfolio            `json:"portfolio"`
+	PortfolioIDs   []string              `json:"portfolioIds"`
+	Positions      []*AggregatedPosition `json:"positions"`
+	Currency       string                `json:"currency"`
+	InvestedAmount float64               `json:"investedAmount"`
+	TotalValue     float64               `json:"totalValue"`
+	ExpectedYield  float64               `json:"expectedYield"`
+	YieldPercent   float64               `json:"yieldPercent"`
+	Unvalued       []string              `json:"unvalued"`
+}
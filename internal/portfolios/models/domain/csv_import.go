@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"time"
+
+	"invest-mate/internal/portfolios/models"
+)
+
+// Названия колонок CSV для полей операции (пустое — колонки нет).
+// Инструмент определяется по ISIN или тикеру
+type CsvColumnMapping struct {
+	Date       string `json:"date"`
+	Type       string `json:"type"`
+	Isin       string `json:"isin"`
+	Ticker     string `json:"ticker"`
+	Quantity   string `json:"quantity"`
+	Price      string `json:"price"`
+	Amount     string `json:"amount"`
+	Commission string `json:"commission"`
+	Currency   string `json:"currency"`
+	Note       string `json:"note"`
+}
+
+// Настройки разбора выписки брокера
+type CsvImportOptions struct {
+	// Без колонок используются заголовки date, type, isin, ticker, quantity, price, amount, commission, currency, note
+	Columns *CsvColumnMapping `json:"columns"`
+	// Разделитель полей (по умолчанию определяется по заголовку)
+	Delimiter string `json:"delimiter"`
+	// Десятичная запятая (пробелы в числах отбрасываются всегда)
+	DecimalComma bool `json:"decimalComma"`
+	// Формат даты в нотации Go (по умолчанию — ISO 8601 и ДД.ММ.ГГГГ)
+	DateFormat string `json:"dateFormat"`
+	// Часовой пояс дат без смещения (IANA, по умолчанию UTC)
+	Timezone string `json:"timezone"`
+	// Значения колонки типа и соответствующие им операции (дополняют стандартные)
+	Types map[string]models.TransactionType `json:"types"`
+	// Загрузить корректные строки, пропустив строки с ошибками
+	SkipInvalid bool `json:"skipInvalid"`
+}
+
+// Разобранная строка выписки
+type CsvImportRow struct {
+	Line          int                    `json:"line"`
+	Type          models.TransactionType `json:"type,omitempty"`
+	InstrumentUid string                 `json:"instrumentUid,omitempty"`
+	Isin          string                 `json:"isin,omitempty"`
+	Ticker        string                 `json:"ticker,omitempty"`
+	Quantity      int32                  `json:"quantity"`
+	Price         float64                `json:"price"`
+	Amount        float64                `json:"amount"`
+	Commission    float64                `json:"commission"`
+	Currency      string                 `json:"currency,omitempty"`
+	Note          string                 `json:"note,omitempty"`
+	ExecutedAt    *time.Time             `json:"executedAt,omitempty"`
+	// Такая операция уже есть в портфеле (строка не загружается)
+	Duplicate bool   `json:"duplicate"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Предпросмотр загрузки без сохранения
+type CsvImportPreview struct {
+	Rows       []*CsvImportRow `json:"rows"`
+	Total      int             `json:"total"`
+	Valid      int             `json:"valid"`
+	Invalid    int             `json:"invalid"`
+	Duplicates int             `json:"duplicates"`
+}
+
+// Итог загрузки выписки в портфель
+type CsvImportResult struct {
+	Imported   int             `json:"imported"`
+	Skipped    int             `json:"skipped"`
+	Duplicates int             `json:"duplicates"`
+	Errors     []*CsvImportRow `json:"errors"`
+	Positions  []*Position     `json:"positions"`
+}
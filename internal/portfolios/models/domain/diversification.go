@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"invest-mate/internal/portfolios/models"
+)
+
+// Группа позиций по измерению (сумма — в базовой валюте)
+type DiversificationGroup struct {
+	Key            string  `json:"key"`
+	Name           string  `json:"name"`
+	Value          float64 `json:"value"`
+	WeightPercent  float64 `json:"weightPercent"`
+	PositionsCount int     `json:"positionsCount"`
+}
+
+// Показатели концентрации: индекс Херфиндаля (0–1), эффективное число групп и доля крупнейших
+type ConcentrationMetrics struct {
+	HerfindahlIndex float64 `json:"herfindahlIndex"`
+	EffectiveCount  float64 `json:"effectiveCount"`
+	TopN            int     `json:"topN"`
+	TopSharePercent float64 `json:"topSharePercent"`
+}
+
+// Структура портфеля по измерению с показателями концентрации
+type Diversification struct {
+	PortfolioID   string                     `json:"portfolioId"`
+	Currency      string                     `json:"currency"`
+	Dimension     models.AllocationDimension `json:"dimension"`
+	TotalValue    float64                    `json:"totalValue"`
+	Groups        []*DiversificationGroup    `json:"groups"`
+	Concentration *ConcentrationMetrics      `json:"concentration"`
+	// Концентрация по отдельным инструментам
+	InstrumentConcentration *ConcentrationMetrics `json:"instrumentConcentration"`
+	Unvalued                []string              `json:"unvalued"`
+}
@@ -0,0 +1,28 @@
+package domain
+
+// Процентный риск облигационной позиции (дюрации — в годах, доходность — в процентах)
+type BondPositionDuration struct {
+	InstrumentUid    string   `json:"instrumentUid"`
+	Ticker           string   `json:"ticker"`
+	Quantity         int32    `json:"quantity"`
+	ValueBase        float64  `json:"valueBase"`
+	WeightPercent    float64  `json:"weightPercent"`
+	Horizon          string   `json:"horizon,omitempty"`
+	Yield            *float64 `json:"yield,omitempty"`
+	MacaulayDuration *float64 `json:"macaulayDuration,omitempty"`
+	ModifiedDuration *float64 `json:"modifiedDuration,omitempty"`
+	Convexity        *float64 `json:"convexity,omitempty"`
+}
+
+// Дюрация облигационной части портфеля, взвешенная по стоимости позиций
+type PortfolioDuration struct {
+	PortfolioID       string                  `json:"portfolioId"`
+	Currency          string                  `json:"currency"`
+	BondsValue        float64                 `json:"bondsValue"`
+	BondsSharePercent float64                 `json:"bondsSharePercent"`
+	MacaulayDuration  float64                 `json:"macaulayDuration"`
+	ModifiedDuration  float64                 `json:"modifiedDuration"`
+	Convexity         float64                 `json:"convexity"`
+	Positions         []*BondPositionDuration `json:"positions"`
+	Unavailable       []string                `json:"unavailable"`
+}
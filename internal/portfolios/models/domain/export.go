@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"time"
+
+	"invest-mate/internal/portfolios/models"
+)
+
+// Колонка выгрузки: ключ для выбора колонок и заголовок
+type ExportColumn struct {
+	Key   string `json:"key"`
+	Title string `json:"title"`
+}
+
+// Таблица выгрузки. Значения ячеек: string, int64, float64, *float64, time.Time или nil
+type ExportTable struct {
+	Dataset models.ExportDataset
+	Columns []ExportColumn
+	// Обход строк: каждая строка передаётся в emit по мере чтения данных
+	Rows func(emit func(row []any) error) error
+}
+
+// Параметры выгрузки портфеля
+type ExportOptions struct {
+	// Ключи колонок в нужном порядке (пусто — все колонки набора)
+	Columns []string
+	// Валюта оценки позиций и аналитики (по умолчанию — валюта портфеля)
+	Currency string
+	// Период операций, дохода и доходности
+	From *time.Time
+	To   *time.Time
+	// Отбор операций
+	Filter *TransactionFilter
+}
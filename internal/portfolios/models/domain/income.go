@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"time"
+
+	"invest-mate/internal/portfolios/models"
+)
+
+// Ставка налога для эмитентов страны (код страны риска, например US)
+type DividendTaxRate struct {
+	Country    string  `json:"country"`
+	TaxPercent float32 `json:"taxPercent"`
+}
+
+type SetDividendTaxRateRequest struct {
+	TaxPercent float32 `json:"taxPercent"`
+}
+
+// Выплата дивиденда или купона с учётом удержанного налога
+type IncomePayment struct {
+	TransactionID string                 `json:"transactionId"`
+	Type          models.TransactionType `json:"type"`
+	InstrumentUid string                 `json:"instrumentUid"`
+	Ticker        string                 `json:"ticker"`
+	Country       string                 `json:"country"`
+	PaidAt        time.Time              `json:"paidAt"`
+	Gross         float64                `json:"gross"`
+	TaxPercent    float32                `json:"taxPercent"`
+	Tax           float64                `json:"tax"`
+	Net           float64                `json:"net"`
+	Currency      string                 `json:"currency"`
+}
+
+// Итоги по доходу в одной валюте
+type IncomeTotals struct {
+	DividendsGross float64 `json:"dividendsGross"`
+	DividendsNet   float64 `json:"dividendsNet"`
+	CouponsGross   float64 `json:"couponsGross"`
+	CouponsNet     float64 `json:"couponsNet"`
+	Gross          float64 `json:"gross"`
+	Tax            float64 `json:"tax"`
+	Net            float64 `json:"net"`
+}
+
+// Отчёт о доходе по дивидендам и купонам за период
+type IncomeReport struct {
+	PortfolioID       string                   `json:"portfolioId"`
+	From              *time.Time               `json:"from,omitempty"`
+	To                *time.Time               `json:"to,omitempty"`
+	ApplyTaxes        bool                     `json:"applyTaxes"`
+	DefaultTaxPercent float32                  `json:"defaultTaxPercent"`
+	CouponTaxPercent  *float32                 `json:"couponTaxPercent"`
+	TaxRates          []*DividendTaxRate       `json:"taxRates"`
+	Payments          []*IncomePayment         `json:"payments"`
+	Totals            map[string]*IncomeTotals `json:"totals"`
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"time"
+
+	"invest-mate/internal/portfolios/models"
+)
+
+// Партия покупки, выбранная для закрытия продажей
+type LotSelection struct {
+	TransactionID string `json:"transactionId"`
+	Quantity      int32  `json:"quantity"`
+}
+
+// Открытая партия бумаг
+type OpenLot struct {
+	TransactionID string    `json:"transactionId"`
+	InstrumentUid string    `json:"instrumentUid"`
+	Ticker        string    `json:"ticker"`
+	OpenedAt      time.Time `json:"openedAt"`
+	Quantity      int32     `json:"quantity"`
+	Price         float64   `json:"price"`
+	Commission    float64   `json:"commission"`
+	CostBasis     float64   `json:"costBasis"`
+	Currency      string    `json:"currency"`
+}
+
+// Часть партии, закрытая продажей
+type LotMatch struct {
+	TransactionID string    `json:"transactionId"`
+	OpenedAt      time.Time `json:"openedAt"`
+	Quantity      int32     `json:"quantity"`
+	Price         float64   `json:"price"`
+	CostBasis     float64   `json:"costBasis"`
+}
+
+// Финансовый результат закрывающей сделки
+type RealizedTrade struct {
+	TransactionID string      `json:"transactionId"`
+	InstrumentUid string      `json:"instrumentUid"`
+	Ticker        string      `json:"ticker"`
+	ClosedAt      time.Time   `json:"closedAt"`
+	Quantity      int32       `json:"quantity"`
+	Price         float64     `json:"price"`
+	Proceeds      float64     `json:"proceeds"`
+	Commission    float64     `json:"commission"`
+	CostBasis     float64     `json:"costBasis"`
+	RealizedPnL   float64     `json:"realizedPnl"`
+	Currency      string      `json:"currency"`
+	Matches       []*LotMatch `json:"matches"`
+}
+
+// Итоги по валюте
+type RealizedTotals struct {
+	Proceeds    float64 `json:"proceeds"`
+	Commission  float64 `json:"commission"`
+	CostBasis   float64 `json:"costBasis"`
+	RealizedPnL float64 `json:"realizedPnl"`
+}
+
+// Отчёт о реализованном финансовом результате за период
+type RealizedReport struct {
+	PortfolioID string                     `json:"portfolioId"`
+	Method      models.CostMethod          `json:"method"`
+	From        *time.Time                 `json:"from,omitempty"`
+	To          *time.Time                 `json:"to,omitempty"`
+	Trades      []*RealizedTrade           `json:"trades"`
+	Totals      map[string]*RealizedTotals `json:"totals"`
+}
+
+// Открытые партии портфеля
+type OpenLotsReport struct {
+	PortfolioID string            `json:"portfolioId"`
+	Method      models.CostMethod `json:"method"`
+	Lots        []*OpenLot        `json:"lots"`
+}
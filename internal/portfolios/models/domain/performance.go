@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// Доходность портфеля за период
+type PortfolioPerformance struct {
+	PortfolioID      string    `json:"portfolioId"`
+	PortfolioIDs     []string  `json:"portfolioIds"`
+	Currency         string    `json:"currency"`
+	From             time.Time `json:"from"`
+	To               time.Time `json:"to"`
+	CashFlowMode     string    `json:"cashFlowMode"`
+	StartValue       float64   `json:"startValue"`
+	EndValue         float64   `json:"endValue"`
+	NetContributions float64   `json:"netContributions"`
+	Gain             float64   `json:"gain"`
+	FlowsCount       int       `json:"flowsCount"`
+	// Проценты; nil, если показатель нельзя рассчитать по данным периода
+	Twr           *float64 `json:"twr"`
+	TwrAnnualized *float64 `json:"twrAnnualized"`
+	Xirr          *float64 `json:"xirr"`
+	// Внешние потоки периода с оценкой портфеля до и после них
+	Flows []*PerformanceFlow `json:"flows"`
+}
+
+// Внешний поток (вложение положительное) и стоимость портфеля в базовой валюте
+type PerformanceFlow struct {
+	Date        time.Time `json:"date"`
+	Amount      float64   `json:"amount"`
+	ValueBefore float64   `json:"valueBefore"`
+	ValueAfter  float64   `json:"valueAfter"`
+}
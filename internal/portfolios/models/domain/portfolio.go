@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"time"
+)
+
+type Portfolio struct {
+	ID                        string    `json:"id"`
+	UserId                    string    `json:"userId"`
+	Name                      string    `json:"name"`
+	IsComposite               bool      `json:"isComposite"`
+	ApplyTaxesOnPaidDividends bool      `json:"applyTaxesOnPaidDividends"`
+	DividendTaxPercent        float32   `json:"dividendTaxPercent"`
+	CouponTaxPercent          *float32  `json:"couponTaxPercent"`
+	HasToken                  bool      `json:"hasToken"`
+	Token                     string    `json:"-"`
+	ShareAmounts              bool      `json:"shareAmounts"`
+	Currency                  string    `json:"currency"`
+	Note                      string    `json:"note"`
+	IsHidden                  bool      `json:"isHidden"`
+	CreatedAt                 time.Time `json:"createdAt"`
+	UpdatedAt                 time.Time `json:"updatedAt"`
+}
+
+type CreatePortfolioRequest struct {
+	Name                      string   `json:"name" validate:"required,max=255"`
+	IsComposite               bool     `json:"isComposite"`
+	Currency                  string   `json:"currency"`
+	Note                      string   `json:"note"`
+	IsHidden                  bool     `json:"isHidden"`
+	ApplyTaxesOnPaidDividends bool     `json:"applyTaxesOnPaidDividends"`
+	DividendTaxPercent        float32  `json:"dividendTaxPercent"`
+	CouponTaxPercent          *float32 `json:"couponTaxPercent"`
+}
+
+type UpdatePortfolioRequest struct {
+	Name                      *string  `json:"name"`
+	Currency                  *string  `json:"currency"`
+	Note                      *string  `json:"note"`
+	IsHidden                  *bool    `json:"isHidden"`
+	ApplyTaxesOnPaidDividends *bool    `json:"applyTaxesOnPaidDividends"`
+	DividendTaxPercent        *float32 `json:"dividendTaxPercent"`
+	CouponTaxPercent          *float32 `json:"couponTaxPercent"`
+}
+
+type HidePortfolioRequest struct {
+	IsHidden bool `json:"isHidden"`
+}
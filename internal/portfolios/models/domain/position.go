@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"time"
+
+	assetsDomain "invest-mate/internal/assets/models/domain"
+)
+
+type Position struct {
+	ID                       string    `json:"id"`
+	PortfolioID              string    `json:"portfolioId"`
+	Figi                     string    `json:"figi"`
+	IsCustomData             bool      `json:"isCustomData"`
+	Broker                   string    `json:"broker,omitempty"`
+	BrokerAccountID          string    `json:"brokerAccountId,omitempty"`
+	Ticker                   string    `json:"ticker"`
+	PositionUid              string    `json:"positionUid"`
+	AveragePositionPrice     float64   `json:"averagePositionPrice"`
+	AveragePositionPriceFifo float64   `json:"averagePositionPriceFifo"`
+	AveragePositionPricePt   float64   `json:"averagePositionPricePt"`
+	IsBlocked                bool      `json:"isBlocked"`
+	BlockedLots              int32     `json:"blockedLots"`
+	CurrentPrice             float64   `json:"currentPrice"`
+	DailyYield               float64   `json:"dailyYield"`
+	ExpectedYield            float64   `json:"expectedYield"`
+	ExpectedYieldFifo        float64   `json:"expectedYieldFifo"`
+	InstrumentUid            string    `json:"instrumentUid"`
+	Quantity                 int32     `json:"quantity"`
+	QuantityLots             int32     `json:"quantityLots"`
+	VarMargin                float64   `json:"varMargin"`
+	CurrentNkd               float64   `json:"currentNkd"`
+	CreatedAt                time.Time `json:"createdAt"`
+	UpdatedAt                time.Time `json:"updatedAt"`
+
+	Instrument *assetsDomain.Instrument `json:"instrument,omitempty"`
+}
+
+type AddPositionRequest struct {
+	InstrumentUid        string  `json:"instrumentUid"`
+	Figi                 string  `json:"figi"`
+	Ticker               string  `json:"ticker"`
+	Quantity             int32   `json:"quantity"`
+	AveragePositionPrice float64 `json:"averagePositionPrice"`
+	CurrentPrice         float64 `json:"currentPrice"`
+	CurrentNkd           float64 `json:"currentNkd"`
+}
+
+type UpdatePositionRequest struct {
+	Quantity             *int32   `json:"quantity"`
+	AveragePositionPrice *float64 `json:"averagePositionPrice"`
+	CurrentPrice         *float64 `json:"currentPrice"`
+	CurrentNkd           *float64 `json:"currentNkd"`
+}
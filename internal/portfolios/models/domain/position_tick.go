@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// Переоценка позиции по новой цене (суммы — в валюте инструмента)
+type PositionTick struct {
+	PositionID     string    `json:"positionId"`
+	PortfolioID    string    `json:"portfolioId"`
+	InstrumentUid  string    `json:"instrumentUid"`
+	Ticker         string    `json:"ticker"`
+	Currency       string    `json:"currency"`
+	Quantity       int32     `json:"quantity"`
+	Price          float64   `json:"price"`
+	Value          float64   `json:"value"`
+	InvestedAmount float64   `json:"investedAmount"`
+	ExpectedYield  float64   `json:"expectedYield"`
+	YieldPercent   float64   `json:"yieldPercent"`
+	Time           time.Time `json:"time"`
+}
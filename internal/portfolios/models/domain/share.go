@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"time"
+
+	sharedModels "invest-mate/internal/shared/models"
+)
+
+type SharePortfolioRequest struct {
+	ShareAmounts bool `json:"shareAmounts"`
+}
+
+// Состояние открытого доступа к портфелю (видно только владельцу)
+type PortfolioShare struct {
+	PortfolioID  string `json:"portfolioId"`
+	HasToken     bool   `json:"hasToken"`
+	Token        string `json:"token,omitempty"`
+	ShareAmounts bool   `json:"shareAmounts"`
+}
+
+// Позиция в публичном представлении: доли и доходность, суммы — по разрешению владельца
+type PublicPosition struct {
+	Ticker         string                      `json:"ticker"`
+	Name           string                      `json:"name"`
+	InstrumentType sharedModels.InstrumentType `json:"instrumentType,omitempty"`
+	Currency       string                      `json:"currency"`
+	WeightPercent  float64                     `json:"weightPercent"`
+	YieldPercent   float64                     `json:"yieldPercent"`
+	Quantity       *int32                      `json:"quantity,omitempty"`
+	Value          *float64                    `json:"value,omitempty"`
+}
+
+// Доля валюты в публичном представлении
+type PublicExposure struct {
+	Currency     string  `json:"currency"`
+	SharePercent float64 `json:"sharePercent"`
+}
+
+// Публичное представление портфеля без идентификаторов и данных владельца
+type PublicPortfolio struct {
+	Name          string            `json:"name"`
+	Currency      string            `json:"currency"`
+	IsComposite   bool              `json:"isComposite"`
+	YieldPercent  float64           `json:"yieldPercent"`
+	TotalValue    *float64          `json:"totalValue,omitempty"`
+	ExpectedYield *float64          `json:"expectedYield,omitempty"`
+	Exposure      []*PublicExposure `json:"exposure"`
+	Positions     []*PublicPosition `json:"positions"`
+	UpdatedAt     time.Time         `json:"updatedAt"`
+}
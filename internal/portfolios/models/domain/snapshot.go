@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"time"
+)
+
+// Позиция в снимке портфеля (суммы в базовой валюте, кроме Value)
+type SnapshotPosition struct {
+	InstrumentUid string  `json:"instrumentUid"`
+	Ticker        string  `json:"ticker"`
+	Currency      string  `json:"currency"`
+	Quantity      int32   `json:"quantity"`
+	Rate          float64 `json:"rate"`
+	Value         float64 `json:"value"`
+	ValueBase     float64 `json:"valueBase"`
+	InvestedBase  float64 `json:"investedBase"`
+	ExpectedYield float64 `json:"expectedYield"`
+}
+
+// Снимок стоимости портфеля за день
+type PortfolioSnapshot struct {
+	ID             string              `json:"id"`
+	PortfolioID    string              `json:"portfolioId"`
+	Date           time.Time           `json:"date"`
+	Currency       string              `json:"currency"`
+	TotalValue     float64             `json:"totalValue"`
+	InvestedAmount float64             `json:"investedAmount"`
+	ExpectedYield  float64             `json:"expectedYield"`
+	YieldPercent   float64             `json:"yieldPercent"`
+	Positions      []*SnapshotPosition `json:"positions,omitempty"`
+	CreatedAt      time.Time           `json:"createdAt"`
+	UpdatedAt      time.Time           `json:"updatedAt"`
+}
+
+// История стоимости портфеля с выбранной детализацией
+type PortfolioHistory struct {
+	PortfolioID string               `json:"portfolioId"`
+	Granularity string               `json:"granularity"`
+	From        *time.Time           `json:"from,omitempty"`
+	To          *time.Time           `json:"to,omitempty"`
+	Points      []*PortfolioSnapshot `json:"points"`
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"time"
+
+	"invest-mate/internal/portfolios/models"
+)
+
+type Transaction struct {
+	ID            string                 `json:"id"`
+	PortfolioID   string                 `json:"portfolioId"`
+	Type          models.TransactionType `json:"type"`
+	InstrumentUid string                 `json:"instrumentUid,omitempty"`
+	Figi          string                 `json:"figi,omitempty"`
+	Ticker        string                 `json:"ticker,omitempty"`
+	Quantity      int32                  `json:"quantity"`
+	Price         float64                `json:"price"`
+	Amount        float64                `json:"amount"`
+	Commission    float64                `json:"commission"`
+	Currency      string                 `json:"currency"`
+	Note          string                 `json:"note"`
+	ExecutedAt    time.Time              `json:"executedAt"`
+	CreatedAt     time.Time              `json:"createdAt"`
+	UpdatedAt     time.Time              `json:"updatedAt"`
+
+	Lots []LotSelection `json:"lots,omitempty"`
+}
+
+type CreateTransactionRequest struct {
+	Type          models.TransactionType `json:"type"`
+	InstrumentUid string                 `json:"instrumentUid"`
+	Figi          string                 `json:"figi"`
+	Ticker        string                 `json:"ticker"`
+	Quantity      int32                  `json:"quantity"`
+	Price         float64                `json:"price"`
+	Amount        float64                `json:"amount"`
+	Commission    float64                `json:"commission"`
+	Currency      string                 `json:"currency"`
+	Note          string                 `json:"note"`
+	ExecutedAt    *time.Time             `json:"executedAt"`
+	Lots          []LotSelection         `json:"lots"`
+}
+
+type UpdateTransactionRequest struct {
+	Quantity   *int32          `json:"quantity"`
+	Price      *float64        `json:"price"`
+	Amount     *float64        `json:"amount"`
+	Commission *float64        `json:"commission"`
+	Currency   *string         `json:"currency"`
+	Note       *string         `json:"note"`
+	ExecutedAt *time.Time      `json:"executedAt"`
+	Lots       *[]LotSelection `json:"lots"`
+}
+
+// Фильтр списка операций портфеля
+type TransactionFilter struct {
+	InstrumentUid string
+	Type          models.TransactionType
+	From          *time.Time
+	To            *time.Time
+}
@@ -0,0 +1,40 @@
+package domain
+
+// Позиция, оценённая в базовой валюте портфеля
+type PositionValuation struct {
+	PositionID        string  `json:"positionId"`
+	PortfolioID       string  `json:"portfolioId"`
+	InstrumentUid     string  `json:"instrumentUid"`
+	Ticker            string  `json:"ticker"`
+	Currency          string  `json:"currency"`
+	Quantity          int32   `json:"quantity"`
+	Rate              float64 `json:"rate"`
+	Value             float64 `json:"value"`
+	InvestedAmount    float64 `json:"investedAmount"`
+	ValueBase         float64 `json:"valueBase"`
+	InvestedBase      float64 `json:"investedBase"`
+	ExpectedYieldBase float64 `json:"expectedYieldBase"`
+}
+
+// Доля валюты в стоимости портфеля
+type CurrencyExposure struct {
+	Currency     string  `json:"currency"`
+	Value        float64 `json:"value"`
+	ValueBase    float64 `json:"valueBase"`
+	SharePercent float64 `json:"sharePercent"`
+}
+
+// Оценка портфеля (вместе с вложенными) в базовой валюте
+type PortfolioValuation struct {
+	PortfolioID    string               `json:"portfolioId"`
+	PortfolioIDs   []string             `json:"portfolioIds"`
+	Currency       string               `json:"currency"`
+	TotalValue     float64              `json:"totalValue"`
+	InvestedAmount float64              `json:"investedAmount"`
+	ExpectedYield  float64              `json:"expectedYield"`
+	YieldPercent   float64              `json:"yieldPercent"`
+	Rates          map[string]float64   `json:"rates"`
+	Exposure       []*CurrencyExposure  `json:"exposure"`
+	Positions      []*PositionValuation `json:"positions"`
+	Unvalued       []string             `json:"unvalued"`
+}
@@ -0,0 +1,8 @@
+package entity
+
+// Ставка налога на дивиденды для эмитентов страны
+type DividendTaxRate struct {
+	PortfolioID string  `gorm:"primaryKey;type:uuid;autoIncrement:false;constraint:OnDelete:CASCADE"`
+	Country     string  `gorm:"primaryKey;size:2;autoIncrement:false"`
+	TaxPercent  float32 `gorm:"not null;default:0"`
+}
@@ -11,8 +11,10 @@ type Portfolio struct {
 	IsComposite               bool      `gorm:"not null;default:false"`
 	ApplyTaxesOnPaidDividends bool      `gorm:"not null;default:false"`
 	DividendTaxPercent        float32   `gorm:"default:0"`
+	CouponTaxPercent          *float32  `gorm:"default:null"`
 	HasToken                  bool      `gorm:"default:false"`
-	Token                     string    `gorm:"size:10;default:''"`
+	Token                     string    `gorm:"size:10;default:'';uniqueIndex:idx_portfolios_share_token,where:token <> ''"`
+	ShareAmounts              bool      `gorm:"not null;default:false"`
 	Currency                  string    `gorm:"size:3;default:'RUB'"`
 	Note                      string    `gorm:"size:255"`
 	IsHidden                  bool      `gorm:"default:false"`
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+)
+
+// Стоимость портфеля на конец дня
+type PortfolioSnapshot struct {
+	ID             string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	PortfolioID    string    `gorm:"type:uuid;not null;uniqueIndex:idx_snapshots_portfolio_date;constraint:OnDelete:CASCADE"`
+	Date           time.Time `gorm:"type:date;not null;uniqueIndex:idx_snapshots_portfolio_date"`
+	Currency       string    `gorm:"size:3;not null"`
+	TotalValue     float64   `gorm:"type:double precision;default:0.0"`
+	InvestedAmount float64   `gorm:"type:double precision;default:0.0"`
+	ExpectedYield  float64   `gorm:"type:double precision;default:0.0"`
+	YieldPercent   float64   `gorm:"type:double precision;default:0.0"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+}
+
+// Позиция в снимке портфеля
+type PortfolioSnapshotPosition struct {
+	ID            string  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	SnapshotID    string  `gorm:"type:uuid;not null;index;constraint:OnDelete:CASCADE"`
+	InstrumentUid string  `gorm:"type:text;not null"`
+	Ticker        string  `gorm:"type:text"`
+	Currency      string  `gorm:"size:3"`
+	Quantity      int32   `gorm:"not null;default:0"`
+	Rate          float64 `gorm:"type:double precision;default:0.0"`
+	Value         float64 `gorm:"type:double precision;default:0.0"`
+	ValueBase     float64 `gorm:"type:double precision;default:0.0"`
+	InvestedBase  float64 `gorm:"type:double precision;default:0.0"`
+	ExpectedYield float64 `gorm:"type:double precision;default:0.0"`
+}
@@ -6,11 +6,13 @@ import (
 
 type Position struct {
 	ID                       string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
-	PortfolioID              string    `gorm:"not null;index;constraint:OnDelete:CASCADE"`
+	PortfolioID              string    `gorm:"not null;index;uniqueIndex:idx_positions_portfolio_instrument;index:idx_positions_broker_account,priority:1;constraint:OnDelete:CASCADE"`
 	Figi                     string    `gorm:"type:text;not null"`
 	IsCustomData             bool      `gorm:"not null;default:false"`
+	Broker                   string    `gorm:"type:text;not null;default:'';index:idx_positions_broker_account,priority:2"`
+	BrokerAccountID          string    `gorm:"type:text;not null;default:'';index:idx_positions_broker_account,priority:3"`
 	Ticker                   string    `gorm:"type:text;not null"`
-	PositionUid              string    `gorm:"type:text;uniqueIndex"`
+	PositionUid              string    `gorm:"type:text;index"`
 	AveragePositionPrice     float64   `gorm:"type:double precision;default:0.0"`
 	AveragePositionPriceFifo float64   `gorm:"type:double precision;default:0.0"`
 	AveragePositionPricePt   float64   `gorm:"type:double precision;default:0.0"`
@@ -20,7 +22,7 @@ type Position struct {
 	DailyYield               float64   `gorm:"type:double precision;default:0.0"`
 	ExpectedYield            float64   `gorm:"type:double precision;default:0.0"`
 	ExpectedYieldFifo        float64   `gorm:"type:double precision;default:0.0"`
-	InstrumentUid            string    `gorm:"type:text;not null"`
+	InstrumentUid            string    `gorm:"type:text;not null;uniqueIndex:idx_positions_portfolio_instrument"`
 	Quantity                 int32     `gorm:"not null;default:0"`
 	QuantityLots             int32     `gorm:"not null;default:0"`
 	VarMargin                float64   `gorm:"type:double precision;default:0.0"`
@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"time"
+
+	"invest-mate/internal/portfolios/models"
+)
+
+// Целевая доля группы активов в портфеле
+type TargetAllocation struct {
+	ID            string                     `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	PortfolioID   string                     `gorm:"type:uuid;not null;uniqueIndex:idx_targets_portfolio_key;constraint:OnDelete:CASCADE"`
+	Dimension     models.AllocationDimension `gorm:"size:20;not null"`
+	Key           string                     `gorm:"type:text;not null;uniqueIndex:idx_targets_portfolio_key"`
+	WeightPercent float64                    `gorm:"type:double precision;not null;default:0.0"`
+	CreatedAt     time.Time                  `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"time"
+
+	"invest-mate/internal/portfolios/models"
+)
+
+type Transaction struct {
+	ID            string                 `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	PortfolioID   string                 `gorm:"not null;index:idx_transactions_portfolio_instrument;constraint:OnDelete:CASCADE"`
+	Type          models.TransactionType `gorm:"size:20;not null"`
+	InstrumentUid string                 `gorm:"type:text;index:idx_transactions_portfolio_instrument"`
+	Figi          string                 `gorm:"type:text"`
+	Ticker        string                 `gorm:"type:text"`
+	Quantity      int32                  `gorm:"not null;default:0"`
+	Price         float64                `gorm:"type:double precision;default:0.0"`
+	Amount        float64                `gorm:"type:double precision;default:0.0"`
+	Commission    float64                `gorm:"type:double precision;default:0.0"`
+	Currency      string                 `gorm:"size:3"`
+	Note          string                 `gorm:"size:255"`
+	ExecutedAt    time.Time              `gorm:"not null;index"`
+	CreatedAt     time.Time              `gorm:"autoCreateTime;not null"`
+	UpdatedAt     time.Time              `gorm:"autoUpdateTime;not null"`
+}
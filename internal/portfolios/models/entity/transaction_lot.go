@@ -0,0 +1,8 @@
+package entity
+
+// Партия покупки, явно выбранная для закрытия продажей
+type TransactionLot struct {
+	SellTransactionID string `gorm:"primaryKey;type:uuid;autoIncrement:false;constraint:OnDelete:CASCADE"`
+	BuyTransactionID  string `gorm:"primaryKey;type:uuid;autoIncrement:false;index;constraint:OnDelete:CASCADE"`
+	Quantity          int32  `gorm:"not null"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"errors"
+)
+
+var (
+	ErrPortfolioNotFound     = errors.New("Портфель не найден")
+	ErrPortfolioAccessDenied = errors.New("Нет доступа к портфелю")
+	ErrInvalidRequest        = errors.New("Некорректный запрос")
+	ErrPositionNotFound      = errors.New("Позиция не найдена")
+	ErrPositionAlreadyExists = errors.New("Позиция по инструменту уже есть в портфеле")
+	ErrInstrumentNotFound    = errors.New("Инструмент не найден")
+	ErrNotCompositePortfolio = errors.New("Портфель не является составным")
+	ErrCompositePortfolio    = errors.New("Составной портфель не может содержать собственные позиции")
+	ErrHierarchyCycle        = errors.New("Связь портфелей образует цикл")
+	ErrHierarchyLinkNotFound = errors.New("Портфель не входит в составной портфель")
+	ErrBrokerUnavailable     = errors.New("Не удалось получить данные брокера")
+	ErrTransactionNotFound   = errors.New("Операция не найдена")
+	ErrInsufficientQuantity  = errors.New("Продажа превышает количество бумаг в позиции")
+	ErrInvalidLotSelection   = errors.New("Выбранные партии не соответствуют продаже")
+	ErrTaxRateNotFound       = errors.New("Ставка налога для страны не задана")
+	ErrExchangeRateNotFound  = errors.New("Нет курса для валюты")
+	ErrMarketDataUnavailable = errors.New("Не удалось получить рыночные данные")
+	ErrTargetsNotFound       = errors.New("Целевое распределение портфеля не задано")
+	ErrImportRowsInvalid     = errors.New("В файле есть строки с ошибками")
+	ErrShareTokenTaken       = errors.New("Не удалось выпустить уникальный токен доступа")
+)
@@ -0,0 +1,37 @@
+package models
+
+type ExportFormat string
+
+const (
+	ExportFormatCsv  ExportFormat = "CSV"
+	ExportFormatXlsx ExportFormat = "XLSX"
+)
+
+// Проверка формата выгрузки на валидность
+func (f ExportFormat) IsValid() bool {
+	switch f {
+	case ExportFormatCsv, ExportFormatXlsx:
+		return true
+	default:
+		return false
+	}
+}
+
+type ExportDataset string
+
+const (
+	ExportDatasetPositions    ExportDataset = "POSITIONS"
+	ExportDatasetTransactions ExportDataset = "TRANSACTIONS"
+	ExportDatasetIncome       ExportDataset = "INCOME"
+	ExportDatasetAnalytics    ExportDataset = "ANALYTICS"
+)
+
+// Проверка набора данных выгрузки на валидность
+func (d ExportDataset) IsValid() bool {
+	switch d {
+	case ExportDatasetPositions, ExportDatasetTransactions, ExportDatasetIncome, ExportDatasetAnalytics:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,42 @@
+package models
+
+type TransactionType string
+
+const (
+	TransactionTypeBuy        TransactionType = "BUY"
+	TransactionTypeSell       TransactionType = "SELL"
+	TransactionTypeDividend   TransactionType = "DIVIDEND"
+	TransactionTypeCoupon     TransactionType = "COUPON"
+	TransactionTypeFee        TransactionType = "FEE"
+	TransactionTypeTax        TransactionType = "TAX"
+	TransactionTypeDeposit    TransactionType = "DEPOSIT"
+	TransactionTypeWithdrawal TransactionType = "WITHDRAWAL"
+)
+
+// Проверка типа операции на валидность
+func (t TransactionType) IsValid() bool {
+	switch t {
+	case TransactionTypeBuy, TransactionTypeSell,
+		TransactionTypeDividend, TransactionTypeCoupon,
+		TransactionTypeFee, TransactionTypeTax,
+		TransactionTypeDeposit, TransactionTypeWithdrawal:
+		return true
+	default:
+		return false
+	}
+}
+
+// Сделка с инструментом (меняет количество бумаг в позиции)
+func (t TransactionType) IsTrade() bool {
+	return t == TransactionTypeBuy || t == TransactionTypeSell
+}
+
+// Доход по инструменту
+func (t TransactionType) IsIncome() bool {
+	return t == TransactionTypeDividend || t == TransactionTypeCoupon
+}
+
+// Движение денег на счёт или со счёта
+func (t TransactionType) IsCashFlow() bool {
+	return t == TransactionTypeDeposit || t == TransactionTypeWithdrawal
+}
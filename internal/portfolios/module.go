@@ -3,15 +3,23 @@ package portfolios
 import (
 	"gorm.io/gorm"
 
+	assetsRepository "invest-mate/internal/assets/repository"
+	assetsServices "invest-mate/internal/assets/services"
+	"invest-mate/internal/assets/storage"
+	"invest-mate/internal/marketdata"
+	"invest-mate/internal/marketdata/stream"
 	"invest-mate/internal/portfolios/handlers"
 	"invest-mate/internal/portfolios/migrations"
 	"invest-mate/internal/portfolios/repository"
 	"invest-mate/internal/portfolios/services"
 	"invest-mate/internal/shared/config"
+	usersApi "invest-mate/internal/users/api"
 )
 
 type Module struct {
 	portfoliosHandler *handlers.PortfoliosHandler
+	snapshotScheduler *services.SnapshotScheduler
+	priceScheduler    *services.PriceScheduler
 }
 
 // Инициализация модуля
@@ -21,11 +29,70 @@ func InitModule(db *gorm.DB, cfg *config.Config) (*Module, error) {
 		return nil, err
 	}
 
+	tinkoffStorage := storage.GetInstance(assetsRepository.NewAssetRepository(db))
+
+	candlesService, err := marketdata.GetCandlesService(db)
+	if err != nil {
+		return nil, err
+	}
+
 	portfoliosRepo := repository.NewPortfoliosRepository(db)
+	positionsRepo := repository.NewPositionsRepository(db)
+	hierarchyRepo := repository.NewHierarchyRepository(db)
+	transactionsRepo := repository.NewTransactionsRepository(db)
+	taxRatesRepo := repository.NewTaxRatesRepository(db)
+	snapshotsRepo := repository.NewSnapshotsRepository(db)
+	allocationsRepo := repository.NewAllocationsRepository(db)
 	portfoliosService := services.NewPortfoliosService(portfoliosRepo)
-	portfoliosHandler := handlers.NewPortfoliosHandler(portfoliosService)
+	positionsService := services.NewPositionsService(portfoliosService, positionsRepo, tinkoffStorage)
+	compositeService := services.NewCompositeService(portfoliosService, hierarchyRepo, positionsRepo, tinkoffStorage, tinkoffStorage)
+	importService := services.NewImportService(portfoliosService, positionsRepo, tinkoffStorage, usersApi.NewPortfolioSource(cfg.TinkoffAPIURL))
+	transactionsService := services.NewTransactionsService(portfoliosService, transactionsRepo, positionsRepo, tinkoffStorage)
+	lotsService := services.NewLotsService(portfoliosService, transactionsRepo)
+	incomeService := services.NewIncomeService(portfoliosService, transactionsRepo, taxRatesRepo, tinkoffStorage)
+	valuationService := services.NewValuationService(portfoliosService, compositeService, positionsRepo, tinkoffStorage, tinkoffStorage)
+	performanceService := services.NewPerformanceService(portfoliosService, compositeService, transactionsRepo, positionsRepo, tinkoffStorage, tinkoffStorage, candlesService)
+	snapshotsService := services.NewSnapshotsService(portfoliosService, portfoliosRepo, snapshotsRepo, valuationService, tinkoffStorage)
+	shareService := services.NewShareService(portfoliosService, portfoliosRepo, valuationService, tinkoffStorage)
+	allocationService := services.NewAllocationService(portfoliosService, allocationsRepo, valuationService, tinkoffStorage, tinkoffStorage, tinkoffStorage)
+	calendarService := services.NewCalendarService(portfoliosService, compositeService, positionsRepo, tinkoffStorage, tinkoffStorage)
+	durationService := services.NewDurationService(portfoliosService, valuationService, tinkoffStorage, assetsServices.NewBondAnalyticsService(tinkoffStorage))
+	diversificationService := services.NewDiversificationService(portfoliosService, valuationService, tinkoffStorage)
+	priceHub := stream.GetInstance(tinkoffStorage, cfg.PriceStreamInterval)
+	streamService := services.NewStreamService(portfoliosService, compositeService, positionsRepo, tinkoffStorage, priceHub)
+	csvImportService := services.NewCsvImportService(portfoliosService, transactionsService, transactionsRepo, assetsRepository.NewAssetRepository(db))
+	exportService := services.NewExportService(portfoliosService, compositeService, transactionsRepo, incomeService, valuationService, performanceService, tinkoffStorage)
+	portfoliosHandler := handlers.NewPortfoliosHandler(
+		portfoliosService,
+		positionsService,
+		compositeService,
+		importService,
+		transactionsService,
+		lotsService,
+		incomeService,
+		valuationService,
+		performanceService,
+		snapshotsService,
+		shareService,
+		allocationService,
+		calendarService,
+		durationService,
+		diversificationService,
+		streamService,
+		csvImportService,
+		exportService,
+	)
+
+	snapshotScheduler := services.NewSnapshotScheduler(snapshotsService, cfg.SnapshotInterval)
+	snapshotScheduler.Start()
+
+	pricesService := services.NewPricesService(positionsRepo, tinkoffStorage, tinkoffStorage)
+	priceScheduler := services.NewPriceScheduler(pricesService, cfg.PriceRefreshInterval)
+	priceScheduler.Start()
 
 	return &Module{
 		portfoliosHandler: portfoliosHandler,
+		snapshotScheduler: snapshotScheduler,
+		priceScheduler:    priceScheduler,
 	}, nil
 }
@@ -31,5 +31,10 @@ func (mw *ModuleWrapper) GetHandler() interface{} {
 }
 
 func (mw *ModuleWrapper) Close() error {
+	if mw.module != nil {
+		mw.module.priceScheduler.Stop()
+		mw.module.snapshotScheduler.Stop()
+	}
+
 	return nil
 }
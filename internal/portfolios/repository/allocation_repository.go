@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"invest-mate/internal/portfolios/mappers"
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/internal/portfolios/models/entity"
+)
+
+type AllocationsRepository interface {
+	GetByPortfolio(ctx context.Context, portfolioID string) ([]*domain.TargetAllocation, error)
+	Replace(ctx context.Context, portfolioID string, targets []*domain.TargetAllocation) error
+	DeleteByPortfolio(ctx context.Context, portfolioID string) (bool, error)
+}
+
+type allocationsRepository struct {
+	db *gorm.DB
+}
+
+// Создание нового репозитория целевых долей
+func NewAllocationsRepository(db *gorm.DB) AllocationsRepository {
+	return &allocationsRepository{db: db}
+}
+
+// Получить целевые доли портфеля из БД
+func (r *allocationsRepository) GetByPortfolio(ctx context.Context, portfolioID string) ([]*domain.TargetAllocation, error) {
+	var entityTargets []entity.TargetAllocation
+
+	err := r.db.WithContext(ctx).
+		Where("portfolio_id = ?", portfolioID).
+		Order("weight_percent DESC, key").
+		Find(&entityTargets).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return mappers.FromTargetEntityToDomainSlice(entityTargets), nil
+}
+
+// Замена всех целевых долей портфеля в БД
+func (r *allocationsRepository) Replace(ctx context.Context, portfolioID string, targets []*domain.TargetAllocation) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&entity.TargetAllocation{}, "portfolio_id = ?", portfolioID).Error; err != nil {
+			return err
+		}
+
+		if len(targets) == 0 {
+			return nil
+		}
+
+		entityTargets := make([]entity.TargetAllocation, 0, len(targets))
+		for _, target := range targets {
+			entityTargets = append(entityTargets, mappers.FromTargetDomainToEntity(portfolioID, target))
+		}
+
+		return tx.Create(&entityTargets).Error
+	})
+}
+
+// Удаление всех целевых долей портфеля из БД
+func (r *allocationsRepository) DeleteByPortfolio(ctx context.Context, portfolioID string) (bool, error) {
+	result := r.db.WithContext(ctx).Delete(&entity.TargetAllocation{}, "portfolio_id = ?", portfolioID)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
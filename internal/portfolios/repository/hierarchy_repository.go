@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"invest-mate/internal/portfolios/models/entity"
+)
+
+type HierarchyRepository interface {
+	AddChild(ctx context.Context, parentID, childID string) error
+	RemoveChild(ctx context.Context, parentID, childID string) (bool, error)
+	GetChildIDs(ctx context.Context, parentID string) ([]string, error)
+	GetParentIDs(ctx context.Context, childID string) ([]string, error)
+}
+
+type hierarchyRepository struct {
+	db *gorm.DB
+}
+
+// Создание нового репозитория иерархии портфелей
+func NewHierarchyRepository(db *gorm.DB) HierarchyRepository {
+	return &hierarchyRepository{db: db}
+}
+
+// Добавление дочернего портфеля в БД
+func (r *hierarchyRepository) AddChild(ctx context.Context, parentID, childID string) error {
+	link := entity.PortfolioHierarchy{
+		ParentID: parentID,
+		ChildID:  childID,
+	}
+
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&link).Error
+}
+
+// Удаление дочернего портфеля из БД
+func (r *hierarchyRepository) RemoveChild(ctx context.Context, parentID, childID string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Delete(&entity.PortfolioHierarchy{}, "parent_id = ? AND child_id = ?", parentID, childID)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// Получение идентификаторов дочерних портфелей из БД
+func (r *hierarchyRepository) GetChildIDs(ctx context.Context, parentID string) ([]string, error) {
+	var ids []string
+
+	err := r.db.WithContext(ctx).
+		Model(&entity.PortfolioHierarchy{}).
+		Where("parent_id = ?", parentID).
+		Pluck("child_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// Получение идентификаторов родительских портфелей из БД
+func (r *hierarchyRepository) GetParentIDs(ctx context.Context, childID string) ([]string, error) {
+	var ids []string
+
+	err := r.db.WithContext(ctx).
+		Model(&entity.PortfolioHierarchy{}).
+		Where("child_id = ?", childID).
+		Pluck("parent_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
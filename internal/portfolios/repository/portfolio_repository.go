@@ -1,10 +1,33 @@
 package repository
 
 import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
+
+	"invest-mate/internal/portfolios/mappers"
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/internal/portfolios/models/entity"
+)
+
+const (
+	// Код ошибки Postgres при нарушении уникальности
+	uniqueViolationCode = "23505"
+	shareTokenIndex     = "idx_portfolios_share_token"
 )
 
 type PortfoliosRepository interface {
+	Create(ctx context.Context, portfolio *domain.Portfolio) error
+	FindByID(ctx context.Context, id string) (*domain.Portfolio, error)
+	FindByToken(ctx context.Context, token string) (*domain.Portfolio, error)
+	GetListByUser(ctx context.Context, userID string, includeHidden bool, limit, offset int) ([]*domain.Portfolio, error)
+	CountByUser(ctx context.Context, userID string, includeHidden bool) (int64, error)
+	GetAll(ctx context.Context) ([]*domain.Portfolio, error)
+	Update(ctx context.Context, portfolio *domain.Portfolio) error
+	Delete(ctx context.Context, id string) (bool, error)
 }
 
 type portfoliosRepository struct {
@@ -15,3 +38,176 @@ type portfoliosRepository struct {
 func NewPortfoliosRepository(db *gorm.DB) PortfoliosRepository {
 	return &portfoliosRepository{db: db}
 }
+
+// Создание нового портфеля в БД
+func (r *portfoliosRepository) Create(ctx context.Context, portfolio *domain.Portfolio) error {
+	entityPortfolio := mappers.FromDomainToEntity(portfolio)
+
+	if err := r.db.WithContext(ctx).Create(&entityPortfolio).Error; err != nil {
+		return err
+	}
+
+	portfolio.ID = entityPortfolio.ID
+	portfolio.CreatedAt = entityPortfolio.CreatedAt
+	portfolio.UpdatedAt = entityPortfolio.UpdatedAt
+
+	return nil
+}
+
+// Найти портфель по идентификатору в БД
+func (r *portfoliosRepository) FindByID(ctx context.Context, id string) (*domain.Portfolio, error) {
+	var entityPortfolio entity.Portfolio
+
+	err := r.db.WithContext(ctx).First(&entityPortfolio, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.ErrPortfolioNotFound
+		}
+		return nil, err
+	}
+
+	return mappers.FromEntityToDomain(entityPortfolio), nil
+}
+
+// Найти портфель с открытым доступом по токену в БД
+func (r *portfoliosRepository) FindByToken(ctx context.Context, token string) (*domain.Portfolio, error) {
+	var entityPortfolio entity.Portfolio
+
+	err := r.db.WithContext(ctx).First(&entityPortfolio, "has_token = ? AND token = ?", true, token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.ErrPortfolioNotFound
+		}
+		return nil, err
+	}
+
+	return mappers.FromEntityToDomain(entityPortfolio), nil
+}
+
+// Получить список портфелей пользователя в БД
+func (r *portfoliosRepository) GetListByUser(ctx context.Context, userID string, includeHidden bool, limit, offset int) ([]*domain.Portfolio, error) {
+	var entityPortfolios []entity.Portfolio
+
+	query := r.userScope(ctx, userID, includeHidden).
+		Order("created_at DESC")
+
+	if limit > 0 {
+		query = query.Limit(limit).Offset(offset)
+	}
+
+	if err := query.Find(&entityPortfolios).Error; err != nil {
+		return nil, err
+	}
+
+	return mappers.FromEntityToDomainSlice(entityPortfolios), nil
+}
+
+// Подсчёт портфелей пользователя в БД
+func (r *portfoliosRepository) CountByUser(ctx context.Context, userID string, includeHidden bool) (int64, error) {
+	var count int64
+
+	if err := r.userScope(ctx, userID, includeHidden).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// Получить все портфели из БД
+func (r *portfoliosRepository) GetAll(ctx context.Context) ([]*domain.Portfolio, error) {
+	var entityPortfolios []entity.Portfolio
+
+	if err := r.db.WithContext(ctx).Order("created_at").Find(&entityPortfolios).Error; err != nil {
+		return nil, err
+	}
+
+	return mappers.FromEntityToDomainSlice(entityPortfolios), nil
+}
+
+// Обновить портфель в БД
+func (r *portfoliosRepository) Update(ctx context.Context, portfolio *domain.Portfolio) error {
+	entityPortfolio := mappers.FromDomainToEntity(portfolio)
+
+	if err := r.db.WithContext(ctx).Save(&entityPortfolio).Error; err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode && pgErr.ConstraintName == shareTokenIndex {
+			return models.ErrShareTokenTaken
+		}
+		return err
+	}
+
+	portfolio.UpdatedAt = entityPortfolio.UpdatedAt
+
+	return nil
+}
+
+// Удаление портфеля вместе со всеми зависимыми данными из БД
+func (r *portfoliosRepository) Delete(ctx context.Context, id string) (bool, error) {
+	var deleted bool
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&entity.Position{}, "portfolio_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("sell_transaction_id IN (?)",
+			tx.Model(&entity.Transaction{}).Select("id").Where("portfolio_id = ?", id),
+		).Delete(&entity.TransactionLot{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&entity.Transaction{}, "portfolio_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&entity.DividendTaxRate{}, "portfolio_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&entity.TargetAllocation{}, "portfolio_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("snapshot_id IN (?)",
+			tx.Model(&entity.PortfolioSnapshot{}).Select("id").Where("portfolio_id = ?", id),
+		).Delete(&entity.PortfolioSnapshotPosition{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&entity.PortfolioSnapshot{}, "portfolio_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&entity.PortfolioHierarchy{}, "parent_id = ? OR child_id = ?", id, id).Error; err != nil {
+			return err
+		}
+
+		result := tx.Delete(&entity.Portfolio{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		deleted = result.RowsAffected > 0
+
+		return nil
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return deleted, nil
+}
+
+// Базовый запрос портфелей пользователя
+func (r *portfoliosRepository) userScope(ctx context.Context, userID string, includeHidden bool) *gorm.DB {
+	query := r.db.WithContext(ctx).
+		Model(&entity.Portfolio{}).
+		Where("user_id = ?", userID)
+
+	if !includeHidden {
+		query = query.Where("is_hidden = ?", false)
+	}
+
+	return query
+}
@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"invest-mate/internal/portfolios/mappers"
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/internal/portfolios/models/entity"
+)
+
+type PositionsRepository interface {
+	Create(ctx context.Context, position *domain.Position) error
+	FindByID(ctx context.Context, portfolioID, id string) (*domain.Position, error)
+	FindByInstrument(ctx context.Context, portfolioID, instrumentUid string) (*domain.Position, error)
+	GetByPortfolio(ctx context.Context, portfolioID string) ([]*domain.Position, error)
+	GetByPortfolios(ctx context.Context, portfolioIDs []string) ([]*domain.Position, error)
+	Update(ctx context.Context, position *domain.Position) error
+	Delete(ctx context.Context, portfolioID, id string) (bool, error)
+	ReplaceBrokerPositions(ctx context.Context, portfolioID, broker, accountID string, positions []*domain.Position) (int64, error)
+	GetHeldInstrumentUids(ctx context.Context) ([]string, error)
+	UpdateCurrentPrices(ctx context.Context, prices map[string]float64) (int64, error)
+}
+
+// Колонки, перезаписываемые при загрузке позиций брокера
+var brokerPositionColumns = []string{
+	"figi",
+	"is_custom_data",
+	"broker",
+	"broker_account_id",
+	"ticker",
+	"position_uid",
+	"average_position_price",
+	"average_position_price_fifo",
+	"average_position_price_pt",
+	"is_blocked",
+	"blocked_lots",
+	"current_price",
+	"daily_yield",
+	"expected_yield",
+	"expected_yield_fifo",
+	"quantity",
+	"quantity_lots",
+	"var_margin",
+	"current_nkd",
+	"updated_at",
+}
+
+type positionsRepository struct {
+	db *gorm.DB
+}
+
+// Создание нового репозитория позиций
+func NewPositionsRepository(db *gorm.DB) PositionsRepository {
+	return &positionsRepository{db: db}
+}
+
+// Создание новой позиции в БД
+func (r *positionsRepository) Create(ctx context.Context, position *domain.Position) error {
+	entityPosition := mappers.FromPositionDomainToEntity(position)
+
+	if err := r.db.WithContext(ctx).Create(&entityPosition).Error; err != nil {
+		return err
+	}
+
+	position.ID = entityPosition.ID
+	position.CreatedAt = entityPosition.CreatedAt
+	position.UpdatedAt = entityPosition.UpdatedAt
+
+	return nil
+}
+
+// Найти позицию портфеля по идентификатору в БД
+func (r *positionsRepository) FindByID(ctx context.Context, portfolioID, id string) (*domain.Position, error) {
+	return r.findOne(ctx, "portfolio_id = ? AND id = ?", portfolioID, id)
+}
+
+// Найти позицию портфеля по инструменту в БД
+func (r *positionsRepository) FindByInstrument(ctx context.Context, portfolioID, instrumentUid string) (*domain.Position, error) {
+	return r.findOne(ctx, "portfolio_id = ? AND instrument_uid = ?", portfolioID, instrumentUid)
+}
+
+// Получить позиции портфеля из БД
+func (r *positionsRepository) GetByPortfolio(ctx context.Context, portfolioID string) ([]*domain.Position, error) {
+	var entityPositions []entity.Position
+
+	err := r.db.WithContext(ctx).
+		Where("portfolio_id = ?", portfolioID).
+		Order("ticker").
+		Find(&entityPositions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return mappers.FromPositionEntityToDomainSlice(entityPositions), nil
+}
+
+// Получить позиции нескольких портфелей из БД
+func (r *positionsRepository) GetByPortfolios(ctx context.Context, portfolioIDs []string) ([]*domain.Position, error) {
+	if len(portfolioIDs) == 0 {
+		return []*domain.Position{}, nil
+	}
+
+	var entityPositions []entity.Position
+
+	err := r.db.WithContext(ctx).
+		Where("portfolio_id IN ?", portfolioIDs).
+		Order("ticker").
+		Find(&entityPositions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return mappers.FromPositionEntityToDomainSlice(entityPositions), nil
+}
+
+// Обновить позицию в БД
+func (r *positionsRepository) Update(ctx context.Context, position *domain.Position) error {
+	entityPosition := mappers.FromPositionDomainToEntity(position)
+
+	if err := r.db.WithContext(ctx).Save(&entityPosition).Error; err != nil {
+		return err
+	}
+
+	position.UpdatedAt = entityPosition.UpdatedAt
+
+	return nil
+}
+
+// Удаление позиции портфеля из БД
+func (r *positionsRepository) Delete(ctx context.Context, portfolioID, id string) (bool, error) {
+	result := r.db.WithContext(ctx).Delete(&entity.Position{}, "portfolio_id = ? AND id = ?", portfolioID, id)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// Замена позиций брокерского счёта в БД: позиции счёта по тем же инструментам перезаписываются,
+// устаревшие позиции счёта удаляются. Позиции других счетов и пользовательские позиции не изменяются.
+// Позиции брокера без счёта (загруженные до появления колонки счёта) считаются позициями загружаемого счёта
+func (r *positionsRepository) ReplaceBrokerPositions(ctx context.Context, portfolioID, broker, accountID string, positions []*domain.Position) (int64, error) {
+	var removed int64
+
+	entityPositions := mappers.FromPositionDomainToEntitySlice(positions)
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		instrumentUids := make([]string, 0, len(entityPositions))
+
+		for i := range entityPositions {
+			instrumentUids = append(instrumentUids, entityPositions[i].InstrumentUid)
+
+			// Конфликт с позицией другого счёта или пользовательской позицией оставляет её без изменений
+			err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "portfolio_id"}, {Name: "instrument_uid"}},
+				Where: clause.Where{Exprs: []clause.Expression{
+					clause.Expr{
+						SQL:  "positions.is_custom_data = ? AND positions.broker = ? AND positions.broker_account_id IN ?",
+						Vars: []any{false, broker, []string{accountID, ""}},
+					},
+				}},
+				DoUpdates: clause.AssignmentColumns(brokerPositionColumns),
+			}).Create(&entityPositions[i]).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		query := tx.Where("portfolio_id = ? AND is_custom_data = ? AND broker = ? AND broker_account_id IN ?",
+			portfolioID, false, broker, []string{accountID, ""})
+		if len(instrumentUids) > 0 {
+			query = query.Where("instrument_uid NOT IN ?", instrumentUids)
+		}
+
+		result := query.Delete(&entity.Position{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		removed = result.RowsAffected
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return removed, nil
+}
+
+// Получить инструменты открытых позиций всех портфелей из БД
+func (r *positionsRepository) GetHeldInstrumentUids(ctx context.Context) ([]string, error) {
+	var uids []string
+
+	err := r.db.WithContext(ctx).
+		Model(&entity.Position{}).
+		Where("quantity <> 0").
+		Distinct().
+		Pluck("instrument_uid", &uids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return uids, nil
+}
+
+// Обновление текущих цен позиций по инструментам в БД; возвращает число обновлённых позиций
+func (r *positionsRepository) UpdateCurrentPrices(ctx context.Context, prices map[string]float64) (int64, error) {
+	var updated int64
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for instrumentUid, price := range prices {
+			result := tx.Model(&entity.Position{}).
+				Where("instrument_uid = ? AND current_price <> ?", instrumentUid, price).
+				Update("current_price", price)
+			if result.Error != nil {
+				return result.Error
+			}
+
+			updated += result.RowsAffected
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return updated, nil
+}
+
+// Поиск одной позиции по условию
+func (r *positionsRepository) findOne(ctx context.Context, query string, args ...any) (*domain.Position, error) {
+	var entityPosition entity.Position
+
+	err := r.db.WithContext(ctx).Where(query, args...).First(&entityPosition).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.ErrPositionNotFound
+		}
+		return nil, err
+	}
+
+	return mappers.FromPositionEntityToDomain(entityPosition), nil
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"invest-mate/internal/portfolios/mappers"
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/internal/portfolios/models/entity"
+)
+
+type SnapshotsRepository interface {
+	Save(ctx context.Context, snapshot *domain.PortfolioSnapshot) error
+	GetByPortfolio(ctx context.Context, portfolioID string, from, to *time.Time, withPositions bool) ([]*domain.PortfolioSnapshot, error)
+}
+
+type snapshotsRepository struct {
+	db *gorm.DB
+}
+
+// Создание нового репозитория снимков портфелей
+func NewSnapshotsRepository(db *gorm.DB) SnapshotsRepository {
+	return &snapshotsRepository{db: db}
+}
+
+// Сохранение снимка за день с заменой ранее сохранённого в БД
+func (r *snapshotsRepository) Save(ctx context.Context, snapshot *domain.PortfolioSnapshot) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing entity.PortfolioSnapshot
+
+		err := tx.Where("portfolio_id = ? AND date = ?", snapshot.PortfolioID, snapshot.Date).First(&existing).Error
+		switch {
+		case err == nil:
+			snapshot.ID = existing.ID
+			snapshot.CreatedAt = existing.CreatedAt
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
+
+		entitySnapshot := mappers.FromSnapshotDomainToEntity(snapshot)
+
+		if err := tx.Save(&entitySnapshot).Error; err != nil {
+			return err
+		}
+
+		// Идентификатор нового снимка генерирует БД
+		snapshot.ID = entitySnapshot.ID
+		snapshot.CreatedAt = entitySnapshot.CreatedAt
+
+		if err := tx.Delete(&entity.PortfolioSnapshotPosition{}, "snapshot_id = ?", snapshot.ID).Error; err != nil {
+			return err
+		}
+
+		if len(snapshot.Positions) == 0 {
+			return nil
+		}
+
+		positions := make([]entity.PortfolioSnapshotPosition, 0, len(snapshot.Positions))
+		for _, position := range snapshot.Positions {
+			positions = append(positions, mappers.FromSnapshotPositionDomainToEntity(snapshot.ID, position))
+		}
+
+		return tx.Create(&positions).Error
+	})
+}
+
+// Получить снимки портфеля за период в хронологическом порядке из БД
+func (r *snapshotsRepository) GetByPortfolio(ctx context.Context, portfolioID string, from, to *time.Time, withPositions bool) ([]*domain.PortfolioSnapshot, error) {
+	var entitySnapshots []entity.PortfolioSnapshot
+
+	query := r.db.WithContext(ctx).Where("portfolio_id = ?", portfolioID)
+
+	if from != nil {
+		query = query.Where("date >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("date <= ?", *to)
+	}
+
+	if err := query.Order("date").Find(&entitySnapshots).Error; err != nil {
+		return nil, err
+	}
+
+	snapshots := mappers.FromSnapshotEntityToDomainSlice(entitySnapshots)
+
+	if !withPositions || len(snapshots) == 0 {
+		return snapshots, nil
+	}
+
+	ids := make([]string, 0, len(snapshots))
+	byID := make(map[string]*domain.PortfolioSnapshot, len(snapshots))
+
+	for _, snapshot := range snapshots {
+		ids = append(ids, snapshot.ID)
+		byID[snapshot.ID] = snapshot
+		snapshot.Positions = make([]*domain.SnapshotPosition, 0)
+	}
+
+	var entityPositions []entity.PortfolioSnapshotPosition
+
+	err := r.db.WithContext(ctx).
+		Where("snapshot_id IN ?", ids).
+		Order("value_base DESC").
+		Find(&entityPositions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entityPosition := range entityPositions {
+		snapshot := byID[entityPosition.SnapshotID]
+		snapshot.Positions = append(snapshot.Positions, mappers.FromSnapshotPositionEntityToDomain(entityPosition))
+	}
+
+	return snapshots, nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"invest-mate/internal/portfolios/mappers"
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/internal/portfolios/models/entity"
+)
+
+type TaxRatesRepository interface {
+	GetByPortfolio(ctx context.Context, portfolioID string) ([]*domain.DividendTaxRate, error)
+	Upsert(ctx context.Context, portfolioID string, rate *domain.DividendTaxRate) error
+	Delete(ctx context.Context, portfolioID, country string) (bool, error)
+}
+
+type taxRatesRepository struct {
+	db *gorm.DB
+}
+
+// Создание нового репозитория ставок налога
+func NewTaxRatesRepository(db *gorm.DB) TaxRatesRepository {
+	return &taxRatesRepository{db: db}
+}
+
+// Получить ставки налога портфеля по странам из БД
+func (r *taxRatesRepository) GetByPortfolio(ctx context.Context, portfolioID string) ([]*domain.DividendTaxRate, error) {
+	var entityRates []entity.DividendTaxRate
+
+	err := r.db.WithContext(ctx).
+		Where("portfolio_id = ?", portfolioID).
+		Order("country").
+		Find(&entityRates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return mappers.FromTaxRateEntityToDomainSlice(entityRates), nil
+}
+
+// Сохранение ставки налога для страны в БД
+func (r *taxRatesRepository) Upsert(ctx context.Context, portfolioID string, rate *domain.DividendTaxRate) error {
+	entityRate := entity.DividendTaxRate{
+		PortfolioID: portfolioID,
+		Country:     rate.Country,
+		TaxPercent:  rate.TaxPercent,
+	}
+
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "portfolio_id"}, {Name: "country"}},
+			DoUpdates: clause.AssignmentColumns([]string{"tax_percent"}),
+		}).
+		Create(&entityRate).Error
+}
+
+// Удаление ставки налога для страны из БД
+func (r *taxRatesRepository) Delete(ctx context.Context, portfolioID, country string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Delete(&entity.DividendTaxRate{}, "portfolio_id = ? AND country = ?", portfolioID, country)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,405 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"invest-mate/internal/portfolios/mappers"
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/internal/portfolios/models/entity"
+)
+
+type TransactionsRepository interface {
+	Create(ctx context.Context, transaction *domain.Transaction) error
+	CreateBatch(ctx context.Context, transactions []*domain.Transaction) error
+	FindByID(ctx context.Context, portfolioID, id string) (*domain.Transaction, error)
+	GetByPortfolio(ctx context.Context, portfolioID string, filter *domain.TransactionFilter, limit, offset int) ([]*domain.Transaction, error)
+	EachByPortfolios(ctx context.Context, portfolioIDs []string, filter *domain.TransactionFilter, batchSize int, fn func([]*domain.Transaction) error) error
+	CountByPortfolio(ctx context.Context, portfolioID string, filter *domain.TransactionFilter) (int64, error)
+	GetByInstrument(ctx context.Context, portfolioID, instrumentUid string) ([]*domain.Transaction, error)
+	GetTrades(ctx context.Context, portfolioID, instrumentUid string) ([]*domain.Transaction, error)
+	GetIncome(ctx context.Context, portfolioID string, from, to *time.Time) ([]*domain.Transaction, error)
+	GetByPortfolios(ctx context.Context, portfolioIDs []string) ([]*domain.Transaction, error)
+	GetTradedInstrumentUids(ctx context.Context, portfolioID string) ([]string, error)
+	Update(ctx context.Context, transaction *domain.Transaction) error
+	Delete(ctx context.Context, portfolioID, id string) (bool, error)
+}
+
+type transactionsRepository struct {
+	db *gorm.DB
+}
+
+// Создание нового репозитория операций
+func NewTransactionsRepository(db *gorm.DB) TransactionsRepository {
+	return &transactionsRepository{db: db}
+}
+
+// Создание новой операции в БД
+func (r *transactionsRepository) Create(ctx context.Context, transaction *domain.Transaction) error {
+	entityTransaction := mappers.FromTransactionDomainToEntity(transaction)
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&entityTransaction).Error; err != nil {
+			return err
+		}
+
+		return saveLots(tx, entityTransaction.ID, transaction.Lots)
+	})
+	if err != nil {
+		return err
+	}
+
+	transaction.ID = entityTransaction.ID
+	transaction.CreatedAt = entityTransaction.CreatedAt
+	transaction.UpdatedAt = entityTransaction.UpdatedAt
+
+	return nil
+}
+
+// Создание нескольких операций одной транзакцией в БД
+func (r *transactionsRepository) CreateBatch(ctx context.Context, transactions []*domain.Transaction) error {
+	if len(transactions) == 0 {
+		return nil
+	}
+
+	entityTransactions := make([]entity.Transaction, len(transactions))
+	for index, transaction := range transactions {
+		entityTransactions[index] = mappers.FromTransactionDomainToEntity(transaction)
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.CreateInBatches(&entityTransactions, 500).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	for index, transaction := range transactions {
+		transaction.ID = entityTransactions[index].ID
+		transaction.CreatedAt = entityTransactions[index].CreatedAt
+		transaction.UpdatedAt = entityTransactions[index].UpdatedAt
+	}
+
+	return nil
+}
+
+// Найти операцию портфеля по идентификатору в БД
+func (r *transactionsRepository) FindByID(ctx context.Context, portfolioID, id string) (*domain.Transaction, error) {
+	var entityTransaction entity.Transaction
+
+	err := r.db.WithContext(ctx).
+		Where("portfolio_id = ? AND id = ?", portfolioID, id).
+		First(&entityTransaction).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.ErrTransactionNotFound
+		}
+		return nil, err
+	}
+
+	transaction := mappers.FromTransactionEntityToDomain(entityTransaction)
+
+	if err := r.attachLots(ctx, []*domain.Transaction{transaction}); err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// Получить операции портфеля из БД (новые первыми)
+func (r *transactionsRepository) GetByPortfolio(ctx context.Context, portfolioID string, filter *domain.TransactionFilter, limit, offset int) ([]*domain.Transaction, error) {
+	var entityTransactions []entity.Transaction
+
+	query := r.portfolioScope(ctx, portfolioID, filter).
+		Order("executed_at DESC, created_at DESC")
+
+	if limit > 0 {
+		query = query.Limit(limit).Offset(offset)
+	}
+
+	if err := query.Find(&entityTransactions).Error; err != nil {
+		return nil, err
+	}
+
+	return mappers.FromTransactionEntityToDomainSlice(entityTransactions), nil
+}
+
+// Обход операций портфелей из БД страницами от старых к новым
+func (r *transactionsRepository) EachByPortfolios(ctx context.Context, portfolioIDs []string, filter *domain.TransactionFilter, batchSize int, fn func([]*domain.Transaction) error) error {
+	if len(portfolioIDs) == 0 {
+		return nil
+	}
+
+	for offset := 0; ; offset += batchSize {
+		var entityTransactions []entity.Transaction
+
+		query := r.db.WithContext(ctx).
+			Model(&entity.Transaction{}).
+			Where("portfolio_id IN ?", portfolioIDs)
+
+		err := applyTransactionFilter(query, filter).
+			Order("executed_at, created_at, id").
+			Limit(batchSize).
+			Offset(offset).
+			Find(&entityTransactions).Error
+		if err != nil {
+			return err
+		}
+
+		if len(entityTransactions) > 0 {
+			if err := fn(mappers.FromTransactionEntityToDomainSlice(entityTransactions)); err != nil {
+				return err
+			}
+		}
+
+		if len(entityTransactions) < batchSize {
+			return nil
+		}
+	}
+}
+
+// Подсчёт операций портфеля в БД
+func (r *transactionsRepository) CountByPortfolio(ctx context.Context, portfolioID string, filter *domain.TransactionFilter) (int64, error) {
+	var count int64
+
+	if err := r.portfolioScope(ctx, portfolioID, filter).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// Получить операции по инструменту в хронологическом порядке из БД
+func (r *transactionsRepository) GetByInstrument(ctx context.Context, portfolioID, instrumentUid string) ([]*domain.Transaction, error) {
+	var entityTransactions []entity.Transaction
+
+	err := r.db.WithContext(ctx).
+		Where("portfolio_id = ? AND instrument_uid = ?", portfolioID, instrumentUid).
+		Order("executed_at, created_at").
+		Find(&entityTransactions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := mappers.FromTransactionEntityToDomainSlice(entityTransactions)
+
+	if err := r.attachLots(ctx, transactions); err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+// Получить сделки портфеля (по всем инструментам или одному) в хронологическом порядке из БД
+func (r *transactionsRepository) GetTrades(ctx context.Context, portfolioID, instrumentUid string) ([]*domain.Transaction, error) {
+	var entityTransactions []entity.Transaction
+
+	query := r.db.WithContext(ctx).
+		Where("portfolio_id = ? AND type IN ?", portfolioID,
+			[]models.TransactionType{models.TransactionTypeBuy, models.TransactionTypeSell})
+
+	if instrumentUid != "" {
+		query = query.Where("instrument_uid = ?", instrumentUid)
+	}
+
+	if err := query.Order("executed_at, created_at").Find(&entityTransactions).Error; err != nil {
+		return nil, err
+	}
+
+	transactions := mappers.FromTransactionEntityToDomainSlice(entityTransactions)
+
+	if err := r.attachLots(ctx, transactions); err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+// Получить выплаты дивидендов и купонов за период в хронологическом порядке из БД
+func (r *transactionsRepository) GetIncome(ctx context.Context, portfolioID string, from, to *time.Time) ([]*domain.Transaction, error) {
+	var entityTransactions []entity.Transaction
+
+	err := r.portfolioScope(ctx, portfolioID, &domain.TransactionFilter{From: from, To: to}).
+		Where("type IN ?", []models.TransactionType{models.TransactionTypeDividend, models.TransactionTypeCoupon}).
+		Order("executed_at, created_at").
+		Find(&entityTransactions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return mappers.FromTransactionEntityToDomainSlice(entityTransactions), nil
+}
+
+// Получить операции нескольких портфелей в хронологическом порядке из БД
+func (r *transactionsRepository) GetByPortfolios(ctx context.Context, portfolioIDs []string) ([]*domain.Transaction, error) {
+	if len(portfolioIDs) == 0 {
+		return []*domain.Transaction{}, nil
+	}
+
+	var entityTransactions []entity.Transaction
+
+	err := r.db.WithContext(ctx).
+		Where("portfolio_id IN ?", portfolioIDs).
+		Order("executed_at, created_at").
+		Find(&entityTransactions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return mappers.FromTransactionEntityToDomainSlice(entityTransactions), nil
+}
+
+// Получить инструменты, по которым в портфеле были сделки, из БД
+func (r *transactionsRepository) GetTradedInstrumentUids(ctx context.Context, portfolioID string) ([]string, error) {
+	var uids []string
+
+	err := r.db.WithContext(ctx).
+		Model(&entity.Transaction{}).
+		Where("portfolio_id = ? AND type IN ?", portfolioID,
+			[]models.TransactionType{models.TransactionTypeBuy, models.TransactionTypeSell}).
+		Distinct().
+		Pluck("instrument_uid", &uids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return uids, nil
+}
+
+// Обновить операцию в БД
+func (r *transactionsRepository) Update(ctx context.Context, transaction *domain.Transaction) error {
+	entityTransaction := mappers.FromTransactionDomainToEntity(transaction)
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&entityTransaction).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&entity.TransactionLot{}, "sell_transaction_id = ?", transaction.ID).Error; err != nil {
+			return err
+		}
+
+		return saveLots(tx, transaction.ID, transaction.Lots)
+	})
+	if err != nil {
+		return err
+	}
+
+	transaction.UpdatedAt = entityTransaction.UpdatedAt
+
+	return nil
+}
+
+// Удаление операции портфеля вместе с выбором партий из БД
+func (r *transactionsRepository) Delete(ctx context.Context, portfolioID, id string) (bool, error) {
+	var deleted bool
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&entity.Transaction{}, "portfolio_id = ? AND id = ?", portfolioID, id)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		deleted = result.RowsAffected > 0
+		if !deleted {
+			return nil
+		}
+
+		return tx.Delete(&entity.TransactionLot{}, "sell_transaction_id = ? OR buy_transaction_id = ?", id, id).Error
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return deleted, nil
+}
+
+// Добавление к продажам выбранных партий из БД
+func (r *transactionsRepository) attachLots(ctx context.Context, transactions []*domain.Transaction) error {
+	sellIDs := make([]string, 0)
+	byID := make(map[string]*domain.Transaction)
+
+	for _, transaction := range transactions {
+		if transaction.Type == models.TransactionTypeSell {
+			sellIDs = append(sellIDs, transaction.ID)
+			byID[transaction.ID] = transaction
+		}
+	}
+
+	if len(sellIDs) == 0 {
+		return nil
+	}
+
+	var lots []entity.TransactionLot
+
+	err := r.db.WithContext(ctx).
+		Where("sell_transaction_id IN ?", sellIDs).
+		Find(&lots).Error
+	if err != nil {
+		return err
+	}
+
+	for _, lot := range lots {
+		transaction := byID[lot.SellTransactionID]
+		transaction.Lots = append(transaction.Lots, domain.LotSelection{
+			TransactionID: lot.BuyTransactionID,
+			Quantity:      lot.Quantity,
+		})
+	}
+
+	return nil
+}
+
+// Сохранение выбранных для продажи партий
+func saveLots(tx *gorm.DB, sellTransactionID string, selections []domain.LotSelection) error {
+	if len(selections) == 0 {
+		return nil
+	}
+
+	lots := make([]entity.TransactionLot, len(selections))
+	for index, selection := range selections {
+		lots[index] = entity.TransactionLot{
+			SellTransactionID: sellTransactionID,
+			BuyTransactionID:  selection.TransactionID,
+			Quantity:          selection.Quantity,
+		}
+	}
+
+	return tx.Create(&lots).Error
+}
+
+// Базовый запрос операций портфеля с фильтром
+func (r *transactionsRepository) portfolioScope(ctx context.Context, portfolioID string, filter *domain.TransactionFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).
+		Model(&entity.Transaction{}).
+		Where("portfolio_id = ?", portfolioID)
+
+	return applyTransactionFilter(query, filter)
+}
+
+// Условия фильтра операций
+func applyTransactionFilter(query *gorm.DB, filter *domain.TransactionFilter) *gorm.DB {
+	if filter == nil {
+		return query
+	}
+
+	if filter.InstrumentUid != "" {
+		query = query.Where("instrument_uid = ?", filter.InstrumentUid)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.From != nil {
+		query = query.Where("executed_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("executed_at < ?", *filter.To)
+	}
+
+	return query
+}
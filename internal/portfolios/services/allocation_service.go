@@ -0,0 +1,398 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	assetsDomain "invest-mate/internal/assets/models/domain"
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/internal/portfolios/repository"
+	sharedModels "invest-mate/internal/shared/models"
+	"invest-mate/pkg/logger"
+)
+
+// Источник последних цен инструментов (реализуется хранилищем модуля активов)
+type LastPriceSource interface {
+	GetLastPrices(ctx context.Context, uids []string) (map[string]float64, error)
+}
+
+type AllocationService interface {
+	GetTargets(ctx context.Context, userID, portfolioID string) ([]*domain.TargetAllocation, error)
+	SetTargets(ctx context.Context, userID, portfolioID string, req *domain.SetTargetAllocationsRequest) ([]*domain.TargetAllocation, error)
+	DeleteTargets(ctx context.Context, userID, portfolioID string) (bool, error)
+	GetRebalancePlan(ctx context.Context, userID, portfolioID, currency string, cash float64) (*domain.RebalancePlan, error)
+}
+
+type allocationService struct {
+	portfoliosService PortfoliosService
+	allocationsRepo   repository.AllocationsRepository
+	valuationService  ValuationService
+	instruments       InstrumentResolver
+	prices            LastPriceSource
+	rates             ExchangeRateSource
+}
+
+// Создание нового сервиса целевого распределения и ребалансировки
+func NewAllocationService(
+	portfoliosService PortfoliosService,
+	allocationsRepo repository.AllocationsRepository,
+	valuationService ValuationService,
+	instruments InstrumentResolver,
+	prices LastPriceSource,
+	rates ExchangeRateSource,
+) AllocationService {
+	return &allocationService{
+		portfoliosService: portfoliosService,
+		allocationsRepo:   allocationsRepo,
+		valuationService:  valuationService,
+		instruments:       instruments,
+		prices:            prices,
+		rates:             rates,
+	}
+}
+
+// Получение целевого распределения портфеля
+func (s *allocationService) GetTargets(ctx context.Context, userID, portfolioID string) ([]*domain.TargetAllocation, error) {
+	if _, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID); err != nil {
+		return nil, err
+	}
+
+	targets, err := s.allocationsRepo.GetByPortfolio(ctx, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.fillTickers(ctx, targets)
+
+	return targets, nil
+}
+
+// Замена целевого распределения портфеля
+func (s *allocationService) SetTargets(ctx context.Context, userID, portfolioID string, req *domain.SetTargetAllocationsRequest) ([]*domain.TargetAllocation, error) {
+	for _, target := range req.Targets {
+		if target != nil {
+			target.Dimension = models.AllocationDimension(strings.ToUpper(strings.TrimSpace(string(target.Dimension))))
+		}
+	}
+
+	if err := validateTargetAllocations(req.Targets); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(req.Targets))
+	for _, target := range req.Targets {
+		if err := s.normalizeTarget(ctx, target); err != nil {
+			return nil, err
+		}
+
+		if seen[target.Key] {
+			return nil, fmt.Errorf("%w: duplicate target %s", models.ErrInvalidRequest, target.Key)
+		}
+		seen[target.Key] = true
+	}
+
+	if err := s.allocationsRepo.Replace(ctx, portfolioID, req.Targets); err != nil {
+		return nil, err
+	}
+
+	logger.InfoLog("Target allocation of portfolio %s set: %d targets", portfolioID, len(req.Targets))
+
+	return req.Targets, nil
+}
+
+// Удаление целевого распределения портфеля
+func (s *allocationService) DeleteTargets(ctx context.Context, userID, portfolioID string) (bool, error) {
+	if _, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID); err != nil {
+		return false, err
+	}
+
+	deleted, err := s.allocationsRepo.DeleteByPortfolio(ctx, portfolioID)
+	if err != nil {
+		return false, err
+	}
+
+	if !deleted {
+		return false, models.ErrTargetsNotFound
+	}
+
+	return true, nil
+}
+
+// Расчёт отклонений от целевого распределения и заявок для его восстановления.
+// cash — сумма в базовой валюте, которую нужно довложить (отрицательная — вывести)
+func (s *allocationService) GetRebalancePlan(ctx context.Context, userID, portfolioID, currency string, cash float64) (*domain.RebalancePlan, error) {
+	portfolio, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := s.allocationsRepo.GetByPortfolio(ctx, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(targets) == 0 {
+		return nil, models.ErrTargetsNotFound
+	}
+
+	rates, err := s.rates.GetExchangeRates(ctx)
+	if err != nil {
+		logger.ErrorLog("Failed to load exchange rates: %v", err)
+		return nil, models.ErrMarketDataUnavailable
+	}
+
+	valuation, err := s.valuationService.Valuate(ctx, portfolio, currency, rates)
+	if err != nil {
+		return nil, err
+	}
+
+	total := valuation.TotalValue + cash
+	if total <= 0 {
+		return nil, fmt.Errorf("%w: portfolio has no value to rebalance", models.ErrInvalidRequest)
+	}
+
+	dimension := targets[0].Dimension
+
+	plan := &domain.RebalancePlan{
+		PortfolioID: portfolioID,
+		Currency:    valuation.Currency,
+		Dimension:   dimension,
+		TotalValue:  total,
+		Cash:        cash,
+		Drift:       make([]*domain.AllocationDrift, 0, len(targets)),
+		Unpriced:    append(make([]string, 0), valuation.Unvalued...),
+	}
+
+	holdings, order := aggregateHoldings(valuation)
+
+	uids := append(make([]string, 0, len(order)+len(targets)), order...)
+	if dimension == models.AllocationDimensionInstrument {
+		for _, target := range targets {
+			if _, ok := holdings[target.Key]; !ok {
+				uids = append(uids, target.Key)
+			}
+		}
+	}
+
+	instruments, err := s.instruments.GetInstrumentsByUids(ctx, uids)
+	if err != nil {
+		return nil, err
+	}
+
+	lastPrices, err := s.prices.GetLastPrices(ctx, uids)
+	if err != nil {
+		// Без рыночных цен заявки считаются по текущим ценам позиций
+		logger.ErrorLog("Failed to load last prices for rebalancing: %v", err)
+	}
+
+	groups := make([]*rebalanceGroup, 0, len(targets))
+	byKey := make(map[string]*rebalanceGroup, len(targets))
+
+	for _, target := range targets {
+		group := &rebalanceGroup{
+			drift: &domain.AllocationDrift{
+				Key:           target.Key,
+				TargetPercent: target.WeightPercent,
+			},
+		}
+
+		groups = append(groups, group)
+		byKey[target.Key] = group
+	}
+
+	for _, uid := range uids {
+		instrument, ok := instruments[uid]
+		if !ok {
+			if holding, held := holdings[uid]; held {
+				plan.Unpriced = append(plan.Unpriced, firstNonEmpty(holding.ticker, uid))
+			} else {
+				plan.Unpriced = append(plan.Unpriced, uid)
+			}
+			continue
+		}
+
+		holding, held := holdings[uid]
+		if !held {
+			holding = &rebalanceHolding{uid: uid}
+		}
+
+		if !completeHolding(holding, instrument, lastPrices[uid], rates, valuation.Currency) {
+			plan.Unpriced = append(plan.Unpriced, firstNonEmpty(holding.ticker, uid))
+			continue
+		}
+
+		key := allocationKey(dimension, instrument)
+
+		// Бумаги вне целевого распределения должны быть проданы
+		group, ok := byKey[key]
+		if !ok {
+			group = &rebalanceGroup{drift: &domain.AllocationDrift{Key: key}}
+			groups = append(groups, group)
+			byKey[key] = group
+		}
+
+		if dimension == models.AllocationDimensionInstrument {
+			group.drift.Ticker = instrument.Ticker
+		}
+
+		group.holdings = append(group.holdings, holding)
+	}
+
+	orders, cashFlow := buildRebalanceOrders(groups, total)
+
+	for _, group := range groups {
+		plan.Drift = append(plan.Drift, group.drift)
+	}
+
+	plan.Orders = orders
+	plan.Unfilled = unfilledGroups(groups, orders)
+	plan.Residual = cash + cashFlow
+
+	return plan, nil
+}
+
+// Приведение ключа цели к хранимому виду: uid инструмента, сектор, тип или валюта
+func (s *allocationService) normalizeTarget(ctx context.Context, target *domain.TargetAllocation) error {
+	key := strings.TrimSpace(target.Key)
+
+	switch target.Dimension {
+	case models.AllocationDimensionInstrument:
+		instrument, err := resolveInstrument(ctx, s.instruments, key, strings.ToUpper(key), strings.ToUpper(key))
+		if err != nil {
+			return err
+		}
+
+		target.Key = instrument.Uid
+		target.Ticker = instrument.Ticker
+	case models.AllocationDimensionSector:
+		target.Key = strings.ToLower(key)
+	case models.AllocationDimensionInstrumentType:
+		target.Key = strings.ToUpper(key)
+
+		switch sharedModels.InstrumentType(target.Key) {
+		case sharedModels.InstrumentTypeBond, sharedModels.InstrumentTypeShare, sharedModels.InstrumentTypeETF, sharedModels.InstrumentTypeCurrency:
+		default:
+			return fmt.Errorf("%w: instrument type must be BOND, SHARE, ETF or CURRENCY", models.ErrInvalidRequest)
+		}
+	case models.AllocationDimensionCurrency:
+		if err := validatePortfolioCurrency(key); err != nil {
+			return err
+		}
+
+		target.Key = strings.ToUpper(key)
+	}
+
+	return nil
+}
+
+// Заполнение тикеров для целей по инструментам
+func (s *allocationService) fillTickers(ctx context.Context, targets []*domain.TargetAllocation) {
+	uids := make([]string, 0, len(targets))
+	for _, target := range targets {
+		if target.Dimension == models.AllocationDimensionInstrument {
+			uids = append(uids, target.Key)
+		}
+	}
+
+	if len(uids) == 0 {
+		return
+	}
+
+	instruments, err := s.instruments.GetInstrumentsByUids(ctx, uids)
+	if err != nil {
+		logger.ErrorLog("Failed to load instruments for target allocation: %v", err)
+		return
+	}
+
+	for _, target := range targets {
+		if instrument, ok := instruments[target.Key]; ok {
+			target.Ticker = instrument.Ticker
+		}
+	}
+}
+
+// Сведение оценённых позиций дерева портфелей по инструменту
+func aggregateHoldings(valuation *domain.PortfolioValuation) (map[string]*rebalanceHolding, []string) {
+	holdings := make(map[string]*rebalanceHolding)
+	order := make([]string, 0, len(valuation.Positions))
+
+	for _, position := range valuation.Positions {
+		holding, ok := holdings[position.InstrumentUid]
+		if !ok {
+			holding = &rebalanceHolding{
+				uid:      position.InstrumentUid,
+				ticker:   position.Ticker,
+				currency: position.Currency,
+				rate:     position.Rate,
+			}
+			holdings[position.InstrumentUid] = holding
+			order = append(order, position.InstrumentUid)
+		}
+
+		holding.quantity += position.Quantity
+		holding.value += position.ValueBase
+		// Пока нет рыночной цены, цена бумаги — по оценке позиций
+		holding.price += position.Value
+	}
+
+	for _, holding := range holdings {
+		if holding.quantity > 0 {
+			holding.price /= float64(holding.quantity)
+		} else {
+			holding.price = 0
+		}
+	}
+
+	return holdings, order
+}
+
+// Дополнение бумаги параметрами инструмента и рыночной ценой; false — цену определить нельзя
+func completeHolding(holding *rebalanceHolding, instrument assetsDomain.Instrument, lastPrice float64, rates map[string]float64, base string) bool {
+	holding.ticker = firstNonEmpty(holding.ticker, instrument.Ticker)
+	holding.currency = firstNonEmpty(holding.currency, strings.ToUpper(instrument.Currency))
+	holding.lot = instrument.Lot
+	holding.increment = instrument.MinPriceIncrement
+
+	isBond := instrument.InstrumentType == sharedModels.InstrumentTypeBond
+	if isBond {
+		holding.nominal = instrument.Nominal
+	}
+
+	if lastPrice > 0 {
+		holding.price = lastPrice
+		// Цена облигации котируется в процентах номинала
+		if isBond && instrument.Nominal > 0 {
+			holding.price = lastPrice * instrument.Nominal / 100
+		}
+	}
+
+	if holding.rate == 0 {
+		rate, ok := rates[holding.currency]
+		if !ok || rates[base] == 0 {
+			return false
+		}
+		holding.rate = rate / rates[base]
+	}
+
+	return holding.price > 0
+}
+
+// Ключ группы инструмента в измерении целевого распределения
+func allocationKey(dimension models.AllocationDimension, instrument assetsDomain.Instrument) string {
+	switch dimension {
+	case models.AllocationDimensionSector:
+		return strings.ToLower(instrument.Sector)
+	case models.AllocationDimensionInstrumentType:
+		return string(instrument.InstrumentType)
+	case models.AllocationDimensionCurrency:
+		return strings.ToUpper(instrument.Currency)
+	default:
+		return instrument.Uid
+	}
+}
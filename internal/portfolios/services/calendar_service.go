@@ -0,0 +1,270 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	assetsDomain "invest-mate/internal/assets/models/domain"
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/internal/portfolios/repository"
+	sharedModels "invest-mate/internal/shared/models"
+	"invest-mate/pkg/logger"
+)
+
+// Период календаря по умолчанию в днях
+const defaultCalendarDays = 90
+
+// Источник графиков выплат инструментов (реализуется хранилищем модуля активов)
+type PayoutScheduleSource interface {
+	GetDividends(ctx context.Context, instrumentUid string, refresh bool) ([]assetsDomain.Dividend, error)
+	GetBondCoupons(ctx context.Context, instrumentUid string, refresh bool) ([]assetsDomain.BondCoupon, error)
+	GetBondEvents(ctx context.Context, instrumentUid string, refresh bool) ([]assetsDomain.BondEvent, error)
+}
+
+type CalendarService interface {
+	GetCalendar(ctx context.Context, userID, portfolioID string, from, to *time.Time) (*domain.EventCalendar, error)
+}
+
+type calendarService struct {
+	portfoliosService PortfoliosService
+	compositeService  CompositeService
+	positionsRepo     repository.PositionsRepository
+	instruments       InstrumentResolver
+	payouts           PayoutScheduleSource
+}
+
+// Создание нового сервиса календаря выплат
+func NewCalendarService(
+	portfoliosService PortfoliosService,
+	compositeService CompositeService,
+	positionsRepo repository.PositionsRepository,
+	instruments InstrumentResolver,
+	payouts PayoutScheduleSource,
+) CalendarService {
+	return &calendarService{
+		portfoliosService: portfoliosService,
+		compositeService:  compositeService,
+		positionsRepo:     positionsRepo,
+		instruments:       instruments,
+		payouts:           payouts,
+	}
+}
+
+// Календарь дивидендов, купонов и событий облигаций по бумагам пользователя
+// (всех портфелей или одного портфеля с вложенными)
+func (s *calendarService) GetCalendar(ctx context.Context, userID, portfolioID string, from, to *time.Time) (*domain.EventCalendar, error) {
+	start := snapshotDate(time.Now())
+	if from != nil {
+		start = *from
+	}
+
+	end := start.AddDate(0, 0, defaultCalendarDays)
+	if to != nil {
+		end = *to
+	}
+
+	if start.After(end) {
+		return nil, fmt.Errorf("%w: from must be before to", models.ErrInvalidRequest)
+	}
+
+	portfolioIDs, err := s.portfolioIDs(ctx, userID, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	positions, err := s.positionsRepo.GetByPortfolios(ctx, portfolioIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	holdings, uids := holdingsByInstrument(positions)
+
+	calendar := &domain.EventCalendar{
+		PortfolioID: portfolioID,
+		From:        start,
+		To:          end,
+		Events:      make([]*domain.CalendarEvent, 0),
+		Totals:      make(map[string]float64),
+		Unavailable: make([]string, 0),
+	}
+
+	if len(uids) == 0 {
+		return calendar, nil
+	}
+
+	instruments, err := s.instruments.GetInstrumentsByUids(ctx, uids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, uid := range uids {
+		instrument, ok := instruments[uid]
+		if !ok {
+			continue
+		}
+
+		events, err := s.instrumentEvents(ctx, instrument)
+		if err != nil {
+			// Недоступный график одной бумаги не мешает построить календарь
+			logger.ErrorLog("Failed to load payouts of %s: %v", instrument.Ticker, err)
+			calendar.Unavailable = append(calendar.Unavailable, instrument.Ticker)
+			continue
+		}
+
+		holding := holdings[uid]
+
+		for _, event := range events {
+			if event.Date.Before(start) || event.Date.After(end) {
+				continue
+			}
+
+			event.InstrumentUid = uid
+			event.Ticker = instrument.Ticker
+			event.Name = instrument.Name
+			event.Quantity = holding.quantity
+			event.ExpectedAmount = event.AmountPerUnit * float64(holding.quantity)
+			event.PortfolioIDs = holding.portfolioIDs
+
+			calendar.Events = append(calendar.Events, event)
+
+			if event.Currency != "" {
+				calendar.Totals[event.Currency] += event.ExpectedAmount
+			}
+		}
+	}
+
+	sort.SliceStable(calendar.Events, func(i, j int) bool {
+		if calendar.Events[i].Date.Equal(calendar.Events[j].Date) {
+			return calendar.Events[i].Ticker < calendar.Events[j].Ticker
+		}
+		return calendar.Events[i].Date.Before(calendar.Events[j].Date)
+	})
+
+	return calendar, nil
+}
+
+// Портфели календаря: дерево указанного портфеля или все портфели пользователя
+func (s *calendarService) portfolioIDs(ctx context.Context, userID, portfolioID string) ([]string, error) {
+	if portfolioID != "" {
+		if _, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID); err != nil {
+			return nil, err
+		}
+
+		return s.compositeService.GetTreePortfolioIDs(ctx, portfolioID)
+	}
+
+	portfolios, _, err := s.portfoliosService.GetPortfolios(ctx, userID, true, 1, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(portfolios))
+	for _, portfolio := range portfolios {
+		ids = append(ids, portfolio.ID)
+	}
+
+	return ids, nil
+}
+
+// Выплаты и события инструмента по его типу
+func (s *calendarService) instrumentEvents(ctx context.Context, instrument assetsDomain.Instrument) ([]*domain.CalendarEvent, error) {
+	events := make([]*domain.CalendarEvent, 0)
+
+	switch instrument.InstrumentType {
+	case sharedModels.InstrumentTypeShare, sharedModels.InstrumentTypeETF:
+		dividends, err := s.payouts.GetDividends(ctx, instrument.Uid, false)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, dividend := range dividends {
+			date := dividend.PaymentDate
+			if date == nil {
+				date = dividend.RecordDate
+			}
+			if date == nil {
+				continue
+			}
+
+			events = append(events, &domain.CalendarEvent{
+				Date:          *date,
+				Type:          models.CalendarEventTypeDividend,
+				DeclaredDate:  dividend.DeclaredDate,
+				RecordDate:    dividend.RecordDate,
+				AmountPerUnit: dividend.AmountPerUnit,
+				Currency:      dividend.Currency,
+			})
+		}
+	case sharedModels.InstrumentTypeBond:
+		coupons, err := s.payouts.GetBondCoupons(ctx, instrument.Uid, false)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, coupon := range coupons {
+			events = append(events, &domain.CalendarEvent{
+				Date:          coupon.CouponDate,
+				Type:          models.CalendarEventTypeCoupon,
+				RecordDate:    coupon.FixDate,
+				AmountPerUnit: coupon.PayOneBond,
+				Currency:      firstNonEmpty(coupon.Currency, strings.ToUpper(instrument.Currency)),
+			})
+		}
+
+		bondEvents, err := s.payouts.GetBondEvents(ctx, instrument.Uid, false)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, bondEvent := range bondEvents {
+			date := bondEvent.EventDate
+			if bondEvent.PayDate != nil {
+				date = *bondEvent.PayDate
+			}
+
+			events = append(events, &domain.CalendarEvent{
+				Date:          date,
+				Type:          models.CalendarEventType(bondEvent.EventType),
+				RecordDate:    bondEvent.FixDate,
+				AmountPerUnit: bondEvent.PayOneBond,
+				Currency:      firstNonEmpty(bondEvent.Currency, strings.ToUpper(instrument.Currency)),
+			})
+		}
+	}
+
+	return events, nil
+}
+
+// Бумаги пользователя, сведённые по инструменту
+type calendarHolding struct {
+	quantity     int32
+	portfolioIDs []string
+}
+
+// Сведение позиций по инструменту с сохранением порядка
+func holdingsByInstrument(positions []*domain.Position) (map[string]*calendarHolding, []string) {
+	holdings := make(map[string]*calendarHolding)
+	uids := make([]string, 0, len(positions))
+
+	for _, position := range positions {
+		if position.Quantity <= 0 {
+			continue
+		}
+
+		holding, ok := holdings[position.InstrumentUid]
+		if !ok {
+			holding = &calendarHolding{portfolioIDs: make([]string, 0, 1)}
+			holdings[position.InstrumentUid] = holding
+			uids = append(uids, position.InstrumentUid)
+		}
+
+		holding.quantity += position.Quantity
+		holding.portfolioIDs = append(holding.portfolioIDs, position.PortfolioID)
+	}
+
+	return holdings, uids
+}
@@ -0,0 +1,312 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/internal/portfolios/repository"
+	"invest-mate/pkg/logger"
+)
+
+type CompositeService interface {
+	GetChildren(ctx context.Context, userID, parentID string) ([]*domain.Portfolio, error)
+	AttachChild(ctx context.Context, userID, parentID, childID string) ([]*domain.Portfolio, error)
+	DetachChild(ctx context.Context, userID, parentID, childID string) (bool, error)
+	GetAggregate(ctx context.Context, userID, portfolioID string) (*domain.PortfolioAggregate, error)
+	GetTreePortfolioIDs(ctx context.Context, rootID string) ([]string, error)
+}
+
+type compositeService struct {
+	portfoliosService PortfoliosService
+	hierarchyRepo     repository.HierarchyRepository
+	positionsRepo     repository.PositionsRepository
+	instruments       InstrumentResolver
+	rates             ExchangeRateSource
+}
+
+// Создание нового сервиса составных портфелей
+func NewCompositeService(
+	portfoliosService PortfoliosService,
+	hierarchyRepo repository.HierarchyRepository,
+	positionsRepo repository.PositionsRepository,
+	instruments InstrumentResolver,
+	rates ExchangeRateSource,
+) CompositeService {
+	return &compositeService{
+		portfoliosService: portfoliosService,
+		hierarchyRepo:     hierarchyRepo,
+		positionsRepo:     positionsRepo,
+		instruments:       instruments,
+		rates:             rates,
+	}
+}
+
+// Получение дочерних портфелей
+func (s *compositeService) GetChildren(ctx context.Context, userID, parentID string) ([]*domain.Portfolio, error) {
+	parent, err := s.portfoliosService.GetPortfolio(ctx, userID, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !parent.IsComposite {
+		return nil, models.ErrNotCompositePortfolio
+	}
+
+	childIDs, err := s.hierarchyRepo.GetChildIDs(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	children := make([]*domain.Portfolio, 0, len(childIDs))
+	for _, childID := range childIDs {
+		child, err := s.portfoliosService.GetPortfolio(ctx, userID, childID)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+	}
+
+	return children, nil
+}
+
+// Добавление дочернего портфеля в составной
+func (s *compositeService) AttachChild(ctx context.Context, userID, parentID, childID string) ([]*domain.Portfolio, error) {
+	if childID == "" {
+		return nil, fmt.Errorf("%w: childId is required", models.ErrInvalidRequest)
+	}
+
+	parent, err := s.portfoliosService.GetPortfolio(ctx, userID, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !parent.IsComposite {
+		return nil, models.ErrNotCompositePortfolio
+	}
+
+	// Проверка владельца дочернего портфеля исключает связи между пользователями
+	if _, err := s.portfoliosService.GetPortfolio(ctx, userID, childID); err != nil {
+		return nil, err
+	}
+
+	if parentID == childID {
+		return nil, models.ErrHierarchyCycle
+	}
+
+	descendants, err := s.GetTreePortfolioIDs(ctx, childID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range descendants {
+		if id == parentID {
+			return nil, models.ErrHierarchyCycle
+		}
+	}
+
+	if err := s.hierarchyRepo.AddChild(ctx, parentID, childID); err != nil {
+		return nil, err
+	}
+
+	logger.InfoLog("Portfolio %s attached to composite portfolio %s", childID, parentID)
+
+	return s.GetChildren(ctx, userID, parentID)
+}
+
+// Исключение дочернего портфеля из составного
+func (s *compositeService) DetachChild(ctx context.Context, userID, parentID, childID string) (bool, error) {
+	if _, err := s.portfoliosService.GetPortfolio(ctx, userID, parentID); err != nil {
+		return false, err
+	}
+
+	removed, err := s.hierarchyRepo.RemoveChild(ctx, parentID, childID)
+	if err != nil {
+		return false, err
+	}
+
+	if !removed {
+		return false, models.ErrHierarchyLinkNotFound
+	}
+
+	return true, nil
+}
+
+// Получение сводных позиций, стоимости и доходности по дереву портфелей
+func (s *compositeService) GetAggregate(ctx context.Context, userID, portfolioID string) (*domain.PortfolioAggregate, error) {
+	portfolio, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	portfolioIDs, err := s.GetTreePortfolioIDs(ctx, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	positions, err := s.positionsRepo.GetByPortfolios(ctx, portfolioIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	rates, err := s.rates.GetExchangeRates(ctx)
+	if err != nil {
+		logger.ErrorLog("Failed to load exchange rates: %v", err)
+		return nil, models.ErrMarketDataUnavailable
+	}
+
+	base := strings.ToUpper(portfolio.Currency)
+
+	baseRate, ok := rates[base]
+	if !ok {
+		return nil, fmt.Errorf("%w %s", models.ErrExchangeRateNotFound, base)
+	}
+
+	aggregated := aggregatePositions(positions)
+	s.attachAggregatedInstruments(ctx, aggregated)
+
+	aggregate := &domain.PortfolioAggregate{
+		Portfolio:    portfolio,
+		PortfolioIDs: portfolioIDs,
+		Positions:    aggregated,
+		Currency:     base,
+		Unvalued:     make([]string, 0),
+	}
+
+	// Портфели дерева могут держать бумаги в разных валютах, поэтому итоги
+	// считаются в валюте портфеля по курсу к рублю; без валюты или курса позиция не оценивается
+	for _, position := range aggregated {
+		rate, ok := rates[position.Currency]
+		if position.Currency == "" || !ok {
+			aggregate.Unvalued = append(aggregate.Unvalued, firstNonEmpty(position.Ticker, position.InstrumentUid))
+			continue
+		}
+
+		rate /= baseRate
+		aggregate.InvestedAmount += position.InvestedAmount * rate
+		aggregate.TotalValue += position.Value * rate
+	}
+
+	aggregate.ExpectedYield = aggregate.TotalValue - aggregate.InvestedAmount
+	aggregate.YieldPercent = yieldPercent(aggregate.ExpectedYield, aggregate.InvestedAmount)
+
+	return aggregate, nil
+}
+
+// Получение идентификаторов всех портфелей дерева, включая корень
+func (s *compositeService) GetTreePortfolioIDs(ctx context.Context, rootID string) ([]string, error) {
+	visited := map[string]bool{rootID: true}
+	result := []string{rootID}
+	queue := []string{rootID}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		childIDs, err := s.hierarchyRepo.GetChildIDs(ctx, current)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, childID := range childIDs {
+			if visited[childID] {
+				continue
+			}
+
+			visited[childID] = true
+			result = append(result, childID)
+			queue = append(queue, childID)
+		}
+	}
+
+	return result, nil
+}
+
+// Добавление сведений об инструментах и валюты к сводным позициям
+func (s *compositeService) attachAggregatedInstruments(ctx context.Context, positions []*domain.AggregatedPosition) {
+	uids := make([]string, 0, len(positions))
+	for _, position := range positions {
+		uids = append(uids, position.InstrumentUid)
+	}
+
+	instruments, err := s.instruments.GetInstrumentsByUids(ctx, uids)
+	if err != nil {
+		logger.ErrorLog("Failed to load instruments for aggregate: %v", err)
+		return
+	}
+
+	for _, position := range positions {
+		if instrument, ok := instruments[position.InstrumentUid]; ok {
+			position.Instrument = &instrument
+			position.Currency = strings.ToUpper(instrument.Currency)
+		}
+	}
+}
+
+// Сведение позиций по инструменту
+func aggregatePositions(positions []*domain.Position) []*domain.AggregatedPosition {
+	byInstrument := make(map[string]*domain.AggregatedPosition)
+	order := make([]string, 0, len(positions))
+
+	for _, position := range positions {
+		aggregated, ok := byInstrument[position.InstrumentUid]
+		if !ok {
+			aggregated = &domain.AggregatedPosition{
+				InstrumentUid: position.InstrumentUid,
+				Figi:          position.Figi,
+				Ticker:        position.Ticker,
+				CurrentPrice:  position.CurrentPrice,
+				CurrentNkd:    position.CurrentNkd,
+			}
+			byInstrument[position.InstrumentUid] = aggregated
+			order = append(order, position.InstrumentUid)
+		}
+
+		aggregated.Quantity += position.Quantity
+		aggregated.InvestedAmount += positionInvestedAmount(position)
+		aggregated.Value += positionValue(position)
+		aggregated.PortfolioIDs = append(aggregated.PortfolioIDs, position.PortfolioID)
+	}
+
+	result := make([]*domain.AggregatedPosition, 0, len(order))
+	for _, uid := range order {
+		aggregated := byInstrument[uid]
+
+		if aggregated.Quantity > 0 {
+			aggregated.AveragePositionPrice = aggregated.InvestedAmount / float64(aggregated.Quantity)
+		}
+
+		aggregated.ExpectedYield = aggregated.Value - aggregated.InvestedAmount
+		aggregated.YieldPercent = yieldPercent(aggregated.ExpectedYield, aggregated.InvestedAmount)
+
+		result = append(result, aggregated)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Ticker < result[j].Ticker
+	})
+
+	return result
+}
+
+// Текущая стоимость позиции с учётом НКД
+func positionValue(position *domain.Position) float64 {
+	return float64(position.Quantity) * (position.CurrentPrice + position.CurrentNkd)
+}
+
+// Вложенная в позицию сумма
+func positionInvestedAmount(position *domain.Position) float64 {
+	return float64(position.Quantity) * position.AveragePositionPrice
+}
+
+// Доходность в процентах
+func yieldPercent(yield, invested float64) float64 {
+	if invested == 0 {
+		return 0
+	}
+
+	return yield / invested * 100
+}
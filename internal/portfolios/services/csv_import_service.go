@@ -0,0 +1,792 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	assetsEntity "invest-mate/internal/assets/models/entity"
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/internal/portfolios/repository"
+	"invest-mate/pkg/logger"
+)
+
+// Наибольшее число строк в одной выписке
+const maxCsvImportRows = 10000
+
+// Форматы дат по умолчанию
+var csvDateFormats = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"02.01.2006 15:04:05",
+	"02.01.2006 15:04",
+	"02.01.2006",
+}
+
+// Стандартные значения колонки типа операции (в нижнем регистре)
+var csvTransactionTypes = map[string]models.TransactionType{
+	"buy":             models.TransactionTypeBuy,
+	"покупка":         models.TransactionTypeBuy,
+	"sell":            models.TransactionTypeSell,
+	"продажа":         models.TransactionTypeSell,
+	"dividend":        models.TransactionTypeDividend,
+	"дивиденд":        models.TransactionTypeDividend,
+	"дивиденды":       models.TransactionTypeDividend,
+	"coupon":          models.TransactionTypeCoupon,
+	"купон":           models.TransactionTypeCoupon,
+	"выплата купонов": models.TransactionTypeCoupon,
+	"fee":             models.TransactionTypeFee,
+	"commission":      models.TransactionTypeFee,
+	"комиссия":        models.TransactionTypeFee,
+	"tax":             models.TransactionTypeTax,
+	"налог":           models.TransactionTypeTax,
+	"deposit":         models.TransactionTypeDeposit,
+	"пополнение":      models.TransactionTypeDeposit,
+	"ввод денежных средств": models.TransactionTypeDeposit,
+	"withdrawal": models.TransactionTypeWithdrawal,
+	"вывод":      models.TransactionTypeWithdrawal,
+	"вывод денежных средств": models.TransactionTypeWithdrawal,
+}
+
+// Справочник инструментов по полю (реализуется репозиторием модуля активов)
+type AssetLookup interface {
+	GetBondByField(ctx context.Context, fieldName string, fieldValue string) (*assetsEntity.Bond, error)
+	GetShareByField(ctx context.Context, fieldName string, fieldValue string) (*assetsEntity.Share, error)
+	GetEtfByField(ctx context.Context, fieldName string, fieldValue string) (*assetsEntity.Etf, error)
+	GetCurrencyByField(ctx context.Context, fieldName string, fieldValue string) (*assetsEntity.Currency, error)
+}
+
+type CsvImportService interface {
+	PreviewCsv(ctx context.Context, userID, portfolioID string, content []byte, options *domain.CsvImportOptions) (*domain.CsvImportPreview, error)
+	ImportCsv(ctx context.Context, userID, portfolioID string, content []byte, options *domain.CsvImportOptions) (*domain.CsvImportResult, error)
+}
+
+type csvImportService struct {
+	portfoliosService   PortfoliosService
+	transactionsService TransactionsService
+	transactionsRepo    repository.TransactionsRepository
+	assets              AssetLookup
+}
+
+// Создание нового сервиса загрузки выписок брокеров
+func NewCsvImportService(
+	portfoliosService PortfoliosService,
+	transactionsService TransactionsService,
+	transactionsRepo repository.TransactionsRepository,
+	assets AssetLookup,
+) CsvImportService {
+	return &csvImportService{
+		portfoliosService:   portfoliosService,
+		transactionsService: transactionsService,
+		transactionsRepo:    transactionsRepo,
+		assets:              assets,
+	}
+}
+
+// Инструмент из справочника активов
+type csvInstrument struct {
+	Uid      string
+	Figi     string
+	Ticker   string
+	Currency string
+}
+
+// Разобранная выписка: строки и операции корректных строк по номеру строки
+type csvImport struct {
+	preview      *domain.CsvImportPreview
+	transactions map[int]*domain.Transaction
+}
+
+// Разбор выписки и проверка строк без сохранения
+func (s *csvImportService) PreviewCsv(ctx context.Context, userID, portfolioID string, content []byte, options *domain.CsvImportOptions) (*domain.CsvImportPreview, error) {
+	parsed, err := s.prepare(ctx, userID, portfolioID, content, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsed.preview, nil
+}
+
+// Загрузка операций выписки в портфель и пересчёт позиций.
+// При ошибках в строках ничего не сохраняется, если не задан skipInvalid
+func (s *csvImportService) ImportCsv(ctx context.Context, userID, portfolioID string, content []byte, options *domain.CsvImportOptions) (*domain.CsvImportResult, error) {
+	parsed, err := s.prepare(ctx, userID, portfolioID, content, options)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.CsvImportResult{
+		Duplicates: parsed.preview.Duplicates,
+		Errors:     []*domain.CsvImportRow{},
+		Positions:  []*domain.Position{},
+	}
+
+	for _, row := range parsed.preview.Rows {
+		if row.Error != "" {
+			result.Errors = append(result.Errors, row)
+		}
+	}
+
+	if len(result.Errors) > 0 && !options.SkipInvalid {
+		return result, models.ErrImportRowsInvalid
+	}
+
+	transactions := make([]*domain.Transaction, 0, len(parsed.transactions))
+	for _, row := range parsed.preview.Rows {
+		if transaction, ok := parsed.transactions[row.Line]; ok {
+			transactions = append(transactions, transaction)
+		}
+	}
+
+	// Пересчёт позиций упорядочивает операции одного момента по времени создания,
+	// поэтому операции сохраняются в хронологическом порядке, а не в порядке строк
+	sortChronologically(transactions)
+
+	createdAt := time.Now()
+	for index, transaction := range transactions {
+		transaction.CreatedAt = createdAt.Add(time.Duration(index) * time.Microsecond)
+		transaction.UpdatedAt = transaction.CreatedAt
+	}
+
+	if err := s.transactionsRepo.CreateBatch(ctx, transactions); err != nil {
+		return nil, err
+	}
+
+	result.Imported = len(transactions)
+	result.Skipped = len(result.Errors)
+
+	result.Positions, err = s.transactionsService.RecalculatePositions(ctx, userID, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.InfoLog("CSV statement imported into portfolio %s: %d transactions, %d duplicates, %d skipped",
+		portfolioID, result.Imported, result.Duplicates, result.Skipped)
+
+	return result, nil
+}
+
+// Разбор и проверка выписки: формат строк, инструменты, повторы и остаток бумаг при продажах
+func (s *csvImportService) prepare(ctx context.Context, userID, portfolioID string, content []byte, options *domain.CsvImportOptions) (*csvImport, error) {
+	portfolio, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	if portfolio.IsComposite {
+		return nil, models.ErrCompositePortfolio
+	}
+
+	parser, err := newCsvRowParser(options)
+	if err != nil {
+		return nil, err
+	}
+
+	records, lines, err := readCsv(content, options.Delimiter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := parser.bindHeader(records[0]); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.transactionsRepo.GetByPortfolio(ctx, portfolioID, nil, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Сколько раз каждая операция уже сохранена в портфеле. Одинаковые сделки
+	// за день допустимы, поэтому повторами считается не больше строк, чем сохранено
+	stored := make(map[string]int, len(existing))
+	trades := make(map[string][]*domain.Transaction)
+	for _, transaction := range existing {
+		stored[transactionKey(transaction)]++
+		if transaction.Type.IsTrade() {
+			trades[transaction.InstrumentUid] = append(trades[transaction.InstrumentUid], transaction)
+		}
+	}
+
+	parsed := &csvImport{
+		preview: &domain.CsvImportPreview{
+			Rows: make([]*domain.CsvImportRow, 0, len(records)-1),
+		},
+		transactions: make(map[int]*domain.Transaction),
+	}
+
+	instruments := make(map[string]*csvInstrument)
+	rows := make(map[*domain.Transaction]*domain.CsvImportRow)
+
+	for index, record := range records[1:] {
+		if isBlankRecord(record) {
+			continue
+		}
+
+		row := parser.parse(record)
+		row.Line = lines[index+1]
+		parsed.preview.Rows = append(parsed.preview.Rows, row)
+
+		if row.Error != "" {
+			continue
+		}
+
+		transaction, err := s.buildTransaction(ctx, portfolio, row, instruments)
+		if err != nil {
+			row.Error = err.Error()
+			continue
+		}
+
+		key := transactionKey(transaction)
+		if stored[key] > 0 {
+			stored[key]--
+			row.Duplicate = true
+			continue
+		}
+
+		if transaction.Type.IsTrade() {
+			trades[transaction.InstrumentUid] = append(trades[transaction.InstrumentUid], transaction)
+			rows[transaction] = row
+		}
+
+		parsed.transactions[row.Line] = transaction
+	}
+
+	// Выписки часто идут от новых операций к старым, поэтому остаток бумаг
+	// проверяется по всем сделкам инструмента сразу в хронологическом порядке
+	for _, instrumentTrades := range trades {
+		for _, rejected := range rejectOversoldTrades(instrumentTrades, rows) {
+			row := rows[rejected]
+			row.Error = models.ErrInsufficientQuantity.Error()
+			delete(parsed.transactions, row.Line)
+		}
+	}
+
+	for _, row := range parsed.preview.Rows {
+		switch {
+		case row.Error != "":
+			parsed.preview.Invalid++
+		case row.Duplicate:
+			parsed.preview.Duplicates++
+		default:
+			parsed.preview.Valid++
+		}
+	}
+	parsed.preview.Total = len(parsed.preview.Rows)
+
+	return parsed, nil
+}
+
+// Продажи из выписки, для которых не хватает бумаг с учётом всех сделок инструмента.
+// Сделки упорядочиваются по времени, покупки одного момента идут раньше продаж
+func rejectOversoldTrades(trades []*domain.Transaction, imported map[*domain.Transaction]*domain.CsvImportRow) []*domain.Transaction {
+	ordered := make([]*domain.Transaction, len(trades))
+	copy(ordered, trades)
+	sortChronologically(ordered)
+
+	if _, err := replayTrades(ordered, models.CostMethodFifo); err == nil {
+		return nil
+	}
+
+	rejected := make([]*domain.Transaction, 0)
+	var quantity int32
+
+	for _, trade := range ordered {
+		switch trade.Type {
+		case models.TransactionTypeBuy:
+			quantity += trade.Quantity
+		case models.TransactionTypeSell:
+			if _, ok := imported[trade]; ok && trade.Quantity > quantity {
+				rejected = append(rejected, trade)
+				continue
+			}
+			quantity -= trade.Quantity
+		}
+	}
+
+	return rejected
+}
+
+// Упорядочивание операций по времени; в один момент покупки идут раньше прочих операций
+func sortChronologically(transactions []*domain.Transaction) {
+	sort.SliceStable(transactions, func(i, j int) bool {
+		if !transactions[i].ExecutedAt.Equal(transactions[j].ExecutedAt) {
+			return transactions[i].ExecutedAt.Before(transactions[j].ExecutedAt)
+		}
+		return transactions[i].Type == models.TransactionTypeBuy && transactions[j].Type != models.TransactionTypeBuy
+	})
+}
+
+// Операция по разобранной строке с инструментом из справочника
+func (s *csvImportService) buildTransaction(ctx context.Context, portfolio *domain.Portfolio, row *domain.CsvImportRow, cache map[string]*csvInstrument) (*domain.Transaction, error) {
+	var instrument *csvInstrument
+
+	if !row.Type.IsCashFlow() && (row.Isin != "" || row.Ticker != "") {
+		var err error
+
+		instrument, err = s.findInstrument(ctx, row.Isin, row.Ticker, cache)
+		if err != nil {
+			return nil, err
+		}
+
+		row.InstrumentUid = instrument.Uid
+	}
+
+	req := &domain.CreateTransactionRequest{
+		Type:          row.Type,
+		InstrumentUid: row.InstrumentUid,
+		Quantity:      row.Quantity,
+		Price:         row.Price,
+		Amount:        row.Amount,
+		Commission:    row.Commission,
+		Currency:      row.Currency,
+		Note:          row.Note,
+		ExecutedAt:    row.ExecutedAt,
+	}
+
+	if err := ValidateCreateTransactionRequest(req); err != nil {
+		return nil, err
+	}
+
+	transaction := &domain.Transaction{
+		ID:          uuid.New().String(),
+		PortfolioID: portfolio.ID,
+		Type:        row.Type,
+		Quantity:    row.Quantity,
+		Price:       row.Price,
+		Amount:      row.Amount,
+		Commission:  row.Commission,
+		Currency:    row.Currency,
+		Note:        row.Note,
+		ExecutedAt:  *row.ExecutedAt,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if instrument != nil {
+		transaction.InstrumentUid = instrument.Uid
+		transaction.Figi = instrument.Figi
+		transaction.Ticker = instrument.Ticker
+	}
+
+	if transaction.Currency == "" {
+		transaction.Currency = portfolio.Currency
+		if instrument != nil && instrument.Currency != "" {
+			transaction.Currency = strings.ToUpper(instrument.Currency)
+		}
+	}
+	row.Currency = transaction.Currency
+
+	if !transaction.Type.IsTrade() {
+		transaction.Quantity = 0
+		transaction.Price = 0
+	} else if transaction.Amount == 0 {
+		transaction.Amount = tradeAmount(transaction)
+		row.Amount = transaction.Amount
+	}
+
+	return transaction, nil
+}
+
+// Поиск инструмента в справочнике по ISIN, затем по тикеру
+func (s *csvImportService) findInstrument(ctx context.Context, isin, ticker string, cache map[string]*csvInstrument) (*csvInstrument, error) {
+	lookups := []struct {
+		field string
+		value string
+	}{
+		{"isin", isin},
+		{"ticker", ticker},
+	}
+
+	for _, lookup := range lookups {
+		if lookup.value == "" {
+			continue
+		}
+
+		key := lookup.field + ":" + lookup.value
+		if instrument, ok := cache[key]; ok {
+			if instrument == nil {
+				continue
+			}
+			return instrument, nil
+		}
+
+		instrument, err := s.lookupAsset(ctx, lookup.field, lookup.value)
+		if err != nil {
+			return nil, err
+		}
+
+		cache[key] = instrument
+		if instrument != nil {
+			return instrument, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: isin %q, ticker %q", models.ErrInstrumentNotFound, isin, ticker)
+}
+
+// Поиск по полю среди облигаций, акций, фондов и валют
+func (s *csvImportService) lookupAsset(ctx context.Context, fieldName, fieldValue string) (*csvInstrument, error) {
+	bond, err := s.assets.GetBondByField(ctx, fieldName, fieldValue)
+	if err != nil {
+		return nil, err
+	}
+	if bond != nil {
+		return &csvInstrument{Uid: bond.Uid, Figi: bond.Figi, Ticker: bond.Ticker, Currency: bond.Currency}, nil
+	}
+
+	share, err := s.assets.GetShareByField(ctx, fieldName, fieldValue)
+	if err != nil {
+		return nil, err
+	}
+	if share != nil {
+		return &csvInstrument{Uid: share.Uid, Figi: share.Figi, Ticker: share.Ticker, Currency: share.Currency}, nil
+	}
+
+	etf, err := s.assets.GetEtfByField(ctx, fieldName, fieldValue)
+	if err != nil {
+		return nil, err
+	}
+	if etf != nil {
+		return &csvInstrument{Uid: etf.Uid, Figi: etf.Figi, Ticker: etf.Ticker, Currency: etf.Currency}, nil
+	}
+
+	currency, err := s.assets.GetCurrencyByField(ctx, fieldName, fieldValue)
+	if err != nil {
+		return nil, err
+	}
+	if currency != nil {
+		return &csvInstrument{Uid: currency.Uid, Figi: currency.Figi, Ticker: currency.Ticker, Currency: currency.Currency}, nil
+	}
+
+	return nil, nil
+}
+
+// Разбор строк выписки по настройкам
+type csvRowParser struct {
+	columns      domain.CsvColumnMapping
+	explicit     bool
+	decimalComma bool
+	dateFormats  []string
+	location     *time.Location
+	types        map[string]models.TransactionType
+
+	// Номера колонок по полям (-1 — колонки нет)
+	index map[string]int
+}
+
+func newCsvRowParser(options *domain.CsvImportOptions) (*csvRowParser, error) {
+	parser := &csvRowParser{
+		decimalComma: options.DecimalComma,
+		dateFormats:  csvDateFormats,
+		location:     time.UTC,
+		types:        make(map[string]models.TransactionType, len(csvTransactionTypes)+len(options.Types)),
+	}
+
+	if options.Columns != nil {
+		parser.columns = *options.Columns
+		parser.explicit = true
+	} else {
+		parser.columns = domain.CsvColumnMapping{
+			Date:       "date",
+			Type:       "type",
+			Isin:       "isin",
+			Ticker:     "ticker",
+			Quantity:   "quantity",
+			Price:      "price",
+			Amount:     "amount",
+			Commission: "commission",
+			Currency:   "currency",
+			Note:       "note",
+		}
+	}
+
+	if options.DateFormat != "" {
+		parser.dateFormats = []string{options.DateFormat}
+	}
+
+	if options.Timezone != "" {
+		location, err := time.LoadLocation(options.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unknown timezone %q", models.ErrInvalidRequest, options.Timezone)
+		}
+		parser.location = location
+	}
+
+	for value, transactionType := range csvTransactionTypes {
+		parser.types[value] = transactionType
+	}
+
+	for value, transactionType := range options.Types {
+		transactionType = models.TransactionType(strings.ToUpper(string(transactionType)))
+		if !transactionType.IsValid() {
+			return nil, fmt.Errorf("%w: unsupported transaction type %q for %q", models.ErrInvalidRequest, transactionType, value)
+		}
+		parser.types[strings.ToLower(strings.TrimSpace(value))] = transactionType
+	}
+
+	return parser, nil
+}
+
+// Сопоставление колонок с заголовком выписки (без учёта регистра)
+func (p *csvRowParser) bindHeader(header []string) error {
+	positions := make(map[string]int, len(header))
+	for index, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\uFEFF")))
+		if _, ok := positions[name]; !ok {
+			positions[name] = index
+		}
+	}
+
+	fields := map[string]string{
+		"date":       p.columns.Date,
+		"type":       p.columns.Type,
+		"isin":       p.columns.Isin,
+		"ticker":     p.columns.Ticker,
+		"quantity":   p.columns.Quantity,
+		"price":      p.columns.Price,
+		"amount":     p.columns.Amount,
+		"commission": p.columns.Commission,
+		"currency":   p.columns.Currency,
+		"note":       p.columns.Note,
+	}
+
+	p.index = make(map[string]int, len(fields))
+
+	for field, column := range fields {
+		p.index[field] = -1
+
+		if column == "" {
+			continue
+		}
+
+		position, ok := positions[strings.ToLower(strings.TrimSpace(column))]
+		if !ok {
+			// Колонки по умолчанию необязательны, кроме даты и типа
+			if p.explicit || field == "date" || field == "type" {
+				return fmt.Errorf("%w: column %q not found in header", models.ErrInvalidRequest, column)
+			}
+			continue
+		}
+
+		p.index[field] = position
+	}
+
+	if p.index["date"] < 0 || p.index["type"] < 0 {
+		return fmt.Errorf("%w: date and type columns are required", models.ErrInvalidRequest)
+	}
+
+	return nil
+}
+
+// Разбор строки; ошибка записывается в строку
+func (p *csvRowParser) parse(record []string) *domain.CsvImportRow {
+	row := &domain.CsvImportRow{}
+
+	value := func(field string) string {
+		position := p.index[field]
+		if position < 0 || position >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[position])
+	}
+
+	typeValue := value("type")
+	transactionType, ok := p.types[strings.ToLower(typeValue)]
+	if !ok {
+		row.Error = fmt.Sprintf("unknown transaction type %q", typeValue)
+		return row
+	}
+	row.Type = transactionType
+
+	executedAt, err := p.parseDate(value("date"))
+	if err != nil {
+		row.Error = err.Error()
+		return row
+	}
+	row.ExecutedAt = &executedAt
+
+	row.Isin = strings.ToUpper(value("isin"))
+	row.Ticker = strings.ToUpper(value("ticker"))
+	row.Currency = strings.ToUpper(value("currency"))
+	row.Note = value("note")
+
+	quantity, err := p.parseNumber(value("quantity"))
+	if err != nil {
+		row.Error = fmt.Sprintf("quantity: %v", err)
+		return row
+	}
+	if quantity != math.Trunc(quantity) || quantity > math.MaxInt32 || quantity < -math.MaxInt32 {
+		row.Error = "quantity must be a whole number"
+		return row
+	}
+	row.Quantity = int32(math.Abs(quantity))
+
+	numbers := []struct {
+		field  string
+		target *float64
+	}{
+		{"price", &row.Price},
+		{"amount", &row.Amount},
+		{"commission", &row.Commission},
+	}
+
+	for _, number := range numbers {
+		parsed, err := p.parseNumber(value(number.field))
+		if err != nil {
+			row.Error = fmt.Sprintf("%s: %v", number.field, err)
+			return row
+		}
+		// Брокеры показывают списания со знаком минус, направление задаёт тип операции
+		*number.target = math.Abs(parsed)
+	}
+
+	return row
+}
+
+// Разбор даты по одному из форматов
+func (p *csvRowParser) parseDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, errors.New("date is required")
+	}
+
+	for _, format := range p.dateFormats {
+		if parsed, err := time.ParseInLocation(format, value, p.location); err == nil {
+			return parsed, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+// Разбор числа: пробелы (в том числе неразрывные) отбрасываются,
+// запятая — десятичный разделитель или разделитель разрядов
+func (p *csvRowParser) parseNumber(value string) (float64, error) {
+	value = strings.Map(func(r rune) rune {
+		if r == ' ' || r == '\u00A0' || r == '\u202F' {
+			return -1
+		}
+		return r
+	}, value)
+
+	if value == "" {
+		return 0, nil
+	}
+
+	if p.decimalComma {
+		value = strings.ReplaceAll(value, ",", ".")
+	} else {
+		value = strings.ReplaceAll(value, ",", "")
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+		return 0, fmt.Errorf("invalid number %q", value)
+	}
+
+	return number, nil
+}
+
+// Чтение CSV: записи и номера их строк в файле
+func readCsv(content []byte, delimiter string) ([][]string, []int, error) {
+	content = bytes.TrimPrefix(content, []byte("\uFEFF"))
+
+	comma, err := csvDelimiter(content, delimiter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.Comma = comma
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	records := make([][]string, 0)
+	lines := make([]int, 0)
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", models.ErrInvalidRequest, err)
+		}
+
+		if len(records) > maxCsvImportRows {
+			return nil, nil, fmt.Errorf("%w: file must have at most %d rows", models.ErrInvalidRequest, maxCsvImportRows)
+		}
+
+		line, _ := reader.FieldPos(0)
+		records = append(records, record)
+		lines = append(lines, line)
+	}
+
+	if len(records) == 0 {
+		return nil, nil, fmt.Errorf("%w: file is empty", models.ErrInvalidRequest)
+	}
+
+	return records, lines, nil
+}
+
+// Разделитель полей: заданный или самый частый из ';', ',' и табуляции в первой строке
+func csvDelimiter(content []byte, delimiter string) (rune, error) {
+	switch delimiter {
+	case "":
+	case "\\t", "\t", "tab":
+		return '\t', nil
+	case ",", ";", "|":
+		return rune(delimiter[0]), nil
+	default:
+		return 0, fmt.Errorf("%w: delimiter must be one of , ; | or tab", models.ErrInvalidRequest)
+	}
+
+	header := content
+	if end := bytes.IndexByte(content, '\n'); end >= 0 {
+		header = content[:end]
+	}
+
+	best, bestCount := ',', 0
+	for _, candidate := range []rune{';', ',', '\t'} {
+		if count := bytes.Count(header, []byte(string(candidate))); count > bestCount {
+			best, bestCount = candidate, count
+		}
+	}
+
+	return best, nil
+}
+
+// Пустая строка выписки
+func isBlankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Ключ для поиска повторно загружаемых операций
+func transactionKey(transaction *domain.Transaction) string {
+	return fmt.Sprintf("%s|%s|%d|%d|%.4f",
+		transaction.Type,
+		transaction.InstrumentUid,
+		transaction.ExecutedAt.Unix(),
+		transaction.Quantity,
+		transaction.Amount,
+	)
+}
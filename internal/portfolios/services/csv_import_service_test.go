@@ -0,0 +1,408 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	assetsEntity "invest-mate/internal/assets/models/entity"
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+)
+
+// Справочник акций в памяти; облигаций, фондов и валют в нём нет
+type memoryAssets struct {
+	shares []assetsEntity.Share
+}
+
+func (a *memoryAssets) GetShareByField(ctx context.Context, fieldName string, fieldValue string) (*assetsEntity.Share, error) {
+	for i := range a.shares {
+		share := &a.shares[i]
+		if (fieldName == "isin" && share.Isin == fieldValue) || (fieldName == "ticker" && share.Ticker == fieldValue) {
+			return share, nil
+		}
+	}
+	return nil, nil
+}
+
+func (a *memoryAssets) GetBondByField(ctx context.Context, fieldName string, fieldValue string) (*assetsEntity.Bond, error) {
+	return nil, nil
+}
+
+func (a *memoryAssets) GetEtfByField(ctx context.Context, fieldName string, fieldValue string) (*assetsEntity.Etf, error) {
+	return nil, nil
+}
+
+func (a *memoryAssets) GetCurrencyByField(ctx context.Context, fieldName string, fieldValue string) (*assetsEntity.Currency, error) {
+	return nil, nil
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+// Разбор выписки без обращения к справочнику и журналу
+func parseCsv(t *testing.T, content string, options *domain.CsvImportOptions) []*domain.CsvImportRow {
+	t.Helper()
+
+	parser, err := newCsvRowParser(options)
+	if err != nil {
+		t.Fatalf("newCsvRowParser: %v", err)
+	}
+
+	records, _, err := readCsv([]byte(content), options.Delimiter)
+	if err != nil {
+		t.Fatalf("readCsv: %v", err)
+	}
+
+	if err := parser.bindHeader(records[0]); err != nil {
+		t.Fatalf("bindHeader: %v", err)
+	}
+
+	rows := make([]*domain.CsvImportRow, 0, len(records)-1)
+	for _, record := range records[1:] {
+		rows = append(rows, parser.parse(record))
+	}
+	return rows
+}
+
+func TestCsvRowParser(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Skipf("no timezone database: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		options domain.CsvImportOptions
+		want    domain.CsvImportRow
+	}{
+		{
+			name:    "default columns with comma delimiter",
+			content: "date,type,ticker,quantity,price,commission\n2024-03-01,buy,sber,10,250.5,1.25\n",
+			want: domain.CsvImportRow{
+				Type: models.TransactionTypeBuy, Ticker: "SBER", Quantity: 10, Price: 250.5, Commission: 1.25,
+				ExecutedAt: ptr(date(2024, 3, 1)),
+			},
+		},
+		{
+			// Разделитель ';' определяется по заголовку, пробелы разрядов и минус отбрасываются
+			name:    "russian statement with decimal comma",
+			content: "Дата;Операция;ISIN;Кол-во;Цена;Сумма\n01.03.2024 10:30;Продажа;ru0009029540;-10;1 250,5;-12 505\n",
+			options: domain.CsvImportOptions{
+				DecimalComma: true,
+				Columns: &domain.CsvColumnMapping{
+					Date: "дата", Type: "операция", Isin: "isin", Quantity: "кол-во", Price: "цена", Amount: "сумма",
+				},
+			},
+			want: domain.CsvImportRow{
+				Type: models.TransactionTypeSell, Isin: "RU0009029540", Quantity: 10, Price: 1250.5, Amount: 12505,
+				ExecutedAt: ptr(time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:    "thousands separator without decimal comma",
+			content: "date,type,ticker,quantity,price\n2024-03-01,buy,lkoh,1,\"7,000.5\"\n",
+			want: domain.CsvImportRow{
+				Type: models.TransactionTypeBuy, Ticker: "LKOH", Quantity: 1, Price: 7000.5,
+				ExecutedAt: ptr(date(2024, 3, 1)),
+			},
+		},
+		{
+			name:    "custom type values, date format and timezone",
+			content: "date\ttype\tamount\tcurrency\n03/01/2024\tЗачисление\t5000\tusd\n",
+			options: domain.CsvImportOptions{
+				Delimiter:  "tab",
+				DateFormat: "01/02/2006",
+				Timezone:   "Europe/Moscow",
+				Types:      map[string]models.TransactionType{"Зачисление": "deposit"},
+			},
+			want: domain.CsvImportRow{
+				Type: models.TransactionTypeDeposit, Amount: 5000, Currency: "USD",
+				ExecutedAt: ptr(time.Date(2024, 3, 1, 0, 0, 0, 0, moscow)),
+			},
+		},
+		{
+			name:    "byte order mark in header",
+			content: "\uFEFFDate,Type,Amount\n2024-03-01,deposit,100\n",
+			want: domain.CsvImportRow{
+				Type: models.TransactionTypeDeposit, Amount: 100, ExecutedAt: ptr(date(2024, 3, 1)),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := parseCsv(t, tt.content, &tt.options)
+			if len(rows) != 1 {
+				t.Fatalf("rows = %d, want 1", len(rows))
+			}
+
+			got := rows[0]
+			if got.Error != "" {
+				t.Fatalf("error = %q", got.Error)
+			}
+			if got.ExecutedAt == nil || !got.ExecutedAt.Equal(*tt.want.ExecutedAt) {
+				t.Errorf("executedAt = %v, want %v", got.ExecutedAt, *tt.want.ExecutedAt)
+			}
+
+			got.ExecutedAt, tt.want.ExecutedAt = nil, nil
+			if *got != tt.want {
+				t.Errorf("row = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCsvRowParserMalformedRows(t *testing.T) {
+	content := "date,type,ticker,quantity,price\n" +
+		"2024-03-01,swap,sber,10,250\n" +
+		"2024-13-01,buy,sber,10,250\n" +
+		",buy,sber,10,250\n" +
+		"2024-03-01,buy,sber,1.5,250\n" +
+		"2024-03-01,buy,sber,10,abc\n" +
+		"2024-03-01,buy,sber,99999999999,250\n"
+
+	want := []string{
+		`unknown transaction type "swap"`,
+		`invalid date "2024-13-01"`,
+		"date is required",
+		"quantity must be a whole number",
+		`price: invalid number "abc"`,
+		"quantity must be a whole number",
+	}
+
+	rows := parseCsv(t, content, &domain.CsvImportOptions{})
+	if len(rows) != len(want) {
+		t.Fatalf("rows = %d, want %d", len(rows), len(want))
+	}
+	for i, row := range rows {
+		if row.Error != want[i] {
+			t.Errorf("row %d error = %q, want %q", i+1, row.Error, want[i])
+		}
+	}
+}
+
+func TestCsvHeaderAndOptionsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		options domain.CsvImportOptions
+	}{
+		{"missing date column", "type,ticker\nbuy,sber\n", domain.CsvImportOptions{}},
+		{
+			name:    "explicit column missing in header",
+			content: "date,type\n2024-03-01,buy\n",
+			options: domain.CsvImportOptions{Columns: &domain.CsvColumnMapping{Date: "date", Type: "type", Ticker: "symbol"}},
+		},
+		{"unsupported delimiter", "date,type\n", domain.CsvImportOptions{Delimiter: ":"}},
+		{"unknown timezone", "date,type\n", domain.CsvImportOptions{Timezone: "Mars/Olympus"}},
+		{"unsupported custom type", "date,type\n", domain.CsvImportOptions{Types: map[string]models.TransactionType{"swap": "SWAP"}}},
+		{"empty file", "", domain.CsvImportOptions{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := func() error {
+				parser, err := newCsvRowParser(&tt.options)
+				if err != nil {
+					return err
+				}
+				records, _, err := readCsv([]byte(tt.content), tt.options.Delimiter)
+				if err != nil {
+					return err
+				}
+				return parser.bindHeader(records[0])
+			}()
+
+			if !errors.Is(err, models.ErrInvalidRequest) {
+				t.Errorf("error = %v, want ErrInvalidRequest", err)
+			}
+		})
+	}
+}
+
+func TestTransactionKey(t *testing.T) {
+	base := trade("buy-1", models.TransactionTypeBuy, time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), 10, 250, 1)
+
+	tests := []struct {
+		name   string
+		change func(transaction *domain.Transaction)
+		same   bool
+	}{
+		{"id and commission are ignored", func(transaction *domain.Transaction) { transaction.ID = "buy-2"; transaction.Commission = 5 }, true},
+		{"same moment in another timezone", func(transaction *domain.Transaction) {
+			transaction.ExecutedAt = transaction.ExecutedAt.In(time.FixedZone("MSK", 3*60*60))
+		}, true},
+		{"amount rounding below the key precision", func(transaction *domain.Transaction) { transaction.Amount += 0.00001 }, true},
+		{"another type", func(transaction *domain.Transaction) { transaction.Type = models.TransactionTypeSell }, false},
+		{"another instrument", func(transaction *domain.Transaction) { transaction.InstrumentUid = "gazp" }, false},
+		{"another second", func(transaction *domain.Transaction) {
+			transaction.ExecutedAt = transaction.ExecutedAt.Add(time.Second)
+		}, false},
+		{"another quantity", func(transaction *domain.Transaction) { transaction.Quantity = 11 }, false},
+		{"another amount", func(transaction *domain.Transaction) { transaction.Amount = 2501 }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			other := *base
+			tt.change(&other)
+
+			if same := transactionKey(base) == transactionKey(&other); same != tt.same {
+				t.Errorf("same key = %v, want %v", same, tt.same)
+			}
+		})
+	}
+}
+
+func TestRejectOversoldTrades(t *testing.T) {
+	at := func(day, hour int) time.Time {
+		return time.Date(2024, 3, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		stored   []*domain.Transaction
+		imported []*domain.Transaction
+		want     []string
+	}{
+		{
+			// Выписка от новых операций к старым
+			name: "statement in reverse order",
+			imported: []*domain.Transaction{
+				trade("sell-1", models.TransactionTypeSell, at(3, 10), 10, 260, 0),
+				trade("buy-1", models.TransactionTypeBuy, at(1, 10), 10, 250, 0),
+			},
+		},
+		{
+			name: "buy and sell at the same moment",
+			imported: []*domain.Transaction{
+				trade("sell-1", models.TransactionTypeSell, at(1, 10), 10, 260, 0),
+				trade("buy-1", models.TransactionTypeBuy, at(1, 10), 10, 250, 0),
+			},
+		},
+		{
+			name:   "sell covered by stored buys",
+			stored: []*domain.Transaction{trade("stored-buy", models.TransactionTypeBuy, at(1, 10), 10, 250, 0)},
+			imported: []*domain.Transaction{
+				trade("sell-1", models.TransactionTypeSell, at(2, 10), 10, 260, 0),
+			},
+		},
+		{
+			// Вторая продажа превышает остаток, третья укладывается в него
+			name:   "only the oversold sell is rejected",
+			stored: []*domain.Transaction{trade("stored-buy", models.TransactionTypeBuy, at(1, 10), 10, 250, 0)},
+			imported: []*domain.Transaction{
+				trade("sell-1", models.TransactionTypeSell, at(2, 10), 6, 260, 0),
+				trade("sell-2", models.TransactionTypeSell, at(3, 10), 6, 260, 0),
+				trade("sell-3", models.TransactionTypeSell, at(4, 10), 4, 260, 0),
+			},
+			want: []string{"sell-2"},
+		},
+		{
+			name: "sell before the buy",
+			imported: []*domain.Transaction{
+				trade("sell-1", models.TransactionTypeSell, at(1, 10), 10, 260, 0),
+				trade("buy-1", models.TransactionTypeBuy, at(1, 11), 10, 250, 0),
+			},
+			want: []string{"sell-1"},
+		},
+		{
+			// Сохранённая продажа не отклоняется, но уменьшает остаток для продаж из выписки
+			name: "stored sells are counted but never rejected",
+			stored: []*domain.Transaction{
+				trade("stored-sell", models.TransactionTypeSell, at(1, 10), 5, 260, 0),
+			},
+			imported: []*domain.Transaction{
+				trade("buy-1", models.TransactionTypeBuy, at(2, 10), 10, 250, 0),
+				trade("sell-1", models.TransactionTypeSell, at(3, 10), 10, 260, 0),
+			},
+			want: []string{"sell-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			imported := make(map[*domain.Transaction]*domain.CsvImportRow, len(tt.imported))
+			for _, transaction := range tt.imported {
+				imported[transaction] = &domain.CsvImportRow{}
+			}
+
+			rejected := rejectOversoldTrades(append(append([]*domain.Transaction{}, tt.stored...), tt.imported...), imported)
+
+			got := make([]string, 0, len(rejected))
+			for _, transaction := range rejected {
+				got = append(got, transaction.ID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("rejected = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPreviewCsv(t *testing.T) {
+	stored := trade("stored-buy", models.TransactionTypeBuy, time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), 10, 250, 0)
+
+	service := NewCsvImportService(
+		newMemoryPortfolios(&domain.Portfolio{ID: "portfolio-1", UserId: "user-1", Currency: "RUB"}),
+		nil,
+		&memoryLedger{transactions: []*domain.Transaction{stored}},
+		&memoryAssets{shares: []assetsEntity.Share{{Uid: "sber", Ticker: "SBER", Isin: "RU0009029540", Currency: "rub"}}},
+	)
+
+	content := "date,type,ticker,quantity,price\n" +
+		// Операция уже сохранена в портфеле
+		"2024-03-01 10:00:00,buy,SBER,10,250\n" +
+		// Такая же сделка в том же файле — вторая покупка, а не повтор
+		"2024-03-01 10:00:00,buy,SBER,10,250\n" +
+		"2024-03-02 10:00:00,sell,SBER,15,260\n" +
+		// Продано больше остатка
+		"2024-03-03 10:00:00,sell,SBER,10,260\n" +
+		"2024-03-03 10:00:00,buy,UNKNOWN,1,100\n" +
+		"not a date,buy,SBER,1,100\n" +
+		",,,,\n"
+
+	preview, err := service.PreviewCsv(t.Context(), "user-1", "portfolio-1", []byte(content), &domain.CsvImportOptions{})
+	if err != nil {
+		t.Fatalf("PreviewCsv: %v", err)
+	}
+
+	if preview.Total != 6 || preview.Valid != 2 || preview.Duplicates != 1 || preview.Invalid != 3 {
+		t.Errorf("total = %d, valid = %d, duplicates = %d, invalid = %d, want 6, 2, 1, 3",
+			preview.Total, preview.Valid, preview.Duplicates, preview.Invalid)
+	}
+
+	want := []struct {
+		line      int
+		duplicate bool
+		error     string
+	}{
+		{line: 2, duplicate: true},
+		{line: 3},
+		{line: 4},
+		{line: 5, error: models.ErrInsufficientQuantity.Error()},
+		{line: 6, error: `Инструмент не найден: isin "", ticker "UNKNOWN"`},
+		{line: 7, error: `invalid date "not a date"`},
+	}
+
+	if len(preview.Rows) != len(want) {
+		t.Fatalf("rows = %d, want %d", len(preview.Rows), len(want))
+	}
+	for i, w := range want {
+		row := preview.Rows[i]
+		if row.Line != w.line || row.Duplicate != w.duplicate || row.Error != w.error {
+			t.Errorf("row %d = line %d duplicate %v error %q, want line %d duplicate %v error %q",
+				i, row.Line, row.Duplicate, row.Error, w.line, w.duplicate, w.error)
+		}
+	}
+
+	// Валюта и сумма берутся из справочника и цены
+	if row := preview.Rows[1]; row.Currency != "RUB" || row.Amount != 2500 || row.InstrumentUid != "sber" {
+		t.Errorf("row 3 = %+v, want RUB 2500 of sber", row)
+	}
+}
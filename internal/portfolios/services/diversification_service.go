@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	assetsDomain "invest-mate/internal/assets/models/domain"
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+)
+
+const (
+	defaultDiversificationTop = 5
+	unknownGroupKey           = "UNKNOWN"
+)
+
+type DiversificationService interface {
+	GetDiversification(ctx context.Context, userID, portfolioID string, dimension models.AllocationDimension, currency string, top int) (*domain.Diversification, error)
+}
+
+type diversificationService struct {
+	portfoliosService PortfoliosService
+	valuationService  ValuationService
+	instruments       InstrumentResolver
+}
+
+// Создание нового сервиса диверсификации портфелей
+func NewDiversificationService(
+	portfoliosService PortfoliosService,
+	valuationService ValuationService,
+	instruments InstrumentResolver,
+) DiversificationService {
+	return &diversificationService{
+		portfoliosService: portfoliosService,
+		valuationService:  valuationService,
+		instruments:       instruments,
+	}
+}
+
+// Группировка позиций портфеля и вложенных портфелей по сектору, стране, валюте,
+// типу или инструменту с расчётом концентрации
+func (s *diversificationService) GetDiversification(ctx context.Context, userID, portfolioID string, dimension models.AllocationDimension, currency string, top int) (*domain.Diversification, error) {
+	dimension = models.AllocationDimension(strings.ToUpper(firstNonEmpty(string(dimension), string(models.AllocationDimensionSector))))
+	if !dimension.IsValidForDiversification() {
+		return nil, fmt.Errorf("%w: dimension must be INSTRUMENT, SECTOR, INSTRUMENT_TYPE, CURRENCY or COUNTRY", models.ErrInvalidRequest)
+	}
+
+	if top < 0 {
+		return nil, fmt.Errorf("%w: top must be positive", models.ErrInvalidRequest)
+	}
+	if top == 0 {
+		top = defaultDiversificationTop
+	}
+
+	portfolio, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	valuation, err := s.valuationService.Valuate(ctx, portfolio, currency, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	uids := make([]string, 0, len(valuation.Positions))
+	for _, position := range valuation.Positions {
+		uids = append(uids, position.InstrumentUid)
+	}
+
+	instruments, err := s.instruments.GetInstrumentsByUids(ctx, uids)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]*domain.DiversificationGroup, 0)
+	byKey := make(map[string]*domain.DiversificationGroup)
+	byInstrument := make(map[string]float64)
+
+	for _, position := range valuation.Positions {
+		byInstrument[position.InstrumentUid] += position.ValueBase
+
+		key, name := unknownGroupKey, ""
+		if instrument, ok := instruments[position.InstrumentUid]; ok {
+			key, name = groupKey(dimension, instrument)
+		} else if dimension == models.AllocationDimensionInstrument {
+			key, name = position.InstrumentUid, position.Ticker
+		}
+
+		group, ok := byKey[key]
+		if !ok {
+			group = &domain.DiversificationGroup{Key: key, Name: name}
+			byKey[key] = group
+			groups = append(groups, group)
+		}
+
+		group.Value += position.ValueBase
+		group.PositionsCount++
+	}
+
+	for _, group := range groups {
+		if valuation.TotalValue != 0 {
+			group.WeightPercent = group.Value / valuation.TotalValue * 100
+		}
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Value > groups[j].Value
+	})
+
+	instrumentValues := make([]float64, 0, len(byInstrument))
+	for _, value := range byInstrument {
+		instrumentValues = append(instrumentValues, value)
+	}
+
+	groupValues := make([]float64, 0, len(groups))
+	for _, group := range groups {
+		groupValues = append(groupValues, group.Value)
+	}
+
+	return &domain.Diversification{
+		PortfolioID:             portfolioID,
+		Currency:                valuation.Currency,
+		Dimension:               dimension,
+		TotalValue:              valuation.TotalValue,
+		Groups:                  groups,
+		Concentration:           concentration(groupValues, top),
+		InstrumentConcentration: concentration(instrumentValues, top),
+		Unvalued:                valuation.Unvalued,
+	}, nil
+}
+
+// Ключ и название группы инструмента
+func groupKey(dimension models.AllocationDimension, instrument assetsDomain.Instrument) (string, string) {
+	// Страна риска есть только в структуре портфеля, в целевом распределении её нет
+	if dimension == models.AllocationDimensionCountry {
+		key := strings.ToUpper(instrument.CountryOfRisk)
+		if key == "" {
+			return unknownGroupKey, ""
+		}
+
+		return key, instrument.CountryOfRiskName
+	}
+
+	key := allocationKey(dimension, instrument)
+
+	name := key
+	if dimension == models.AllocationDimensionInstrument {
+		name = instrument.Ticker
+	}
+
+	if key == "" {
+		return unknownGroupKey, ""
+	}
+
+	return key, name
+}
+
+// Индекс Херфиндаля по долям, эффективное число групп и доля top крупнейших
+func concentration(values []float64, top int) *domain.ConcentrationMetrics {
+	metrics := &domain.ConcentrationMetrics{TopN: top}
+
+	total := 0.0
+	for _, value := range values {
+		total += value
+	}
+
+	if total <= 0 {
+		return metrics
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Sort(sort.Reverse(sort.Float64Slice(sorted)))
+
+	for i, value := range sorted {
+		share := value / total
+
+		metrics.HerfindahlIndex += share * share
+		if i < top {
+			metrics.TopSharePercent += share * 100
+		}
+	}
+
+	if metrics.HerfindahlIndex > 0 {
+		metrics.EffectiveCount = 1 / metrics.HerfindahlIndex
+	}
+
+	return metrics
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"sort"
+
+	assetsDomain "invest-mate/internal/assets/models/domain"
+	"invest-mate/internal/portfolios/models/domain"
+	sharedModels "invest-mate/internal/shared/models"
+	"invest-mate/pkg/logger"
+)
+
+// Источник аналитики облигаций (реализуется сервисом аналитики модуля активов)
+type BondAnalyticsSource interface {
+	GetBondsAnalytics(ctx context.Context, instrumentUids []string) (map[string]*assetsDomain.BondAnalytics, error)
+}
+
+type DurationService interface {
+	GetDuration(ctx context.Context, userID, portfolioID, currency string) (*domain.PortfolioDuration, error)
+}
+
+type durationService struct {
+	portfoliosService PortfoliosService
+	valuationService  ValuationService
+	instruments       InstrumentResolver
+	bonds             BondAnalyticsSource
+}
+
+// Создание нового сервиса дюрации портфелей
+func NewDurationService(
+	portfoliosService PortfoliosService,
+	valuationService ValuationService,
+	instruments InstrumentResolver,
+	bonds BondAnalyticsSource,
+) DurationService {
+	return &durationService{
+		portfoliosService: portfoliosService,
+		valuationService:  valuationService,
+		instruments:       instruments,
+		bonds:             bonds,
+	}
+}
+
+// Дюрация и выпуклость облигационных позиций портфеля и вложенных портфелей
+// (облигации оцениваются по последним ценам)
+func (s *durationService) GetDuration(ctx context.Context, userID, portfolioID, currency string) (*domain.PortfolioDuration, error) {
+	portfolio, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	valuation, err := s.valuationService.Valuate(ctx, portfolio, currency, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.PortfolioDuration{
+		PortfolioID: portfolioID,
+		Currency:    valuation.Currency,
+		Positions:   make([]*domain.BondPositionDuration, 0),
+		Unavailable: make([]string, 0),
+	}
+
+	byInstrument := make(map[string]*domain.BondPositionDuration)
+	uids := make([]string, 0, len(valuation.Positions))
+
+	for _, position := range valuation.Positions {
+		item, ok := byInstrument[position.InstrumentUid]
+		if !ok {
+			item = &domain.BondPositionDuration{
+				InstrumentUid: position.InstrumentUid,
+				Ticker:        position.Ticker,
+			}
+			byInstrument[position.InstrumentUid] = item
+			uids = append(uids, position.InstrumentUid)
+		}
+
+		item.Quantity += position.Quantity
+		item.ValueBase += position.ValueBase
+	}
+
+	instruments, err := s.instruments.GetInstrumentsByUids(ctx, uids)
+	if err != nil {
+		return nil, err
+	}
+
+	bondUids := make([]string, 0, len(uids))
+	for _, uid := range uids {
+		if instrument, ok := instruments[uid]; ok && instrument.InstrumentType == sharedModels.InstrumentTypeBond {
+			bondUids = append(bondUids, uid)
+		}
+	}
+
+	// Доходности всех облигаций считаются одним запросом цен и графиков
+	analyticsByUid, err := s.bonds.GetBondsAnalytics(ctx, bondUids)
+	if err != nil {
+		logger.ErrorLog("Failed to analyze bonds of portfolio %s: %v", portfolioID, err)
+		analyticsByUid = map[string]*assetsDomain.BondAnalytics{}
+	}
+
+	// Веса считаются только по облигациям, для которых рассчитана дюрация
+	covered := 0.0
+
+	for _, uid := range bondUids {
+		item := byInstrument[uid]
+		result.BondsValue += item.ValueBase
+		result.Positions = append(result.Positions, item)
+
+		analytics, ok := analyticsByUid[uid]
+		if !ok || analytics.MacaulayDuration == nil {
+			result.Unavailable = append(result.Unavailable, item.Ticker)
+			continue
+		}
+
+		item.Horizon = analytics.DurationHorizon
+		item.Yield = analytics.YieldToMaturity
+		if analytics.DurationHorizon == assetsDomain.BondHorizonOffer {
+			item.Yield = analytics.YieldToOffer
+		}
+		item.MacaulayDuration = analytics.MacaulayDuration
+		item.ModifiedDuration = analytics.ModifiedDuration
+		item.Convexity = analytics.Convexity
+
+		covered += item.ValueBase
+		result.MacaulayDuration += item.ValueBase * *item.MacaulayDuration
+		result.ModifiedDuration += item.ValueBase * *item.ModifiedDuration
+		result.Convexity += item.ValueBase * *item.Convexity
+	}
+
+	if covered > 0 {
+		result.MacaulayDuration /= covered
+		result.ModifiedDuration /= covered
+		result.Convexity /= covered
+	}
+
+	for _, item := range result.Positions {
+		if result.BondsValue != 0 {
+			item.WeightPercent = item.ValueBase / result.BondsValue * 100
+		}
+	}
+
+	if valuation.TotalValue != 0 {
+		result.BondsSharePercent = result.BondsValue / valuation.TotalValue * 100
+	}
+
+	sort.Slice(result.Positions, func(i, j int) bool {
+		return result.Positions[i].ValueBase > result.Positions[j].ValueBase
+	})
+
+	return result, nil
+}
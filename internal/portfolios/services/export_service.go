@@ -0,0 +1,387 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	assetsDomain "invest-mate/internal/assets/models/domain"
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/internal/portfolios/repository"
+)
+
+type ExportService interface {
+	Export(ctx context.Context, userID, portfolioID string, dataset models.ExportDataset, options *domain.ExportOptions) (*domain.ExportTable, error)
+}
+
+type exportService struct {
+	portfoliosService  PortfoliosService
+	compositeService   CompositeService
+	transactionsRepo   repository.TransactionsRepository
+	incomeService      IncomeService
+	valuationService   ValuationService
+	performanceService PerformanceService
+	instruments        InstrumentResolver
+}
+
+// Создание нового сервиса выгрузки портфелей в таблицы
+func NewExportService(
+	portfoliosService PortfoliosService,
+	compositeService CompositeService,
+	transactionsRepo repository.TransactionsRepository,
+	incomeService IncomeService,
+	valuationService ValuationService,
+	performanceService PerformanceService,
+	instruments InstrumentResolver,
+) ExportService {
+	return &exportService{
+		portfoliosService:  portfoliosService,
+		compositeService:   compositeService,
+		transactionsRepo:   transactionsRepo,
+		incomeService:      incomeService,
+		valuationService:   valuationService,
+		performanceService: performanceService,
+		instruments:        instruments,
+	}
+}
+
+// Размер страницы операций при выгрузке
+const exportBatchSize = 500
+
+// Колонка выгрузки со способом получения значения из строки данных
+type exportColumn[T any] struct {
+	key   string
+	title string
+	value func(T) any
+}
+
+// Строка аналитики портфеля
+type analyticsMetric struct {
+	key      string
+	title    string
+	value    any
+	currency string
+}
+
+// Строка выгрузки с инструментом из справочника
+type exportItem[T any] struct {
+	item       T
+	instrument assetsDomain.Instrument
+}
+
+var positionExportColumns = []exportColumn[exportItem[*domain.PositionValuation]]{
+	{"ticker", "Тикер", func(p exportItem[*domain.PositionValuation]) any { return p.item.Ticker }},
+	{"name", "Название", func(p exportItem[*domain.PositionValuation]) any { return p.instrument.Name }},
+	{"isin", "ISIN", func(p exportItem[*domain.PositionValuation]) any { return p.instrument.Isin }},
+	{"instrumentType", "Тип инструмента", func(p exportItem[*domain.PositionValuation]) any { return string(p.instrument.InstrumentType) }},
+	{"instrumentUid", "UID инструмента", func(p exportItem[*domain.PositionValuation]) any { return p.item.InstrumentUid }},
+	{"quantity", "Количество", func(p exportItem[*domain.PositionValuation]) any { return int64(p.item.Quantity) }},
+	{"currency", "Валюта", func(p exportItem[*domain.PositionValuation]) any { return p.item.Currency }},
+	{"value", "Стоимость", func(p exportItem[*domain.PositionValuation]) any { return p.item.Value }},
+	{"investedAmount", "Вложено", func(p exportItem[*domain.PositionValuation]) any { return p.item.InvestedAmount }},
+	{"expectedYield", "Доход", func(p exportItem[*domain.PositionValuation]) any { return p.item.Value - p.item.InvestedAmount }},
+	{"rate", "Курс", func(p exportItem[*domain.PositionValuation]) any { return p.item.Rate }},
+	{"valueBase", "Стоимость в валюте оценки", func(p exportItem[*domain.PositionValuation]) any { return p.item.ValueBase }},
+	{"investedBase", "Вложено в валюте оценки", func(p exportItem[*domain.PositionValuation]) any { return p.item.InvestedBase }},
+	{"expectedYieldBase", "Доход в валюте оценки", func(p exportItem[*domain.PositionValuation]) any { return p.item.ExpectedYieldBase }},
+	{"portfolioId", "Портфель", func(p exportItem[*domain.PositionValuation]) any { return p.item.PortfolioID }},
+}
+
+var transactionExportColumns = []exportColumn[exportItem[*domain.Transaction]]{
+	{"executedAt", "Дата", func(t exportItem[*domain.Transaction]) any { return t.item.ExecutedAt }},
+	{"type", "Операция", func(t exportItem[*domain.Transaction]) any { return string(t.item.Type) }},
+	{"ticker", "Тикер", func(t exportItem[*domain.Transaction]) any { return t.item.Ticker }},
+	{"name", "Название", func(t exportItem[*domain.Transaction]) any { return t.instrument.Name }},
+	{"isin", "ISIN", func(t exportItem[*domain.Transaction]) any { return t.instrument.Isin }},
+	{"instrumentUid", "UID инструмента", func(t exportItem[*domain.Transaction]) any { return t.item.InstrumentUid }},
+	{"quantity", "Количество", func(t exportItem[*domain.Transaction]) any { return int64(t.item.Quantity) }},
+	{"price", "Цена", func(t exportItem[*domain.Transaction]) any { return t.item.Price }},
+	{"amount", "Сумма", func(t exportItem[*domain.Transaction]) any { return t.item.Amount }},
+	{"commission", "Комиссия", func(t exportItem[*domain.Transaction]) any { return t.item.Commission }},
+	{"currency", "Валюта", func(t exportItem[*domain.Transaction]) any { return t.item.Currency }},
+	{"note", "Комментарий", func(t exportItem[*domain.Transaction]) any { return t.item.Note }},
+	{"id", "ID операции", func(t exportItem[*domain.Transaction]) any { return t.item.ID }},
+	{"portfolioId", "Портфель", func(t exportItem[*domain.Transaction]) any { return t.item.PortfolioID }},
+}
+
+var incomeExportColumns = []exportColumn[exportItem[*domain.IncomePayment]]{
+	{"paidAt", "Дата выплаты", func(p exportItem[*domain.IncomePayment]) any { return p.item.PaidAt }},
+	{"type", "Вид дохода", func(p exportItem[*domain.IncomePayment]) any { return string(p.item.Type) }},
+	{"ticker", "Тикер", func(p exportItem[*domain.IncomePayment]) any { return p.item.Ticker }},
+	{"name", "Название", func(p exportItem[*domain.IncomePayment]) any { return p.instrument.Name }},
+	{"isin", "ISIN", func(p exportItem[*domain.IncomePayment]) any { return p.instrument.Isin }},
+	{"country", "Страна", func(p exportItem[*domain.IncomePayment]) any { return p.item.Country }},
+	{"gross", "Начислено", func(p exportItem[*domain.IncomePayment]) any { return p.item.Gross }},
+	{"taxPercent", "Ставка налога, %", func(p exportItem[*domain.IncomePayment]) any { return float64(p.item.TaxPercent) }},
+	{"tax", "Налог", func(p exportItem[*domain.IncomePayment]) any { return p.item.Tax }},
+	{"net", "К получению", func(p exportItem[*domain.IncomePayment]) any { return p.item.Net }},
+	{"currency", "Валюта", func(p exportItem[*domain.IncomePayment]) any { return p.item.Currency }},
+	{"transactionId", "ID операции", func(p exportItem[*domain.IncomePayment]) any { return p.item.TransactionID }},
+}
+
+var analyticsExportColumns = []exportColumn[analyticsMetric]{
+	{"metric", "Показатель", func(m analyticsMetric) any { return m.key }},
+	{"title", "Описание", func(m analyticsMetric) any { return m.title }},
+	{"value", "Значение", func(m analyticsMetric) any { return m.value }},
+	{"currency", "Валюта", func(m analyticsMetric) any { return m.currency }},
+}
+
+// Выгрузка набора данных портфеля в виде таблицы с выбранными колонками
+func (s *exportService) Export(ctx context.Context, userID, portfolioID string, dataset models.ExportDataset, options *domain.ExportOptions) (*domain.ExportTable, error) {
+	dataset = models.ExportDataset(strings.ToUpper(string(dataset)))
+	if !dataset.IsValid() {
+		return nil, fmt.Errorf("%w: dataset must be POSITIONS, TRANSACTIONS, INCOME or ANALYTICS", models.ErrInvalidRequest)
+	}
+
+	switch dataset {
+	case models.ExportDatasetPositions:
+		return s.exportPositions(ctx, userID, portfolioID, options)
+	case models.ExportDatasetTransactions:
+		return s.exportTransactions(ctx, userID, portfolioID, options)
+	case models.ExportDatasetIncome:
+		return s.exportIncome(ctx, userID, portfolioID, options)
+	default:
+		return s.exportAnalytics(ctx, userID, portfolioID, options)
+	}
+}
+
+// Позиции портфеля и вложенных портфелей с оценкой
+func (s *exportService) exportPositions(ctx context.Context, userID, portfolioID string, options *domain.ExportOptions) (*domain.ExportTable, error) {
+	valuation, err := s.valuationService.GetValuation(ctx, userID, portfolioID, options.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	uids := make([]string, 0, len(valuation.Positions))
+	for _, position := range valuation.Positions {
+		uids = append(uids, position.InstrumentUid)
+	}
+
+	instruments, err := s.instrumentsByUids(ctx, uids)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]exportItem[*domain.PositionValuation], 0, len(valuation.Positions))
+	for _, position := range valuation.Positions {
+		items = append(items, exportItem[*domain.PositionValuation]{item: position, instrument: instruments[position.InstrumentUid]})
+	}
+
+	return buildExportTable(models.ExportDatasetPositions, positionExportColumns, items, options.Columns)
+}
+
+// Операции портфеля и вложенных портфелей в хронологическом порядке. Операции читаются
+// из БД страницами во время записи файла, поэтому большая история не загружается в память целиком
+func (s *exportService) exportTransactions(ctx context.Context, userID, portfolioID string, options *domain.ExportOptions) (*domain.ExportTable, error) {
+	if _, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID); err != nil {
+		return nil, err
+	}
+
+	portfolioIDs, err := s.compositeService.GetTreePortfolioIDs(ctx, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	selected, err := selectExportColumns(models.ExportDatasetTransactions, transactionExportColumns, options.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := options.Filter
+	if filter == nil {
+		filter = &domain.TransactionFilter{From: options.From, To: options.To}
+	}
+
+	instruments := make(map[string]assetsDomain.Instrument)
+
+	each := func(yield func(exportItem[*domain.Transaction]) error) error {
+		return s.transactionsRepo.EachByPortfolios(ctx, portfolioIDs, filter, exportBatchSize, func(transactions []*domain.Transaction) error {
+			// Справочник дополняется только инструментами, которых не было на прошлых страницах
+			uids := make([]string, 0, len(transactions))
+			for _, transaction := range transactions {
+				if _, ok := instruments[transaction.InstrumentUid]; !ok && transaction.InstrumentUid != "" {
+					uids = append(uids, transaction.InstrumentUid)
+				}
+			}
+
+			found, err := s.instrumentsByUids(ctx, uids)
+			if err != nil {
+				return err
+			}
+			for _, uid := range uids {
+				instruments[uid] = found[uid]
+			}
+
+			for _, transaction := range transactions {
+				if err := yield(exportItem[*domain.Transaction]{item: transaction, instrument: instruments[transaction.InstrumentUid]}); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	return newExportTable(models.ExportDatasetTransactions, selected, each), nil
+}
+
+// Выплаты дивидендов и купонов за период
+func (s *exportService) exportIncome(ctx context.Context, userID, portfolioID string, options *domain.ExportOptions) (*domain.ExportTable, error) {
+	report, err := s.incomeService.GetIncome(ctx, userID, portfolioID, options.From, options.To)
+	if err != nil {
+		return nil, err
+	}
+
+	uids := make([]string, 0, len(report.Payments))
+	for _, payment := range report.Payments {
+		uids = append(uids, payment.InstrumentUid)
+	}
+
+	instruments, err := s.instrumentsByUids(ctx, uids)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]exportItem[*domain.IncomePayment], 0, len(report.Payments))
+	for _, payment := range report.Payments {
+		items = append(items, exportItem[*domain.IncomePayment]{item: payment, instrument: instruments[payment.InstrumentUid]})
+	}
+
+	return buildExportTable(models.ExportDatasetIncome, incomeExportColumns, items, options.Columns)
+}
+
+// Сводные показатели: оценка, доходность за период и доли валют
+func (s *exportService) exportAnalytics(ctx context.Context, userID, portfolioID string, options *domain.ExportOptions) (*domain.ExportTable, error) {
+	valuation, err := s.valuationService.GetValuation(ctx, userID, portfolioID, options.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	performance, err := s.performanceService.GetPerformance(ctx, userID, portfolioID, options.From, options.To)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := []analyticsMetric{
+		{"totalValue", "Стоимость портфеля", valuation.TotalValue, valuation.Currency},
+		{"investedAmount", "Вложено", valuation.InvestedAmount, valuation.Currency},
+		{"expectedYield", "Доход", valuation.ExpectedYield, valuation.Currency},
+		{"yieldPercent", "Доходность, %", valuation.YieldPercent, ""},
+		{"periodFrom", "Начало периода", performance.From, ""},
+		{"periodTo", "Конец периода", performance.To, ""},
+		{"startValue", "Стоимость на начало периода", performance.StartValue, performance.Currency},
+		{"endValue", "Стоимость на конец периода", performance.EndValue, performance.Currency},
+		{"netContributions", "Пополнения за вычетом выводов", performance.NetContributions, performance.Currency},
+		{"gain", "Результат за период", performance.Gain, performance.Currency},
+		{"twr", "TWR, %", performance.Twr, ""},
+		{"twrAnnualized", "TWR годовых, %", performance.TwrAnnualized, ""},
+		{"xirr", "XIRR, %", performance.Xirr, ""},
+	}
+
+	for _, exposure := range valuation.Exposure {
+		metrics = append(metrics, analyticsMetric{
+			key:      "exposure." + exposure.Currency,
+			title:    "Доля валюты " + exposure.Currency + ", %",
+			value:    exposure.SharePercent,
+			currency: "",
+		})
+	}
+
+	return buildExportTable(models.ExportDatasetAnalytics, analyticsExportColumns, metrics, options.Columns)
+}
+
+// Инструменты справочника по uid без повторов
+func (s *exportService) instrumentsByUids(ctx context.Context, uids []string) (map[string]assetsDomain.Instrument, error) {
+	if len(uids) == 0 {
+		return map[string]assetsDomain.Instrument{}, nil
+	}
+
+	seen := make(map[string]bool, len(uids))
+	unique := make([]string, 0, len(uids))
+	for _, uid := range uids {
+		if !seen[uid] {
+			seen[uid] = true
+			unique = append(unique, uid)
+		}
+	}
+
+	return s.instruments.GetInstrumentsByUids(ctx, unique)
+}
+
+// Построение таблицы из готового списка строк и выбранных колонок
+func buildExportTable[T any](dataset models.ExportDataset, available []exportColumn[T], items []T, keys []string) (*domain.ExportTable, error) {
+	selected, err := selectExportColumns(dataset, available, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	each := func(yield func(T) error) error {
+		for _, item := range items {
+			if err := yield(item); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return newExportTable(dataset, selected, each), nil
+}
+
+// Таблица, строки которой строятся из данных по мере обхода
+func newExportTable[T any](dataset models.ExportDataset, selected []exportColumn[T], each func(yield func(T) error) error) *domain.ExportTable {
+	table := &domain.ExportTable{
+		Dataset: dataset,
+		Columns: make([]domain.ExportColumn, 0, len(selected)),
+	}
+
+	for _, column := range selected {
+		table.Columns = append(table.Columns, domain.ExportColumn{Key: column.key, Title: column.title})
+	}
+
+	table.Rows = func(emit func(row []any) error) error {
+		return each(func(item T) error {
+			row := make([]any, 0, len(selected))
+			for _, column := range selected {
+				row = append(row, column.value(item))
+			}
+
+			return emit(row)
+		})
+	}
+
+	return table
+}
+
+// Выбор колонок по ключам в заданном порядке (все колонки, если ключи не заданы)
+func selectExportColumns[T any](dataset models.ExportDataset, available []exportColumn[T], keys []string) ([]exportColumn[T], error) {
+	if len(keys) == 0 {
+		return available, nil
+	}
+
+	byKey := make(map[string]exportColumn[T], len(available))
+	for _, column := range available {
+		byKey[strings.ToLower(column.key)] = column
+	}
+
+	selected := make([]exportColumn[T], 0, len(keys))
+	used := make(map[string]bool, len(keys))
+
+	for _, key := range keys {
+		key = strings.ToLower(strings.TrimSpace(key))
+
+		column, ok := byKey[key]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown column %q for %s", models.ErrInvalidRequest, key, strings.ToLower(string(dataset)))
+		}
+		if used[key] {
+			return nil, fmt.Errorf("%w: column %q is repeated", models.ErrInvalidRequest, key)
+		}
+
+		used[key] = true
+		selected = append(selected, column)
+	}
+
+	return selected, nil
+}
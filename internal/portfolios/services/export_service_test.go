@@ -0,0 +1,43 @@
+package services
+
+import (
+	"slices"
+	"testing"
+
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+)
+
+func TestExportTransactionsOfCompositePortfolio(t *testing.T) {
+	deposit := func(id, portfolioID string) *domain.Transaction {
+		return &domain.Transaction{ID: id, PortfolioID: portfolioID, Type: models.TransactionTypeDeposit, Amount: 100, ExecutedAt: date(2024, 1, 10)}
+	}
+
+	service := NewExportService(
+		newMemoryPortfolios(
+			&domain.Portfolio{ID: "composite", UserId: "user-1", IsComposite: true},
+			&domain.Portfolio{ID: "child", UserId: "user-1"},
+		),
+		&memoryTree{children: map[string][]string{"composite": {"child"}}},
+		&memoryLedger{transactions: []*domain.Transaction{deposit("child-deposit", "child"), deposit("other-deposit", "other")}},
+		nil, nil, nil,
+		newMemoryInstruments(),
+	)
+
+	table, err := service.Export(t.Context(), "user-1", "composite", models.ExportDatasetTransactions, &domain.ExportOptions{Columns: []string{"id", "portfolioId"}})
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	rows := make([][]any, 0)
+	if err := table.Rows(func(row []any) error {
+		rows = append(rows, row)
+		return nil
+	}); err != nil {
+		t.Fatalf("Rows: %v", err)
+	}
+
+	if len(rows) != 1 || !slices.Equal(rows[0], []any{"child-deposit", "child"}) {
+		t.Errorf("rows = %v, want the deposit of the nested portfolio", rows)
+	}
+}
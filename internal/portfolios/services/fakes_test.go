@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	assetsDomain "invest-mate/internal/assets/models/domain"
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/internal/portfolios/repository"
+)
+
+// Портфели пользователя в памяти; остальные методы сервиса в тестах не вызываются
+type memoryPortfolios struct {
+	PortfoliosService
+	portfolios map[string]*domain.Portfolio
+}
+
+func newMemoryPortfolios(portfolios ...*domain.Portfolio) *memoryPortfolios {
+	result := &memoryPortfolios{portfolios: make(map[string]*domain.Portfolio)}
+	for _, portfolio := range portfolios {
+		result.portfolios[portfolio.ID] = portfolio
+	}
+	return result
+}
+
+func (s *memoryPortfolios) GetPortfolio(ctx context.Context, userID, id string) (*domain.Portfolio, error) {
+	portfolio, ok := s.portfolios[id]
+	if !ok {
+		return nil, models.ErrPortfolioNotFound
+	}
+	if portfolio.UserId != userID {
+		return nil, models.ErrPortfolioAccessDenied
+	}
+	return portfolio, nil
+}
+
+// Вложенные портфели составных портфелей в памяти
+type memoryTree struct {
+	CompositeService
+	children map[string][]string
+}
+
+func (s *memoryTree) GetTreePortfolioIDs(ctx context.Context, rootID string) ([]string, error) {
+	return append([]string{rootID}, s.children[rootID]...), nil
+}
+
+// Позиции в памяти с той же семантикой замены позиций счёта, что и в БД
+type memoryPositions struct {
+	repository.PositionsRepository
+	positions []*domain.Position
+	next      int
+}
+
+func (r *memoryPositions) GetByPortfolio(ctx context.Context, portfolioID string) ([]*domain.Position, error) {
+	result := make([]*domain.Position, 0)
+	for _, position := range r.positions {
+		if position.PortfolioID == portfolioID {
+			copied := *position
+			result = append(result, &copied)
+		}
+	}
+	return result, nil
+}
+
+func (r *memoryPositions) ReplaceBrokerPositions(ctx context.Context, portfolioID, broker, accountID string, positions []*domain.Position) (int64, error) {
+	owned := func(position *domain.Position) bool {
+		return position.PortfolioID == portfolioID && !position.IsCustomData && position.Broker == broker &&
+			(position.BrokerAccountID == accountID || position.BrokerAccountID == "")
+	}
+
+	uids := make([]string, 0, len(positions))
+	for _, incoming := range positions {
+		uids = append(uids, incoming.InstrumentUid)
+
+		index := slices.IndexFunc(r.positions, func(position *domain.Position) bool {
+			return position.PortfolioID == portfolioID && position.InstrumentUid == incoming.InstrumentUid
+		})
+
+		copied := *incoming
+		switch {
+		case index < 0:
+			r.next++
+			copied.ID = fmt.Sprintf("position-%d", r.next)
+			r.positions = append(r.positions, &copied)
+		case owned(r.positions[index]):
+			copied.ID = r.positions[index].ID
+			r.positions[index] = &copied
+		}
+	}
+
+	var removed int64
+	r.positions = slices.DeleteFunc(r.positions, func(position *domain.Position) bool {
+		stale := owned(position) && !slices.Contains(uids, position.InstrumentUid)
+		if stale {
+			removed++
+		}
+		return stale
+	})
+
+	return removed, nil
+}
+
+// Журнал операций в памяти
+type memoryLedger struct {
+	repository.TransactionsRepository
+	transactions []*domain.Transaction
+}
+
+func (r *memoryLedger) GetByPortfolio(ctx context.Context, portfolioID string, filter *domain.TransactionFilter, limit, offset int) ([]*domain.Transaction, error) {
+	result := make([]*domain.Transaction, 0)
+	for _, transaction := range r.transactions {
+		if transaction.PortfolioID == portfolioID {
+			result = append(result, transaction)
+		}
+	}
+	return result, nil
+}
+
+func (r *memoryLedger) GetByPortfolios(ctx context.Context, portfolioIDs []string) ([]*domain.Transaction, error) {
+	result := make([]*domain.Transaction, 0)
+	for _, transaction := range r.transactions {
+		if slices.Contains(portfolioIDs, transaction.PortfolioID) {
+			result = append(result, transaction)
+		}
+	}
+	return result, nil
+}
+
+func (r *memoryLedger) EachByPortfolios(ctx context.Context, portfolioIDs []string, filter *domain.TransactionFilter, batchSize int, fn func([]*domain.Transaction) error) error {
+	transactions, _ := r.GetByPortfolios(ctx, portfolioIDs)
+	for start := 0; start < len(transactions); start += batchSize {
+		if err := fn(transactions[start:min(start+batchSize, len(transactions))]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Справочник инструментов в памяти
+type memoryInstruments struct {
+	instruments map[string]assetsDomain.Instrument
+}
+
+func newMemoryInstruments(instruments ...assetsDomain.Instrument) *memoryInstruments {
+	result := &memoryInstruments{instruments: make(map[string]assetsDomain.Instrument)}
+	for _, instrument := range instruments {
+		result.instruments[instrument.Uid] = instrument
+	}
+	return result
+}
+
+func (r *memoryInstruments) FindInstrument(ctx context.Context, fieldName string, fieldValue string) (*assetsDomain.Instrument, error) {
+	for _, instrument := range r.instruments {
+		if (fieldName == "uid" && instrument.Uid == fieldValue) ||
+			(fieldName == "figi" && instrument.Figi == fieldValue) ||
+			(fieldName == "ticker" && instrument.Ticker == fieldValue) {
+			return &instrument, nil
+		}
+	}
+	return nil, models.ErrInstrumentNotFound
+}
+
+func (r *memoryInstruments) GetInstrumentsByUids(ctx context.Context, uids []string) (map[string]assetsDomain.Instrument, error) {
+	result := make(map[string]assetsDomain.Instrument)
+	for _, uid := range uids {
+		if instrument, ok := r.instruments[uid]; ok {
+			result[uid] = instrument
+		}
+	}
+	return result, nil
+}
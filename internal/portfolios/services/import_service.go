@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"invest-mate/internal/portfolios/mappers"
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/internal/portfolios/repository"
+	usersDomain "invest-mate/internal/users/models/domain"
+	"invest-mate/pkg/logger"
+)
+
+// Источник брокерских портфелей (реализуется API модуля пользователей)
+type BrokerPortfolioSource interface {
+	GetAccounts(ctx context.Context, token string) ([]*usersDomain.BrokerAccount, error)
+	GetPortfolio(ctx context.Context, token, accountID, currency string) (*usersDomain.BrokerPortfolio, error)
+}
+
+type ImportService interface {
+	GetBrokerAccounts(ctx context.Context, req *domain.BrokerAccountsRequest) ([]*usersDomain.BrokerAccount, error)
+	ImportFromBroker(ctx context.Context, userID, portfolioID string, req *domain.ImportPortfolioRequest) (*domain.ImportResult, error)
+}
+
+type importService struct {
+	portfoliosService PortfoliosService
+	positionsRepo     repository.PositionsRepository
+	instruments       InstrumentResolver
+	broker            BrokerPortfolioSource
+}
+
+// Создание нового сервиса загрузки портфелей брокера
+func NewImportService(
+	portfoliosService PortfoliosService,
+	positionsRepo repository.PositionsRepository,
+	instruments InstrumentResolver,
+	broker BrokerPortfolioSource,
+) ImportService {
+	return &importService{
+		portfoliosService: portfoliosService,
+		positionsRepo:     positionsRepo,
+		instruments:       instruments,
+		broker:            broker,
+	}
+}
+
+// Получение счетов пользователя у брокера
+func (s *importService) GetBrokerAccounts(ctx context.Context, req *domain.BrokerAccountsRequest) ([]*usersDomain.BrokerAccount, error) {
+	if strings.TrimSpace(req.Token) == "" {
+		return nil, fmt.Errorf("%w: token is required", models.ErrInvalidRequest)
+	}
+
+	accounts, err := s.broker.GetAccounts(ctx, req.Token)
+	if err != nil {
+		logger.ErrorLog("Failed to load broker accounts: %v", err)
+		return nil, models.ErrBrokerUnavailable
+	}
+
+	return accounts, nil
+}
+
+// Загрузка позиций брокерского счёта в портфель
+func (s *importService) ImportFromBroker(ctx context.Context, userID, portfolioID string, req *domain.ImportPortfolioRequest) (*domain.ImportResult, error) {
+	if strings.TrimSpace(req.Token) == "" {
+		return nil, fmt.Errorf("%w: token is required", models.ErrInvalidRequest)
+	}
+	if strings.TrimSpace(req.AccountID) == "" {
+		return nil, fmt.Errorf("%w: accountId is required", models.ErrInvalidRequest)
+	}
+
+	portfolio, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	if portfolio.IsComposite {
+		return nil, models.ErrCompositePortfolio
+	}
+
+	brokerPortfolio, err := s.broker.GetPortfolio(ctx, req.Token, req.AccountID, portfolio.Currency)
+	if err != nil {
+		logger.ErrorLog("Failed to load broker portfolio %s: %v", req.AccountID, err)
+		return nil, models.ErrBrokerUnavailable
+	}
+
+	existing, err := s.positionsRepo.GetByPortfolio(ctx, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	owners := make(map[string]*domain.Position, len(existing))
+	for _, position := range existing {
+		owners[position.InstrumentUid] = position
+	}
+
+	result := &domain.ImportResult{
+		AccountID: req.AccountID,
+		Skipped:   []string{},
+		Rejected:  []*domain.RejectedPosition{},
+	}
+
+	positions := make([]*domain.Position, 0, len(brokerPortfolio.Positions))
+	for _, brokerPosition := range brokerPortfolio.Positions {
+		// Дробное количество (например, дробные акции) не представимо в позиции,
+		// поэтому такая позиция не загружается, а попадает в отчёт
+		if !isWholeQuantity(brokerPosition) {
+			result.Rejected = append(result.Rejected, &domain.RejectedPosition{
+				Figi:     brokerPosition.Figi,
+				Ticker:   brokerPosition.Ticker,
+				Quantity: brokerPosition.Quantity,
+				Reason:   "fractional quantity",
+			})
+			continue
+		}
+
+		position := mappers.FromBrokerPositionToDomain(portfolioID, req.AccountID, brokerPosition)
+
+		if !s.fillIdentifiers(ctx, position) {
+			result.Skipped = append(result.Skipped, brokerPosition.Figi)
+			continue
+		}
+
+		// Позиция по инструменту уже ведётся вручную или загружена с другого счёта
+		if reason := importConflict(owners[position.InstrumentUid], req.AccountID); reason != "" {
+			result.Rejected = append(result.Rejected, &domain.RejectedPosition{
+				Figi:     position.Figi,
+				Ticker:   position.Ticker,
+				Quantity: brokerPosition.Quantity,
+				Reason:   reason,
+			})
+			continue
+		}
+
+		position.UpdatedAt = time.Now()
+		positions = append(positions, position)
+	}
+
+	removed, err := s.positionsRepo.ReplaceBrokerPositions(ctx, portfolioID, models.BrokerTinkoff, req.AccountID, positions)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Imported = len(positions)
+	result.Removed = removed
+
+	result.Positions, err = s.positionsRepo.GetByPortfolio(ctx, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.InfoLog("Broker account %s imported into portfolio %s: %d positions, %d removed, %d skipped, %d rejected",
+		req.AccountID, portfolioID, result.Imported, result.Removed, len(result.Skipped), len(result.Rejected))
+
+	return result, nil
+}
+
+// Заполнение отсутствующих идентификаторов инструмента из хранилища
+func (s *importService) fillIdentifiers(ctx context.Context, position *domain.Position) bool {
+	if position.InstrumentUid != "" && position.Figi != "" && position.Ticker != "" {
+		return true
+	}
+
+	instrument, err := resolveInstrument(ctx, s.instruments, position.InstrumentUid, position.Figi, position.Ticker)
+	if err != nil {
+		// Инструмента нет в хранилище: позицию можно сохранить только при известном uid
+		return position.InstrumentUid != ""
+	}
+
+	if position.InstrumentUid == "" {
+		position.InstrumentUid = instrument.Uid
+	}
+	if position.Figi == "" {
+		position.Figi = instrument.Figi
+	}
+	if position.Ticker == "" {
+		position.Ticker = instrument.Ticker
+	}
+	if position.PositionUid == "" {
+		position.PositionUid = instrument.PositionUid
+	}
+
+	return true
+}
+
+// Причина, по которой позицию счёта нельзя записать поверх существующей; пустая — конфликта нет.
+// Позиции Tinkoff без счёта загружены до появления колонки счёта и переходят к загружаемому счёту
+func importConflict(owner *domain.Position, accountID string) string {
+	switch {
+	case owner == nil:
+		return ""
+	case owner.IsCustomData:
+		return "custom position exists"
+	case owner.Broker != models.BrokerTinkoff:
+		return "position of another broker exists"
+	case owner.BrokerAccountID != "" && owner.BrokerAccountID != accountID:
+		return "position of account " + owner.BrokerAccountID + " exists"
+	}
+
+	return ""
+}
+
+// Проверка, что количество бумаг и лотов позиции целое
+func isWholeQuantity(position *usersDomain.BrokerPosition) bool {
+	for _, value := range []float64{position.Quantity, position.QuantityLots, position.BlockedLots} {
+		if value != math.Trunc(value) || math.Abs(value) > math.MaxInt32 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+	usersDomain "invest-mate/internal/users/models/domain"
+)
+
+// Брокер с фиксированными позициями по счетам
+type staticBroker struct {
+	accounts map[string][]*usersDomain.BrokerPosition
+}
+
+func (b *staticBroker) GetAccounts(ctx context.Context, token string) ([]*usersDomain.BrokerAccount, error) {
+	return nil, nil
+}
+
+func (b *staticBroker) GetPortfolio(ctx context.Context, token, accountID, currency string) (*usersDomain.BrokerPortfolio, error) {
+	return &usersDomain.BrokerPortfolio{AccountID: accountID, Positions: b.accounts[accountID]}, nil
+}
+
+func brokerPosition(uid, ticker string, quantity float64) *usersDomain.BrokerPosition {
+	return &usersDomain.BrokerPosition{
+		Figi:          "FIGI-" + ticker,
+		Ticker:        ticker,
+		InstrumentUid: uid,
+		Quantity:      quantity,
+		QuantityLots:  quantity,
+	}
+}
+
+func importPositions(t *testing.T, service ImportService, accountID string) *domain.ImportResult {
+	t.Helper()
+
+	result, err := service.ImportFromBroker(t.Context(), "user-1", "portfolio-1", &domain.ImportPortfolioRequest{Token: "token", AccountID: accountID})
+	if err != nil {
+		t.Fatalf("import %s: %v", accountID, err)
+	}
+	return result
+}
+
+func positionsByUid(positions []*domain.Position) map[string]*domain.Position {
+	result := make(map[string]*domain.Position, len(positions))
+	for _, position := range positions {
+		result[position.InstrumentUid] = position
+	}
+	return result
+}
+
+func TestImportFromBrokerKeepsOtherAccounts(t *testing.T) {
+	broker := &staticBroker{accounts: map[string][]*usersDomain.BrokerPosition{
+		"account-a": {brokerPosition("sber", "SBER", 10), brokerPosition("gazp", "GAZP", 20)},
+		"account-b": {brokerPosition("lkoh", "LKOH", 3), brokerPosition("sber", "SBER", 5)},
+	}}
+	positions := &memoryPositions{}
+	service := NewImportService(
+		newMemoryPortfolios(&domain.Portfolio{ID: "portfolio-1", UserId: "user-1", Currency: "RUB"}),
+		positions, newMemoryInstruments(), broker,
+	)
+
+	importPositions(t, service, "account-a")
+	result := importPositions(t, service, "account-b")
+
+	if result.Imported != 1 || result.Removed != 0 {
+		t.Errorf("imported = %d, removed = %d, want 1 and 0", result.Imported, result.Removed)
+	}
+
+	// SBER уже загружен со счёта A и не перезаписывается позицией счёта B
+	if len(result.Rejected) != 1 || result.Rejected[0].Ticker != "SBER" || result.Rejected[0].Reason != "position of account account-a exists" {
+		t.Errorf("rejected = %+v, want SBER held by account-a", result.Rejected)
+	}
+
+	byUid := positionsByUid(result.Positions)
+	if len(byUid) != 3 {
+		t.Fatalf("positions = %d, want 3", len(byUid))
+	}
+	for uid, want := range map[string]struct {
+		account  string
+		quantity int32
+	}{
+		"sber": {"account-a", 10},
+		"gazp": {"account-a", 20},
+		"lkoh": {"account-b", 3},
+	} {
+		got := byUid[uid]
+		if got == nil || got.BrokerAccountID != want.account || got.Quantity != want.quantity {
+			t.Errorf("position %s = %+v, want account %s quantity %d", uid, got, want.account, want.quantity)
+		}
+	}
+
+	// Повторная загрузка счёта A удаляет только его проданные позиции
+	broker.accounts["account-a"] = []*usersDomain.BrokerPosition{brokerPosition("sber", "SBER", 12)}
+	result = importPositions(t, service, "account-a")
+
+	byUid = positionsByUid(result.Positions)
+	if result.Removed != 1 || byUid["gazp"] != nil || byUid["lkoh"] == nil || byUid["sber"].Quantity != 12 {
+		t.Errorf("after reimport removed = %d, positions = %v", result.Removed, byUid)
+	}
+}
+
+func TestImportFromBrokerSkipsCustomPositions(t *testing.T) {
+	broker := &staticBroker{accounts: map[string][]*usersDomain.BrokerPosition{
+		"account-a": {brokerPosition("sber", "SBER", 10)},
+	}}
+	positions := &memoryPositions{positions: []*domain.Position{
+		{ID: "custom", PortfolioID: "portfolio-1", InstrumentUid: "sber", Ticker: "SBER", IsCustomData: true, Quantity: 7},
+		{ID: "legacy", PortfolioID: "portfolio-1", InstrumentUid: "gazp", Ticker: "GAZP", Broker: models.BrokerTinkoff, Quantity: 1},
+	}}
+	service := NewImportService(
+		newMemoryPortfolios(&domain.Portfolio{ID: "portfolio-1", UserId: "user-1", Currency: "RUB"}),
+		positions, newMemoryInstruments(), broker,
+	)
+
+	result := importPositions(t, service, "account-a")
+
+	if len(result.Rejected) != 1 || result.Rejected[0].Reason != "custom position exists" {
+		t.Errorf("rejected = %+v, want custom SBER", result.Rejected)
+	}
+
+	byUid := positionsByUid(result.Positions)
+	if sber := byUid["sber"]; sber == nil || !sber.IsCustomData || sber.Quantity != 7 {
+		t.Errorf("custom position changed: %+v", sber)
+	}
+
+	// Позиция Tinkoff без счёта считается позицией загружаемого счёта и удаляется как устаревшая
+	if result.Removed != 1 || byUid["gazp"] != nil {
+		t.Errorf("legacy position kept: removed = %d, positions = %v", result.Removed, byUid)
+	}
+}
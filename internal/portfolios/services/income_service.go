@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/internal/portfolios/repository"
+	"invest-mate/pkg/logger"
+)
+
+type IncomeService interface {
+	GetIncome(ctx context.Context, userID, portfolioID string, from, to *time.Time) (*domain.IncomeReport, error)
+	GetTaxRates(ctx context.Context, userID, portfolioID string) ([]*domain.DividendTaxRate, error)
+	SetTaxRate(ctx context.Context, userID, portfolioID, country string, req *domain.SetDividendTaxRateRequest) (*domain.DividendTaxRate, error)
+	DeleteTaxRate(ctx context.Context, userID, portfolioID, country string) (bool, error)
+}
+
+type incomeService struct {
+	portfoliosService PortfoliosService
+	transactionsRepo  repository.TransactionsRepository
+	taxRatesRepo      repository.TaxRatesRepository
+	instruments       InstrumentResolver
+}
+
+// Создание нового сервиса доходов по дивидендам и купонам
+func NewIncomeService(
+	portfoliosService PortfoliosService,
+	transactionsRepo repository.TransactionsRepository,
+	taxRatesRepo repository.TaxRatesRepository,
+	instruments InstrumentResolver,
+) IncomeService {
+	return &incomeService{
+		portfoliosService: portfoliosService,
+		transactionsRepo:  transactionsRepo,
+		taxRatesRepo:      taxRatesRepo,
+		instruments:       instruments,
+	}
+}
+
+// Получение дохода по дивидендам и купонам до и после налога за период
+func (s *incomeService) GetIncome(ctx context.Context, userID, portfolioID string, from, to *time.Time) (*domain.IncomeReport, error) {
+	portfolio, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	if portfolio.IsComposite {
+		return nil, models.ErrCompositePortfolio
+	}
+
+	payments, err := s.transactionsRepo.GetIncome(ctx, portfolioID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	rates, err := s.taxRatesRepo.GetByPortfolio(ctx, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	countries := s.issuerCountries(ctx, payments)
+
+	overrides := make(map[string]float32, len(rates))
+	for _, rate := range rates {
+		overrides[rate.Country] = rate.TaxPercent
+	}
+
+	report := &domain.IncomeReport{
+		PortfolioID:       portfolioID,
+		From:              from,
+		To:                to,
+		ApplyTaxes:        portfolio.ApplyTaxesOnPaidDividends,
+		DefaultTaxPercent: portfolio.DividendTaxPercent,
+		CouponTaxPercent:  portfolio.CouponTaxPercent,
+		TaxRates:          rates,
+		Payments:          make([]*domain.IncomePayment, 0, len(payments)),
+		Totals:            make(map[string]*domain.IncomeTotals),
+	}
+
+	for _, payment := range payments {
+		item := &domain.IncomePayment{
+			TransactionID: payment.ID,
+			Type:          payment.Type,
+			InstrumentUid: payment.InstrumentUid,
+			Ticker:        payment.Ticker,
+			Country:       countries[payment.InstrumentUid],
+			PaidAt:        payment.ExecutedAt,
+			Gross:         payment.Amount,
+			Currency:      payment.Currency,
+		}
+
+		// Без удержания налога сумма выплаты считается уже чистой
+		if portfolio.ApplyTaxesOnPaidDividends {
+			item.TaxPercent = incomeTaxPercent(portfolio, item, overrides)
+		}
+
+		item.Tax = item.Gross * float64(item.TaxPercent) / 100
+		item.Net = item.Gross - item.Tax
+
+		report.Payments = append(report.Payments, item)
+		addIncomeTotals(report.Totals, item)
+	}
+
+	return report, nil
+}
+
+// Ставка налога для выплаты: купоны облагаются по ставке купонов портфеля,
+// если она задана, иначе — как дивиденды, с учётом ставки страны эмитента
+func incomeTaxPercent(portfolio *domain.Portfolio, item *domain.IncomePayment, overrides map[string]float32) float32 {
+	if item.Type == models.TransactionTypeCoupon && portfolio.CouponTaxPercent != nil {
+		return *portfolio.CouponTaxPercent
+	}
+	if percent, ok := overrides[item.Country]; ok {
+		return percent
+	}
+
+	return portfolio.DividendTaxPercent
+}
+
+// Получение ставок налога портфеля по странам
+func (s *incomeService) GetTaxRates(ctx context.Context, userID, portfolioID string) ([]*domain.DividendTaxRate, error) {
+	if _, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID); err != nil {
+		return nil, err
+	}
+
+	return s.taxRatesRepo.GetByPortfolio(ctx, portfolioID)
+}
+
+// Установка ставки налога для эмитентов страны
+func (s *incomeService) SetTaxRate(ctx context.Context, userID, portfolioID, country string, req *domain.SetDividendTaxRateRequest) (*domain.DividendTaxRate, error) {
+	country = strings.ToUpper(country)
+
+	if err := validateCountryCode(country); err != nil {
+		return nil, err
+	}
+	if err := validateDividendTaxPercent(req.TaxPercent); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID); err != nil {
+		return nil, err
+	}
+
+	rate := &domain.DividendTaxRate{
+		Country:    country,
+		TaxPercent: req.TaxPercent,
+	}
+
+	if err := s.taxRatesRepo.Upsert(ctx, portfolioID, rate); err != nil {
+		return nil, err
+	}
+
+	logger.InfoLog("Dividend tax rate for %s set to %.2f%% in portfolio %s", country, req.TaxPercent, portfolioID)
+
+	return rate, nil
+}
+
+// Удаление ставки налога для эмитентов страны
+func (s *incomeService) DeleteTaxRate(ctx context.Context, userID, portfolioID, country string) (bool, error) {
+	if _, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID); err != nil {
+		return false, err
+	}
+
+	deleted, err := s.taxRatesRepo.Delete(ctx, portfolioID, strings.ToUpper(country))
+	if err != nil {
+		return false, err
+	}
+
+	if !deleted {
+		return false, models.ErrTaxRateNotFound
+	}
+
+	return true, nil
+}
+
+// Страны риска эмитентов выплат
+func (s *incomeService) issuerCountries(ctx context.Context, payments []*domain.Transaction) map[string]string {
+	uids := make([]string, 0, len(payments))
+	seen := make(map[string]bool)
+
+	for _, payment := range payments {
+		if payment.InstrumentUid != "" && !seen[payment.InstrumentUid] {
+			seen[payment.InstrumentUid] = true
+			uids = append(uids, payment.InstrumentUid)
+		}
+	}
+
+	countries := make(map[string]string, len(uids))
+	if len(uids) == 0 {
+		return countries
+	}
+
+	instruments, err := s.instruments.GetInstrumentsByUids(ctx, uids)
+	if err != nil {
+		// Без сведений об инструментах применяется ставка портфеля по умолчанию
+		logger.ErrorLog("Failed to load instruments for income report: %v", err)
+		return countries
+	}
+
+	for uid, instrument := range instruments {
+		countries[uid] = strings.ToUpper(instrument.CountryOfRisk)
+	}
+
+	return countries
+}
+
+// Добавление выплаты в итоги по её валюте
+func addIncomeTotals(totals map[string]*domain.IncomeTotals, payment *domain.IncomePayment) {
+	total, ok := totals[payment.Currency]
+	if !ok {
+		total = &domain.IncomeTotals{}
+		totals[payment.Currency] = total
+	}
+
+	switch payment.Type {
+	case models.TransactionTypeDividend:
+		total.DividendsGross += payment.Gross
+		total.DividendsNet += payment.Net
+	case models.TransactionTypeCoupon:
+		total.CouponsGross += payment.Gross
+		total.CouponsNet += payment.Net
+	}
+
+	total.Gross += payment.Gross
+	total.Tax += payment.Tax
+	total.Net += payment.Net
+}
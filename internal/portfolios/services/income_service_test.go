@@ -0,0 +1,61 @@
+package services
+
+import (
+	"testing"
+
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+)
+
+func TestIncomeTaxPercent(t *testing.T) {
+	couponRate := float32(0)
+	overrides := map[string]float32{"US": 10}
+
+	tests := []struct {
+		name      string
+		portfolio *domain.Portfolio
+		item      *domain.IncomePayment
+		want      float32
+	}{
+		{
+			name:      "dividend uses the portfolio rate",
+			portfolio: &domain.Portfolio{DividendTaxPercent: 13},
+			item:      &domain.IncomePayment{Type: models.TransactionTypeDividend, Country: "RU"},
+			want:      13,
+		},
+		{
+			name:      "dividend uses the issuer country rate",
+			portfolio: &domain.Portfolio{DividendTaxPercent: 13},
+			item:      &domain.IncomePayment{Type: models.TransactionTypeDividend, Country: "US"},
+			want:      10,
+		},
+		{
+			// Без ставки купонов купоны облагаются как дивиденды
+			name:      "coupon falls back to the dividend rate",
+			portfolio: &domain.Portfolio{DividendTaxPercent: 13},
+			item:      &domain.IncomePayment{Type: models.TransactionTypeCoupon, Country: "US"},
+			want:      10,
+		},
+		{
+			// Нулевая ставка купонов задана явно и не заменяется ставкой дивидендов или страны
+			name:      "coupon uses the coupon rate",
+			portfolio: &domain.Portfolio{DividendTaxPercent: 13, CouponTaxPercent: &couponRate},
+			item:      &domain.IncomePayment{Type: models.TransactionTypeCoupon, Country: "US"},
+			want:      0,
+		},
+		{
+			name:      "dividend ignores the coupon rate",
+			portfolio: &domain.Portfolio{DividendTaxPercent: 13, CouponTaxPercent: &couponRate},
+			item:      &domain.IncomePayment{Type: models.TransactionTypeDividend, Country: "RU"},
+			want:      13,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := incomeTaxPercent(tt.portfolio, tt.item, overrides); got != tt.want {
+				t.Errorf("tax percent = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
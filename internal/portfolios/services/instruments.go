@@ -0,0 +1,29 @@
+package services
+
+import (
+	"context"
+
+	assetsDomain "invest-mate/internal/assets/models/domain"
+	"invest-mate/internal/portfolios/models"
+	sharedInstruments "invest-mate/internal/shared/instruments"
+)
+
+// Источник сведений об инструментах (реализуется хранилищем модуля активов)
+type InstrumentResolver interface {
+	FindInstrument(ctx context.Context, fieldName string, fieldValue string) (*assetsDomain.Instrument, error)
+	GetInstrumentsByUids(ctx context.Context, uids []string) (map[string]assetsDomain.Instrument, error)
+}
+
+// Поиск инструмента по uid, figi или тикеру (в порядке приоритета)
+func resolveInstrument(ctx context.Context, resolver InstrumentResolver, uid, figi, ticker string) (*assetsDomain.Instrument, error) {
+	return sharedInstruments.Resolve(ctx, resolver, uid, figi, ticker, models.ErrInvalidRequest, models.ErrInstrumentNotFound)
+}
+
+// Расчёт количества лотов
+func quantityToLots(quantity int32, lot int) int32 {
+	if lot <= 0 {
+		return quantity
+	}
+
+	return quantity / int32(lot)
+}
@@ -0,0 +1,282 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+)
+
+// Позиция, восстановленная по журналу сделок
+type ledgerPosition struct {
+	Quantity     int32
+	AveragePrice float64
+	OpenLots     []*domain.OpenLot
+	Realized     []*domain.RealizedTrade
+}
+
+// Открытая партия внутри движка (с исходной комиссией для пропорционального списания)
+type engineLot struct {
+	lot               *domain.OpenLot
+	initialQuantity   int32
+	initialCommission float64
+}
+
+// Движок партий: хранит открытые партии по инструменту и закрывает их продажами
+type lotEngine struct {
+	method models.CostMethod
+
+	lots     []*engineLot
+	quantity int32
+
+	// Суммы по открытой позиции для средневзвешенной цены
+	totalPrice float64
+	totalCost  float64
+
+	realized []*domain.RealizedTrade
+}
+
+// Создание движка партий с выбранным способом списания
+func newLotEngine(method models.CostMethod) *lotEngine {
+	return &lotEngine{method: method}
+}
+
+// Открытие партии покупкой
+func (e *lotEngine) buy(trade *domain.Transaction) {
+	e.lots = append(e.lots, &engineLot{
+		lot: &domain.OpenLot{
+			TransactionID: trade.ID,
+			InstrumentUid: trade.InstrumentUid,
+			Ticker:        trade.Ticker,
+			OpenedAt:      trade.ExecutedAt,
+			Quantity:      trade.Quantity,
+			Price:         trade.Price,
+			Commission:    trade.Commission,
+			Currency:      trade.Currency,
+		},
+		initialQuantity:   trade.Quantity,
+		initialCommission: trade.Commission,
+	})
+
+	e.quantity += trade.Quantity
+	e.totalPrice += trade.Price * float64(trade.Quantity)
+	e.totalCost += trade.Price*float64(trade.Quantity) + trade.Commission
+}
+
+// Закрытие партий продажей с расчётом финансового результата
+func (e *lotEngine) sell(trade *domain.Transaction) error {
+	if trade.Quantity > e.quantity {
+		return fmt.Errorf("%w: %d of %d on %s",
+			models.ErrInsufficientQuantity, trade.Quantity, e.quantity, trade.ExecutedAt.Format("2006-01-02"))
+	}
+
+	var matches []*domain.LotMatch
+	var err error
+
+	if e.method == models.CostMethodSpecific && len(trade.Lots) > 0 {
+		matches, err = e.matchSpecific(trade)
+	} else {
+		matches = e.matchFifo(trade.Quantity)
+	}
+	if err != nil {
+		return err
+	}
+
+	share := float64(trade.Quantity) / float64(e.quantity)
+	averageCost := e.totalCost * share
+
+	e.quantity -= trade.Quantity
+	e.totalPrice -= e.totalPrice * share
+	e.totalCost -= averageCost
+	e.compactLots()
+
+	costBasis := averageCost
+	if e.method != models.CostMethodAverage {
+		costBasis = 0
+		for _, match := range matches {
+			costBasis += match.CostBasis
+		}
+	}
+
+	proceeds := trade.Price * float64(trade.Quantity)
+
+	e.realized = append(e.realized, &domain.RealizedTrade{
+		TransactionID: trade.ID,
+		InstrumentUid: trade.InstrumentUid,
+		Ticker:        trade.Ticker,
+		ClosedAt:      trade.ExecutedAt,
+		Quantity:      trade.Quantity,
+		Price:         trade.Price,
+		Proceeds:      proceeds,
+		Commission:    trade.Commission,
+		CostBasis:     costBasis,
+		RealizedPnL:   proceeds - trade.Commission - costBasis,
+		Currency:      trade.Currency,
+		Matches:       matches,
+	})
+
+	return nil
+}
+
+// Списание партий в порядке покупки
+func (e *lotEngine) matchFifo(quantity int32) []*domain.LotMatch {
+	matches := make([]*domain.LotMatch, 0)
+
+	for _, lot := range e.lots {
+		if quantity == 0 {
+			break
+		}
+		if lot.lot.Quantity == 0 {
+			continue
+		}
+
+		matched := min(lot.lot.Quantity, quantity)
+		matches = append(matches, e.take(lot, matched))
+		quantity -= matched
+	}
+
+	return matches
+}
+
+// Списание явно выбранных партий
+func (e *lotEngine) matchSpecific(trade *domain.Transaction) ([]*domain.LotMatch, error) {
+	var selected int32
+	for _, selection := range trade.Lots {
+		selected += selection.Quantity
+	}
+
+	if selected != trade.Quantity {
+		return nil, fmt.Errorf("%w: selected %d of %d", models.ErrInvalidLotSelection, selected, trade.Quantity)
+	}
+
+	matches := make([]*domain.LotMatch, 0, len(trade.Lots))
+
+	for _, selection := range trade.Lots {
+		lot := e.findLot(selection.TransactionID)
+		if lot == nil || lot.lot.Quantity < selection.Quantity {
+			return nil, fmt.Errorf("%w: lot %s is not open for %d",
+				models.ErrInvalidLotSelection, selection.TransactionID, selection.Quantity)
+		}
+
+		matches = append(matches, e.take(lot, selection.Quantity))
+	}
+
+	return matches, nil
+}
+
+// Списание части партии
+func (e *lotEngine) take(lot *engineLot, quantity int32) *domain.LotMatch {
+	commission := lot.initialCommission * float64(quantity) / float64(lot.initialQuantity)
+
+	lot.lot.Quantity -= quantity
+	lot.lot.Commission -= commission
+
+	return &domain.LotMatch{
+		TransactionID: lot.lot.TransactionID,
+		OpenedAt:      lot.lot.OpenedAt,
+		Quantity:      quantity,
+		Price:         lot.lot.Price,
+		CostBasis:     lot.lot.Price*float64(quantity) + commission,
+	}
+}
+
+// Поиск открытой партии по операции покупки
+func (e *lotEngine) findLot(transactionID string) *engineLot {
+	for _, lot := range e.lots {
+		if lot.lot.TransactionID == transactionID {
+			return lot
+		}
+	}
+
+	return nil
+}
+
+// Удаление полностью закрытых партий
+func (e *lotEngine) compactLots() {
+	open := e.lots[:0]
+	for _, lot := range e.lots {
+		if lot.lot.Quantity > 0 {
+			open = append(open, lot)
+		}
+	}
+	e.lots = open
+}
+
+// Текущее состояние позиции
+func (e *lotEngine) position() *ledgerPosition {
+	result := &ledgerPosition{
+		Quantity: e.quantity,
+		OpenLots: make([]*domain.OpenLot, 0, len(e.lots)),
+		Realized: e.realized,
+	}
+
+	if e.quantity > 0 {
+		result.AveragePrice = e.totalPrice / float64(e.quantity)
+	}
+
+	averageCost := 0.0
+	if e.quantity > 0 {
+		averageCost = e.totalCost / float64(e.quantity)
+	}
+
+	for _, lot := range e.lots {
+		open := *lot.lot
+		open.CostBasis = open.Price*float64(open.Quantity) + open.Commission
+
+		// При средневзвешенном способе стоимость всех партий одинакова
+		if e.method == models.CostMethodAverage {
+			open.CostBasis = averageCost * float64(open.Quantity)
+		}
+
+		result.OpenLots = append(result.OpenLots, &open)
+	}
+
+	return result
+}
+
+// Воспроизведение сделок по инструменту в хронологическом порядке
+func replayTrades(transactions []*domain.Transaction, method models.CostMethod) (*ledgerPosition, error) {
+	trades := make([]*domain.Transaction, 0, len(transactions))
+	for _, transaction := range transactions {
+		if transaction.Type.IsTrade() {
+			trades = append(trades, transaction)
+		}
+	}
+
+	sort.SliceStable(trades, func(i, j int) bool {
+		return trades[i].ExecutedAt.Before(trades[j].ExecutedAt)
+	})
+
+	engine := newLotEngine(method)
+
+	for _, trade := range trades {
+		switch trade.Type {
+		case models.TransactionTypeBuy:
+			engine.buy(trade)
+		case models.TransactionTypeSell:
+			if err := engine.sell(trade); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return engine.position(), nil
+}
+
+// Средняя цена открытых партий
+func averageLotPrice(lots []*domain.OpenLot) float64 {
+	var quantity int32
+	var total float64
+
+	for _, lot := range lots {
+		quantity += lot.Quantity
+		total += lot.Price * float64(lot.Quantity)
+	}
+
+	if quantity == 0 {
+		return 0
+	}
+
+	return total / float64(quantity)
+}
@@ -0,0 +1,287 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/internal/portfolios/repository"
+)
+
+const floatTolerance = 1e-9
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func trade(id string, kind models.TransactionType, executedAt time.Time, quantity int32, price, commission float64, lots ...domain.LotSelection) *domain.Transaction {
+	return &domain.Transaction{
+		ID:            id,
+		PortfolioID:   "portfolio-1",
+		Type:          kind,
+		InstrumentUid: "sber",
+		Ticker:        "SBER",
+		Quantity:      quantity,
+		Price:         price,
+		Amount:        price * float64(quantity),
+		Commission:    commission,
+		Currency:      "RUB",
+		ExecutedAt:    executedAt,
+		Lots:          lots,
+	}
+}
+
+func assertClose(t *testing.T, name string, got, want float64) {
+	t.Helper()
+
+	if math.Abs(got-want) > floatTolerance {
+		t.Errorf("%s = %v, want %v", name, got, want)
+	}
+}
+
+// Две покупки: 10 шт. по 100 с комиссией 10 и 10 шт. по 120 с комиссией 20
+func twoBuys() []*domain.Transaction {
+	return []*domain.Transaction{
+		trade("buy-1", models.TransactionTypeBuy, date(2024, 1, 10), 10, 100, 10),
+		trade("buy-2", models.TransactionTypeBuy, date(2024, 2, 10), 10, 120, 20),
+	}
+}
+
+func TestReplayTradesPartialSell(t *testing.T) {
+	type openLot struct {
+		id        string
+		quantity  int32
+		costBasis float64
+	}
+
+	tests := []struct {
+		name          string
+		method        models.CostMethod
+		lots          []domain.LotSelection
+		costBasis     float64
+		realizedPnL   float64
+		lotPrice      float64
+		matchedLots   []string
+		remainingLots []openLot
+	}{
+		{
+			// 10 шт. первой партии (1000 + 10) и 5 шт. второй (600 + половина комиссии 10)
+			name:          "fifo consumes the oldest lot first",
+			method:        models.CostMethodFifo,
+			costBasis:     1620,
+			realizedPnL:   1950 - 15 - 1620,
+			lotPrice:      120,
+			matchedLots:   []string{"buy-1", "buy-2"},
+			remainingLots: []openLot{{"buy-2", 5, 610}},
+		},
+		{
+			// Стоимость позиции 2230 на 20 шт., продано три четверти
+			name:          "average uses the weighted cost of the position",
+			method:        models.CostMethodAverage,
+			costBasis:     1672.5,
+			realizedPnL:   1950 - 15 - 1672.5,
+			lotPrice:      120,
+			matchedLots:   []string{"buy-1", "buy-2"},
+			remainingLots: []openLot{{"buy-2", 5, 557.5}},
+		},
+		{
+			// Вся вторая партия (1200 + 20) и 5 шт. первой (500 + 5)
+			name:          "specific closes the selected lots",
+			method:        models.CostMethodSpecific,
+			lots:          []domain.LotSelection{{TransactionID: "buy-2", Quantity: 10}, {TransactionID: "buy-1", Quantity: 5}},
+			costBasis:     1725,
+			realizedPnL:   1950 - 15 - 1725,
+			lotPrice:      100,
+			matchedLots:   []string{"buy-2", "buy-1"},
+			remainingLots: []openLot{{"buy-1", 5, 505}},
+		},
+		{
+			name:          "specific without selection falls back to fifo",
+			method:        models.CostMethodSpecific,
+			costBasis:     1620,
+			realizedPnL:   1950 - 15 - 1620,
+			lotPrice:      120,
+			matchedLots:   []string{"buy-1", "buy-2"},
+			remainingLots: []openLot{{"buy-2", 5, 610}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trades := append(twoBuys(), trade("sell-1", models.TransactionTypeSell, date(2024, 3, 1), 15, 130, 15, tt.lots...))
+
+			position, err := replayTrades(trades, tt.method)
+			if err != nil {
+				t.Fatalf("replayTrades: %v", err)
+			}
+
+			if position.Quantity != 5 {
+				t.Errorf("quantity = %d, want 5", position.Quantity)
+			}
+			// Средняя цена позиции всегда средневзвешенная, цена открытых партий зависит от способа списания
+			assertClose(t, "average price", position.AveragePrice, 110)
+			assertClose(t, "open lots price", averageLotPrice(position.OpenLots), tt.lotPrice)
+
+			if len(position.Realized) != 1 {
+				t.Fatalf("realized trades = %d, want 1", len(position.Realized))
+			}
+
+			realized := position.Realized[0]
+			assertClose(t, "proceeds", realized.Proceeds, 1950)
+			assertClose(t, "cost basis", realized.CostBasis, tt.costBasis)
+			assertClose(t, "realized pnl", realized.RealizedPnL, tt.realizedPnL)
+
+			if len(realized.Matches) != len(tt.matchedLots) {
+				t.Fatalf("matches = %d, want %d", len(realized.Matches), len(tt.matchedLots))
+			}
+			for i, id := range tt.matchedLots {
+				if realized.Matches[i].TransactionID != id {
+					t.Errorf("match %d = %s, want %s", i, realized.Matches[i].TransactionID, id)
+				}
+			}
+
+			if len(position.OpenLots) != len(tt.remainingLots) {
+				t.Fatalf("open lots = %d, want %d", len(position.OpenLots), len(tt.remainingLots))
+			}
+			for i, want := range tt.remainingLots {
+				got := position.OpenLots[i]
+				if got.TransactionID != want.id || got.Quantity != want.quantity {
+					t.Errorf("open lot %d = %s x%d, want %s x%d", i, got.TransactionID, got.Quantity, want.id, want.quantity)
+				}
+				assertClose(t, "open lot cost basis", got.CostBasis, want.costBasis)
+			}
+		})
+	}
+}
+
+func TestReplayTradesConsumesLotInSeveralSells(t *testing.T) {
+	trades := append(twoBuys(),
+		trade("sell-1", models.TransactionTypeSell, date(2024, 3, 1), 15, 130, 15),
+		trade("sell-2", models.TransactionTypeSell, date(2024, 4, 1), 3, 140, 3),
+	)
+
+	position, err := replayTrades(trades, models.CostMethodFifo)
+	if err != nil {
+		t.Fatalf("replayTrades: %v", err)
+	}
+
+	// Остаток второй партии делится дальше: 3 из 5 шт. с комиссией 20 * 3 / 10
+	second := position.Realized[1]
+	assertClose(t, "second cost basis", second.CostBasis, 360+6)
+	assertClose(t, "second realized pnl", second.RealizedPnL, 420-3-366)
+
+	if len(position.OpenLots) != 1 || position.OpenLots[0].Quantity != 2 {
+		t.Fatalf("open lots = %+v, want 2 of buy-2", position.OpenLots)
+	}
+	assertClose(t, "remaining commission", position.OpenLots[0].Commission, 4)
+	assertClose(t, "remaining cost basis", position.OpenLots[0].CostBasis, 244)
+}
+
+func TestReplayTradesErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		method models.CostMethod
+		sell   *domain.Transaction
+		want   error
+	}{
+		{
+			name:   "oversell",
+			method: models.CostMethodFifo,
+			sell:   trade("sell-1", models.TransactionTypeSell, date(2024, 3, 1), 25, 130, 0),
+			want:   models.ErrInsufficientQuantity,
+		},
+		{
+			name:   "selection does not add up to the sell",
+			method: models.CostMethodSpecific,
+			sell:   trade("sell-1", models.TransactionTypeSell, date(2024, 3, 1), 5, 130, 0, domain.LotSelection{TransactionID: "buy-1", Quantity: 4}),
+			want:   models.ErrInvalidLotSelection,
+		},
+		{
+			name:   "selected lot is too small",
+			method: models.CostMethodSpecific,
+			sell:   trade("sell-1", models.TransactionTypeSell, date(2024, 3, 1), 12, 130, 0, domain.LotSelection{TransactionID: "buy-1", Quantity: 12}),
+			want:   models.ErrInvalidLotSelection,
+		},
+		{
+			name:   "selected lot does not exist",
+			method: models.CostMethodSpecific,
+			sell:   trade("sell-1", models.TransactionTypeSell, date(2024, 3, 1), 1, 130, 0, domain.LotSelection{TransactionID: "missing", Quantity: 1}),
+			want:   models.ErrInvalidLotSelection,
+		},
+		{
+			// Продажа раньше покупки: сделки воспроизводятся по дате, а не по порядку ввода
+			name:   "sell before the lot is bought",
+			method: models.CostMethodFifo,
+			sell:   trade("sell-1", models.TransactionTypeSell, date(2024, 1, 1), 1, 130, 0),
+			want:   models.ErrInsufficientQuantity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := replayTrades(append(twoBuys(), tt.sell), tt.method)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// Сделки портфеля в памяти
+type memoryTrades struct {
+	repository.TransactionsRepository
+	trades []*domain.Transaction
+}
+
+func (r *memoryTrades) GetTrades(ctx context.Context, portfolioID, instrumentUid string) ([]*domain.Transaction, error) {
+	return r.trades, nil
+}
+
+func TestRealizedReportPeriodEndIsExclusive(t *testing.T) {
+	trades := append(twoBuys(),
+		trade("sell-1", models.TransactionTypeSell, date(2024, 3, 1), 15, 130, 15),
+		trade("sell-2", models.TransactionTypeSell, date(2024, 4, 1), 3, 140, 3),
+	)
+
+	service := NewLotsService(
+		newMemoryPortfolios(&domain.Portfolio{ID: "portfolio-1", UserId: "user-1"}),
+		&memoryTrades{trades: trades},
+	)
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     []string
+	}{
+		{"start is inclusive and end is exclusive", date(2024, 3, 1), date(2024, 4, 1), []string{"sell-1"}},
+		{"adjacent period picks up the boundary sell", date(2024, 4, 1), date(2024, 5, 1), []string{"sell-2"}},
+		{"period before any sell", date(2024, 1, 1), date(2024, 3, 1), []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := service.GetRealizedReport(t.Context(), "user-1", "portfolio-1", models.CostMethodFifo, "", &tt.from, &tt.to)
+			if err != nil {
+				t.Fatalf("GetRealizedReport: %v", err)
+			}
+
+			if len(report.Trades) != len(tt.want) {
+				t.Fatalf("trades = %d, want %d", len(report.Trades), len(tt.want))
+			}
+			for i, id := range tt.want {
+				if report.Trades[i].TransactionID != id {
+					t.Errorf("trade %d = %s, want %s", i, report.Trades[i].TransactionID, id)
+				}
+			}
+
+			// Партии сопоставляются по всей истории: результат второй продажи не зависит от периода
+			if len(tt.want) == 1 && tt.want[0] == "sell-2" {
+				assertClose(t, "realized pnl", report.Totals["RUB"].RealizedPnL, 420-3-366)
+			}
+		})
+	}
+}
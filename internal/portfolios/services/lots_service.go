@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/internal/portfolios/repository"
+)
+
+type LotsService interface {
+	GetOpenLots(ctx context.Context, userID, portfolioID string, method models.CostMethod, instrumentUid string) (*domain.OpenLotsReport, error)
+	GetRealizedReport(ctx context.Context, userID, portfolioID string, method models.CostMethod, instrumentUid string, from, to *time.Time) (*domain.RealizedReport, error)
+}
+
+type lotsService struct {
+	portfoliosService PortfoliosService
+	transactionsRepo  repository.TransactionsRepository
+}
+
+// Создание нового сервиса партий
+func NewLotsService(portfoliosService PortfoliosService, transactionsRepo repository.TransactionsRepository) LotsService {
+	return &lotsService{
+		portfoliosService: portfoliosService,
+		transactionsRepo:  transactionsRepo,
+	}
+}
+
+// Получение открытых партий портфеля
+func (s *lotsService) GetOpenLots(ctx context.Context, userID, portfolioID string, method models.CostMethod, instrumentUid string) (*domain.OpenLotsReport, error) {
+	positions, err := s.replayPortfolio(ctx, userID, portfolioID, method, instrumentUid)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.OpenLotsReport{
+		PortfolioID: portfolioID,
+		Method:      method,
+		Lots:        make([]*domain.OpenLot, 0),
+	}
+
+	for _, position := range positions {
+		report.Lots = append(report.Lots, position.OpenLots...)
+	}
+
+	sort.SliceStable(report.Lots, func(i, j int) bool {
+		return report.Lots[i].OpenedAt.Before(report.Lots[j].OpenedAt)
+	})
+
+	return report, nil
+}
+
+// Получение реализованного финансового результата за период
+func (s *lotsService) GetRealizedReport(ctx context.Context, userID, portfolioID string, method models.CostMethod, instrumentUid string, from, to *time.Time) (*domain.RealizedReport, error) {
+	positions, err := s.replayPortfolio(ctx, userID, portfolioID, method, instrumentUid)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.RealizedReport{
+		PortfolioID: portfolioID,
+		Method:      method,
+		From:        from,
+		To:          to,
+		Trades:      make([]*domain.RealizedTrade, 0),
+		Totals:      make(map[string]*domain.RealizedTotals),
+	}
+
+	// Партии сопоставляются по всей истории, а в отчёт попадают только продажи за период;
+	// граница to не включается, как и в журнале операций
+	for _, position := range positions {
+		for _, trade := range position.Realized {
+			if from != nil && trade.ClosedAt.Before(*from) {
+				continue
+			}
+			if to != nil && !trade.ClosedAt.Before(*to) {
+				continue
+			}
+
+			report.Trades = append(report.Trades, trade)
+
+			totals, ok := report.Totals[trade.Currency]
+			if !ok {
+				totals = &domain.RealizedTotals{}
+				report.Totals[trade.Currency] = totals
+			}
+
+			totals.Proceeds += trade.Proceeds
+			totals.Commission += trade.Commission
+			totals.CostBasis += trade.CostBasis
+			totals.RealizedPnL += trade.RealizedPnL
+		}
+	}
+
+	sort.SliceStable(report.Trades, func(i, j int) bool {
+		return report.Trades[i].ClosedAt.Before(report.Trades[j].ClosedAt)
+	})
+
+	return report, nil
+}
+
+// Воспроизведение сделок портфеля по каждому инструменту выбранным способом
+func (s *lotsService) replayPortfolio(ctx context.Context, userID, portfolioID string, method models.CostMethod, instrumentUid string) ([]*ledgerPosition, error) {
+	portfolio, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	if portfolio.IsComposite {
+		return nil, models.ErrCompositePortfolio
+	}
+
+	trades, err := s.transactionsRepo.GetTrades(ctx, portfolioID, instrumentUid)
+	if err != nil {
+		return nil, err
+	}
+
+	order := make([]string, 0)
+	byInstrument := make(map[string][]*domain.Transaction)
+
+	for _, trade := range trades {
+		if _, ok := byInstrument[trade.InstrumentUid]; !ok {
+			order = append(order, trade.InstrumentUid)
+		}
+		byInstrument[trade.InstrumentUid] = append(byInstrument[trade.InstrumentUid], trade)
+	}
+
+	positions := make([]*ledgerPosition, 0, len(order))
+	for _, uid := range order {
+		position, err := replayTrades(byInstrument[uid], method)
+		if err != nil {
+			return nil, err
+		}
+		positions = append(positions, position)
+	}
+
+	return positions, nil
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"math"
+	"time"
+
+	"invest-mate/pkg/finance"
+)
+
+// Подпериод для доходности, взвешенной по времени
+type twrPeriod struct {
+	StartValue float64
+	EndValue   float64
+}
+
+// Доходность, взвешенная по времени: произведение доходностей подпериодов между внешними потоками
+func timeWeightedReturn(periods []twrPeriod) (float64, bool) {
+	growth := 1.0
+	counted := false
+
+	for _, period := range periods {
+		// Подпериоды без вложенных средств не влияют на доходность
+		if period.StartValue <= 0 {
+			continue
+		}
+
+		growth *= period.EndValue / period.StartValue
+		counted = true
+	}
+
+	return growth - 1, counted
+}
+
+// Годовая доходность по периоду в днях
+func annualizeReturn(total float64, from, to time.Time) float64 {
+	days := to.Sub(from).Hours() / 24
+	if days <= 0 || total <= -1 {
+		return total
+	}
+
+	return math.Pow(1+total, finance.DaysInYear/days) - 1
+}
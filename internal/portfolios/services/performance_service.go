@@ -0,0 +1,362 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/internal/portfolios/repository"
+	"invest-mate/pkg/finance"
+	"invest-mate/pkg/logger"
+)
+
+const (
+	// Внешние потоки — пополнения и выводы, стоимость включает денежный остаток
+	cashFlowModeCash = "CASH"
+	// Пополнений нет: внешними потоками считаются покупки, продажи и выплаты
+	cashFlowModeTrades = "TRADES"
+)
+
+type PerformanceService interface {
+	GetPerformance(ctx context.Context, userID, portfolioID string, from, to *time.Time) (*domain.PortfolioPerformance, error)
+}
+
+type performanceService struct {
+	portfoliosService PortfoliosService
+	compositeService  CompositeService
+	transactionsRepo  repository.TransactionsRepository
+	positionsRepo     repository.PositionsRepository
+	rates             ExchangeRateSource
+	rateInstruments   RateInstrumentSource
+	candles           CandleSource
+}
+
+// Создание нового сервиса доходности портфелей
+func NewPerformanceService(
+	portfoliosService PortfoliosService,
+	compositeService CompositeService,
+	transactionsRepo repository.TransactionsRepository,
+	positionsRepo repository.PositionsRepository,
+	rates ExchangeRateSource,
+	rateInstruments RateInstrumentSource,
+	candles CandleSource,
+) PerformanceService {
+	return &performanceService{
+		portfoliosService: portfoliosService,
+		compositeService:  compositeService,
+		transactionsRepo:  transactionsRepo,
+		positionsRepo:     positionsRepo,
+		rates:             rates,
+		rateInstruments:   rateInstruments,
+		candles:           candles,
+	}
+}
+
+// Расчёт доходности, взвешенной по времени (TWR), и по денежным потокам (XIRR) за период.
+// Стоимость на промежуточные даты оценивается по ценам последних сделок и курсам валют на эти даты,
+// на текущий момент — по текущим ценам позиций
+func (s *performanceService) GetPerformance(ctx context.Context, userID, portfolioID string, from, to *time.Time) (*domain.PortfolioPerformance, error) {
+	portfolio, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	portfolioIDs, err := s.compositeService.GetTreePortfolioIDs(ctx, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := s.transactionsRepo.GetByPortfolios(ctx, portfolioIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	end := time.Now()
+	if to != nil {
+		end = *to
+	}
+
+	start := end
+	if from != nil {
+		start = *from
+	} else if len(transactions) > 0 && transactions[0].ExecutedAt.Before(end) {
+		start = transactions[0].ExecutedAt
+	}
+
+	if start.After(end) {
+		return nil, fmt.Errorf("%w: from must be before to", models.ErrInvalidRequest)
+	}
+
+	replay, err := s.newReplay(ctx, portfolio.Currency, transactions, end)
+	if err != nil {
+		return nil, err
+	}
+
+	performance := &domain.PortfolioPerformance{
+		PortfolioID:  portfolioID,
+		PortfolioIDs: portfolioIDs,
+		Currency:     replay.base,
+		From:         start,
+		To:           end,
+		CashFlowMode: cashFlowModeTrades,
+		Flows:        make([]*domain.PerformanceFlow, 0),
+	}
+	if replay.cashMode {
+		performance.CashFlowMode = cashFlowModeCash
+	}
+
+	index := 0
+	for ; index < len(transactions) && transactions[index].ExecutedAt.Before(start); index++ {
+		if _, err := replay.apply(transactions[index]); err != nil {
+			return nil, err
+		}
+	}
+
+	replay.at = start
+	performance.StartValue = replay.value()
+
+	periods := make([]twrPeriod, 0)
+	flows := make([]finance.Flow, 0)
+	previous := performance.StartValue
+
+	if performance.StartValue != 0 {
+		flows = append(flows, finance.Flow{Date: start, Amount: -performance.StartValue})
+	}
+
+	for ; index < len(transactions) && !transactions[index].ExecutedAt.After(end); index++ {
+		transaction := transactions[index]
+
+		replay.observePrice(transaction)
+		replay.at = transaction.ExecutedAt
+		before := replay.value()
+
+		flow, err := replay.apply(transaction)
+		if err != nil {
+			return nil, err
+		}
+
+		if flow == 0 {
+			continue
+		}
+
+		periods = append(periods, twrPeriod{StartValue: previous, EndValue: before})
+		flows = append(flows, finance.Flow{Date: transaction.ExecutedAt, Amount: -flow})
+		previous = replay.value()
+
+		performance.Flows = append(performance.Flows, &domain.PerformanceFlow{
+			Date:        transaction.ExecutedAt,
+			Amount:      flow,
+			ValueBefore: before,
+			ValueAfter:  previous,
+		})
+
+		performance.NetContributions += flow
+		performance.FlowsCount++
+	}
+
+	// Для текущего момента используются текущие цены позиций
+	if to == nil || !to.Before(time.Now()) {
+		if err := s.markToCurrentPrices(ctx, replay, portfolioIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	replay.at = end
+	performance.EndValue = replay.value()
+	performance.Gain = performance.EndValue - performance.StartValue - performance.NetContributions
+
+	periods = append(periods, twrPeriod{StartValue: previous, EndValue: performance.EndValue})
+	flows = append(flows, finance.Flow{Date: end, Amount: performance.EndValue})
+
+	if twr, ok := timeWeightedReturn(periods); ok {
+		annualized := annualizeReturn(twr, start, end) * 100
+		twr *= 100
+
+		performance.Twr = &twr
+		performance.TwrAnnualized = &annualized
+	}
+
+	if rate, err := finance.Xirr(flows); err == nil {
+		rate *= 100
+		performance.Xirr = &rate
+	}
+
+	return performance, nil
+}
+
+// Подготовка воспроизведения журнала в базовой валюте с курсами валют по дням
+func (s *performanceService) newReplay(ctx context.Context, currency string, transactions []*domain.Transaction, end time.Time) (*performanceReplay, error) {
+	replay := &performanceReplay{
+		base:       strings.ToUpper(currency),
+		quantities: make(map[string]int32),
+		prices:     make(map[string]float64),
+		currencies: make(map[string]string),
+		cash:       make(map[string]float64),
+	}
+
+	currencies := make([]string, 0)
+	seen := map[string]bool{replay.base: true}
+
+	for _, transaction := range transactions {
+		if transaction.Type.IsCashFlow() {
+			replay.cashMode = true
+		}
+
+		transactionCurrency := strings.ToUpper(transaction.Currency)
+		if transactionCurrency != "" && !seen[transactionCurrency] {
+			seen[transactionCurrency] = true
+			currencies = append(currencies, transactionCurrency)
+		}
+	}
+
+	if len(currencies) > 0 {
+		rates, err := s.rates.GetExchangeRates(ctx)
+		if err != nil {
+			logger.ErrorLog("Failed to load exchange rates: %v", err)
+			return nil, models.ErrMarketDataUnavailable
+		}
+
+		if _, ok := rates[replay.base]; !ok {
+			return nil, fmt.Errorf("%w %s", models.ErrExchangeRateNotFound, replay.base)
+		}
+
+		// Курс базовой валюты тоже нужен на каждую дату
+		currencies = append(currencies, replay.base)
+
+		replay.rates = loadRateHistory(ctx, s.rateInstruments, s.candles, rates, currencies, transactions[0].ExecutedAt, end)
+	}
+
+	return replay, nil
+}
+
+// Переоценка бумаг по текущим ценам позиций
+func (s *performanceService) markToCurrentPrices(ctx context.Context, replay *performanceReplay, portfolioIDs []string) error {
+	positions, err := s.positionsRepo.GetByPortfolios(ctx, portfolioIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, position := range positions {
+		if position.CurrentPrice > 0 {
+			replay.prices[position.InstrumentUid] = position.CurrentPrice + position.CurrentNkd
+		}
+	}
+
+	return nil
+}
+
+// Состояние портфеля при воспроизведении журнала
+type performanceReplay struct {
+	base     string
+	rates    *rateHistory
+	cashMode bool
+	// Момент, на который пересчитываются суммы и стоимость
+	at time.Time
+
+	quantities map[string]int32
+	prices     map[string]float64
+	currencies map[string]string
+	// Денежные остатки по валютам (ведутся только в режиме денежных потоков)
+	cash map[string]float64
+}
+
+// Учёт цены сделки как последней известной цены инструмента
+func (r *performanceReplay) observePrice(transaction *domain.Transaction) {
+	if transaction.Type.IsTrade() && transaction.Price > 0 {
+		r.prices[transaction.InstrumentUid] = transaction.Price
+		r.currencies[transaction.InstrumentUid] = transaction.Currency
+	}
+}
+
+// Применение операции; возвращает внешний поток в базовой валюте (вложение положительное)
+func (r *performanceReplay) apply(transaction *domain.Transaction) (float64, error) {
+	r.observePrice(transaction)
+	r.at = transaction.ExecutedAt
+
+	currency := strings.ToUpper(transaction.Currency)
+	if currency == "" {
+		currency = r.base
+	}
+
+	// Движение денег в валюте операции; в режиме сделок оно становится внешним потоком
+	var cashChange float64
+
+	switch transaction.Type {
+	case models.TransactionTypeBuy:
+		r.quantities[transaction.InstrumentUid] += transaction.Quantity
+		cashChange = -(transaction.Amount + transaction.Commission)
+	case models.TransactionTypeSell:
+		r.quantities[transaction.InstrumentUid] -= transaction.Quantity
+		cashChange = transaction.Amount - transaction.Commission
+	case models.TransactionTypeDividend, models.TransactionTypeCoupon:
+		cashChange = transaction.Amount
+	case models.TransactionTypeFee, models.TransactionTypeTax:
+		cashChange = -transaction.Amount
+	case models.TransactionTypeDeposit:
+		r.cash[currency] += transaction.Amount
+		return r.convert(transaction.Amount, currency)
+	case models.TransactionTypeWithdrawal:
+		r.cash[currency] -= transaction.Amount
+		return r.convert(-transaction.Amount, currency)
+	}
+
+	flow, err := r.convert(-cashChange, currency)
+	if err != nil {
+		return 0, err
+	}
+
+	if r.cashMode {
+		r.cash[currency] += cashChange
+		return 0, nil
+	}
+
+	return flow, nil
+}
+
+// Стоимость бумаг (и денежного остатка) в базовой валюте
+func (r *performanceReplay) value() float64 {
+	total := 0.0
+
+	// Остатки в валюте переоцениваются по курсу на момент оценки
+	for currency, amount := range r.cash {
+		value, _ := r.convert(amount, currency)
+		total += value
+	}
+
+	for uid, quantity := range r.quantities {
+		if quantity == 0 {
+			continue
+		}
+
+		// Валюта инструмента известна по сделкам, курс для неё проверен при их применении
+		value, _ := r.convert(float64(quantity)*r.prices[uid], r.currencies[uid])
+		total += value
+	}
+
+	return total
+}
+
+// Пересчёт суммы в базовую валюту по курсу на текущий момент воспроизведения
+func (r *performanceReplay) convert(amount float64, currency string) (float64, error) {
+	currency = strings.ToUpper(currency)
+
+	if amount == 0 || currency == "" || currency == r.base {
+		return amount, nil
+	}
+
+	if r.rates == nil {
+		return 0, fmt.Errorf("%w %s", models.ErrExchangeRateNotFound, currency)
+	}
+
+	rate, ok := r.rates.rate(currency, r.at)
+	if !ok {
+		return 0, fmt.Errorf("%w %s", models.ErrExchangeRateNotFound, currency)
+	}
+
+	baseRate, _ := r.rates.rate(r.base, r.at)
+
+	return amount * rate / baseRate, nil
+}
@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	assetsDomain "invest-mate/internal/assets/models/domain"
+	mdDomain "invest-mate/internal/marketdata/models/domain"
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+)
+
+func TestTimeWeightedReturn(t *testing.T) {
+	tests := []struct {
+		name    string
+		periods []twrPeriod
+		want    float64
+		counted bool
+	}{
+		{"single period", []twrPeriod{{100, 110}}, 0.1, true},
+		{"chained periods", []twrPeriod{{100, 110}, {200, 180}}, 1.1*0.9 - 1, true},
+		{"empty periods are skipped", []twrPeriod{{0, 0}, {100, 120}, {0, 50}}, 0.2, true},
+		{"total loss", []twrPeriod{{100, 0}}, -1, true},
+		{"no invested periods", []twrPeriod{{0, 0}}, 0, false},
+		{"no periods", nil, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, counted := timeWeightedReturn(tt.periods)
+			if counted != tt.counted {
+				t.Fatalf("counted = %v, want %v", counted, tt.counted)
+			}
+			assertClose(t, "twr", got, tt.want)
+		})
+	}
+}
+
+func TestAnnualizeReturn(t *testing.T) {
+	tests := []struct {
+		name     string
+		total    float64
+		from, to time.Time
+		want     float64
+	}{
+		{"two years", 0.21, date(2021, 1, 1), date(2023, 1, 1), 0.1},
+		{"one year", 0.1, date(2023, 1, 1), date(2024, 1, 1), 0.1},
+		{"empty period stays as is", 0.05, date(2023, 1, 1), date(2023, 1, 1), 0.05},
+		{"total loss stays as is", -1, date(2023, 1, 1), date(2023, 7, 1), -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertClose(t, "annualized", annualizeReturn(tt.total, tt.from, tt.to), tt.want)
+		})
+	}
+}
+
+// Дневные свечи валютного инструмента в памяти
+type memoryCandles struct {
+	candles map[string][]mdDomain.Candle
+}
+
+func (s *memoryCandles) GetCandles(ctx context.Context, query *mdDomain.CandlesQuery) (*mdDomain.CandleSeries, error) {
+	series := &mdDomain.CandleSeries{InstrumentUid: query.InstrumentUid, Candles: make([]mdDomain.Candle, 0)}
+	for _, candle := range s.candles[query.InstrumentUid] {
+		if !candle.Time.Before(*query.From) && !candle.Time.After(*query.To) {
+			series.Candles = append(series.Candles, candle)
+		}
+	}
+	return series, nil
+}
+
+// Текущие курсы и валютные инструменты в памяти
+type memoryRates struct {
+	rates       map[string]float64
+	instruments map[string]assetsDomain.Currency
+}
+
+func (s *memoryRates) GetExchangeRates(ctx context.Context) (map[string]float64, error) {
+	return s.rates, nil
+}
+
+func (s *memoryRates) GetRateInstruments(ctx context.Context) (map[string]assetsDomain.Currency, error) {
+	return s.instruments, nil
+}
+
+// Курс доллара: 90 в январе, 100 в конце февраля, сегодня 80; цена указана за 100 единиц
+func dollarRates() (*memoryRates, *memoryCandles) {
+	rates := &memoryRates{
+		rates:       map[string]float64{"RUB": 1, "USD": 80},
+		instruments: map[string]assetsDomain.Currency{"USD": {Uid: "usd-tom", Nominal: 100}},
+	}
+	candles := &memoryCandles{candles: map[string][]mdDomain.Candle{
+		"usd-tom": {
+			{Time: date(2024, 1, 9), Close: 9000},
+			{Time: date(2024, 2, 29), Close: 10000},
+		},
+	}}
+	return rates, candles
+}
+
+func TestRateHistory(t *testing.T) {
+	rates, candles := dollarRates()
+	history := loadRateHistory(t.Context(), rates, candles, rates.rates, []string{"USD", "RUB"}, date(2024, 1, 10), date(2024, 3, 1))
+
+	tests := []struct {
+		name     string
+		currency string
+		at       time.Time
+		want     float64
+	}{
+		{"before the history starts", "USD", date(2024, 1, 1), 80},
+		{"on a candle date", "USD", date(2024, 1, 9), 90},
+		{"between candles uses the last close", "USD", date(2024, 2, 15), 90},
+		{"after the last candle", "USD", date(2024, 3, 1), 100},
+		{"lower case currency", "usd", date(2024, 3, 1), 100},
+		{"ruble is always one", "RUB", date(2024, 2, 15), 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, ok := history.rate(tt.currency, tt.at)
+			if !ok {
+				t.Fatalf("no rate for %s", tt.currency)
+			}
+			assertClose(t, "rate", rate, tt.want)
+		})
+	}
+
+	if _, ok := history.rate("EUR", date(2024, 2, 15)); ok {
+		t.Error("rate found for a currency without history or current rate")
+	}
+}
+
+func TestPerformanceConvertsAtRateOfEachDate(t *testing.T) {
+	rates, candles := dollarRates()
+	ledger := &memoryLedger{transactions: []*domain.Transaction{{
+		ID:          "deposit-1",
+		PortfolioID: "portfolio-1",
+		Type:        models.TransactionTypeDeposit,
+		Amount:      1000,
+		Currency:    "USD",
+		ExecutedAt:  date(2024, 1, 10),
+	}}}
+
+	service := NewPerformanceService(
+		newMemoryPortfolios(&domain.Portfolio{ID: "portfolio-1", UserId: "user-1", Currency: "RUB"}),
+		&memoryTree{}, ledger, &memoryPositions{}, rates, rates, candles,
+	)
+
+	from, to := date(2024, 1, 1), date(2024, 3, 1)
+	performance, err := service.GetPerformance(t.Context(), "user-1", "portfolio-1", &from, &to)
+	if err != nil {
+		t.Fatalf("GetPerformance: %v", err)
+	}
+
+	// Пополнение пересчитано по курсу 90 на дату операции, стоимость на конец — по курсу 100
+	assertClose(t, "net contributions", performance.NetContributions, 90000)
+	assertClose(t, "end value", performance.EndValue, 100000)
+	assertClose(t, "gain", performance.Gain, 10000)
+
+	if len(performance.Flows) != 1 {
+		t.Fatalf("flows = %d, want 1", len(performance.Flows))
+	}
+	assertClose(t, "flow value after", performance.Flows[0].ValueAfter, 90000)
+
+	if performance.Twr == nil {
+		t.Fatal("twr is not calculated")
+	}
+	assertClose(t, "twr", *performance.Twr, 100000.0/90000*100-100)
+}
+
+func TestPerformanceWithoutLedger(t *testing.T) {
+	rates, candles := dollarRates()
+	service := NewPerformanceService(
+		newMemoryPortfolios(&domain.Portfolio{ID: "portfolio-1", UserId: "user-1", Currency: "RUB"}),
+		&memoryTree{}, &memoryLedger{}, &memoryPositions{}, rates, rates, candles,
+	)
+
+	to := date(2024, 3, 1)
+	performance, err := service.GetPerformance(t.Context(), "user-1", "portfolio-1", nil, &to)
+	if err != nil {
+		t.Fatalf("GetPerformance: %v", err)
+	}
+
+	if performance.Flows == nil || len(performance.Flows) != 0 {
+		t.Errorf("flows = %#v, want empty series", performance.Flows)
+	}
+	if performance.Twr != nil || performance.Xirr != nil {
+		t.Errorf("twr = %v, xirr = %v, want no values without a ledger", performance.Twr, performance.Xirr)
+	}
+}
@@ -1,10 +1,28 @@
 package services
 
 import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
 	"invest-mate/internal/portfolios/repository"
+	"invest-mate/pkg/logger"
+	"invest-mate/pkg/services"
 )
 
+const defaultPortfolioCurrency = "RUB"
+
 type PortfoliosService interface {
+	CreatePortfolio(ctx context.Context, userID string, req *domain.CreatePortfolioRequest) (*domain.Portfolio, error)
+	GetPortfolios(ctx context.Context, userID string, includeHidden bool, page, limit int) ([]*domain.Portfolio, int64, error)
+	GetPortfolio(ctx context.Context, userID, id string) (*domain.Portfolio, error)
+	UpdatePortfolio(ctx context.Context, userID, id string, req *domain.UpdatePortfolioRequest) (*domain.Portfolio, error)
+	SetPortfolioHidden(ctx context.Context, userID, id string, isHidden bool) (*domain.Portfolio, error)
+	DeletePortfolio(ctx context.Context, userID, id string) (bool, error)
 }
 
 type portfoliosService struct {
@@ -15,3 +33,143 @@ type portfoliosService struct {
 func NewPortfoliosService(portfoliosRepo repository.PortfoliosRepository) PortfoliosService {
 	return &portfoliosService{portfoliosRepo: portfoliosRepo}
 }
+
+// Создание портфеля
+func (s *portfoliosService) CreatePortfolio(ctx context.Context, userID string, req *domain.CreatePortfolioRequest) (*domain.Portfolio, error) {
+	if err := ValidateCreatePortfolioRequest(req); err != nil {
+		return nil, err
+	}
+
+	currency := defaultPortfolioCurrency
+	if req.Currency != "" {
+		currency = strings.ToUpper(req.Currency)
+	}
+
+	portfolio := &domain.Portfolio{
+		ID:                        uuid.New().String(),
+		UserId:                    userID,
+		Name:                      strings.TrimSpace(req.Name),
+		IsComposite:               req.IsComposite,
+		ApplyTaxesOnPaidDividends: req.ApplyTaxesOnPaidDividends,
+		DividendTaxPercent:        req.DividendTaxPercent,
+		CouponTaxPercent:          req.CouponTaxPercent,
+		Currency:                  currency,
+		Note:                      req.Note,
+		IsHidden:                  req.IsHidden,
+		CreatedAt:                 time.Now(),
+		UpdatedAt:                 time.Now(),
+	}
+
+	if err := s.portfoliosRepo.Create(ctx, portfolio); err != nil {
+		return nil, err
+	}
+
+	logger.InfoLog("Portfolio created: %s (user %s)", portfolio.ID, userID)
+
+	return portfolio, nil
+}
+
+// Получение списка портфелей пользователя
+func (s *portfoliosService) GetPortfolios(ctx context.Context, userID string, includeHidden bool, page, limit int) ([]*domain.Portfolio, int64, error) {
+	limit, offset := services.PageOffset(page, limit)
+
+	portfolios, err := s.portfoliosRepo.GetListByUser(ctx, userID, includeHidden, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.portfoliosRepo.CountByUser(ctx, userID, includeHidden)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return portfolios, total, nil
+}
+
+// Получение портфеля пользователя по идентификатору
+func (s *portfoliosService) GetPortfolio(ctx context.Context, userID, id string) (*domain.Portfolio, error) {
+	return s.getOwnedPortfolio(ctx, userID, id)
+}
+
+// Обновление портфеля
+func (s *portfoliosService) UpdatePortfolio(ctx context.Context, userID, id string, req *domain.UpdatePortfolioRequest) (*domain.Portfolio, error) {
+	if err := ValidateUpdatePortfolioRequest(req); err != nil {
+		return nil, err
+	}
+
+	portfolio, err := s.getOwnedPortfolio(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		portfolio.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Currency != nil {
+		portfolio.Currency = strings.ToUpper(*req.Currency)
+	}
+	if req.Note != nil {
+		portfolio.Note = *req.Note
+	}
+	if req.IsHidden != nil {
+		portfolio.IsHidden = *req.IsHidden
+	}
+	if req.ApplyTaxesOnPaidDividends != nil {
+		portfolio.ApplyTaxesOnPaidDividends = *req.ApplyTaxesOnPaidDividends
+	}
+	if req.DividendTaxPercent != nil {
+		portfolio.DividendTaxPercent = *req.DividendTaxPercent
+	}
+	if req.CouponTaxPercent != nil {
+		portfolio.CouponTaxPercent = req.CouponTaxPercent
+	}
+
+	portfolio.UpdatedAt = time.Now()
+
+	if err := s.portfoliosRepo.Update(ctx, portfolio); err != nil {
+		return nil, err
+	}
+
+	return portfolio, nil
+}
+
+// Скрытие или отображение портфеля
+func (s *portfoliosService) SetPortfolioHidden(ctx context.Context, userID, id string, isHidden bool) (*domain.Portfolio, error) {
+	return s.UpdatePortfolio(ctx, userID, id, &domain.UpdatePortfolioRequest{IsHidden: &isHidden})
+}
+
+// Удаление портфеля
+func (s *portfoliosService) DeletePortfolio(ctx context.Context, userID, id string) (bool, error) {
+	if _, err := s.getOwnedPortfolio(ctx, userID, id); err != nil {
+		return false, err
+	}
+
+	deleted, err := s.portfoliosRepo.Delete(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	if deleted {
+		logger.InfoLog("Portfolio deleted: %s (user %s)", id, userID)
+	}
+
+	return deleted, nil
+}
+
+// Получение портфеля с проверкой владельца
+func (s *portfoliosService) getOwnedPortfolio(ctx context.Context, userID, id string) (*domain.Portfolio, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, models.ErrPortfolioNotFound
+	}
+
+	portfolio, err := s.portfoliosRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if portfolio.UserId != userID {
+		return nil, models.ErrPortfolioAccessDenied
+	}
+
+	return portfolio, nil
+}
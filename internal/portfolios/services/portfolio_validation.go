@@ -0,0 +1,298 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+)
+
+const (
+	maxPortfolioNameLength = 255
+	maxPortfolioNoteLength = 255
+	targetWeightTolerance  = 0.01
+)
+
+// Валидация запроса создания портфеля
+func ValidateCreatePortfolioRequest(req *domain.CreatePortfolioRequest) error {
+	if err := validatePortfolioName(req.Name); err != nil {
+		return err
+	}
+	if req.Currency != "" {
+		if err := validatePortfolioCurrency(req.Currency); err != nil {
+			return err
+		}
+	}
+	if err := validatePortfolioNote(req.Note); err != nil {
+		return err
+	}
+
+	if err := validateDividendTaxPercent(req.DividendTaxPercent); err != nil {
+		return err
+	}
+	if req.CouponTaxPercent != nil {
+		return validateCouponTaxPercent(*req.CouponTaxPercent)
+	}
+
+	return nil
+}
+
+// Валидация запроса изменения портфеля
+func ValidateUpdatePortfolioRequest(req *domain.UpdatePortfolioRequest) error {
+	if req.Name != nil {
+		if err := validatePortfolioName(*req.Name); err != nil {
+			return err
+		}
+	}
+	if req.Currency != nil {
+		if err := validatePortfolioCurrency(*req.Currency); err != nil {
+			return err
+		}
+	}
+	if req.Note != nil {
+		if err := validatePortfolioNote(*req.Note); err != nil {
+			return err
+		}
+	}
+	if req.DividendTaxPercent != nil {
+		if err := validateDividendTaxPercent(*req.DividendTaxPercent); err != nil {
+			return err
+		}
+	}
+	if req.CouponTaxPercent != nil {
+		if err := validateCouponTaxPercent(*req.CouponTaxPercent); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Валидация названия портфеля
+func validatePortfolioName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("%w: name is required", models.ErrInvalidRequest)
+	}
+	if utf8.RuneCountInString(name) > maxPortfolioNameLength {
+		return fmt.Errorf("%w: name must be at most %d characters", models.ErrInvalidRequest, maxPortfolioNameLength)
+	}
+
+	return nil
+}
+
+// Валидация валюты портфеля (код ISO 4217)
+func validatePortfolioCurrency(currency string) error {
+	if len(currency) != 3 {
+		return fmt.Errorf("%w: currency must be a 3-letter ISO code", models.ErrInvalidRequest)
+	}
+
+	for _, r := range currency {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return fmt.Errorf("%w: currency must be a 3-letter ISO code", models.ErrInvalidRequest)
+		}
+	}
+
+	return nil
+}
+
+// Валидация заметки портфеля
+func validatePortfolioNote(note string) error {
+	if utf8.RuneCountInString(note) > maxPortfolioNoteLength {
+		return fmt.Errorf("%w: note must be at most %d characters", models.ErrInvalidRequest, maxPortfolioNoteLength)
+	}
+
+	return nil
+}
+
+// Валидация ставки налога на дивиденды
+func validateDividendTaxPercent(percent float32) error {
+	if percent < 0 || percent > 100 {
+		return fmt.Errorf("%w: dividendTaxPercent must be between 0 and 100", models.ErrInvalidRequest)
+	}
+
+	return nil
+}
+
+// Валидация ставки налога на купоны
+func validateCouponTaxPercent(percent float32) error {
+	if percent < 0 || percent > 100 {
+		return fmt.Errorf("%w: couponTaxPercent must be between 0 and 100", models.ErrInvalidRequest)
+	}
+
+	return nil
+}
+
+// Валидация кода страны (ISO 3166-1 alpha-2)
+func validateCountryCode(country string) error {
+	valid := len(country) == 2
+	for _, letter := range country {
+		valid = valid && letter >= 'A' && letter <= 'Z'
+	}
+
+	if !valid {
+		return fmt.Errorf("%w: country must be a two-letter ISO code", models.ErrInvalidRequest)
+	}
+
+	return nil
+}
+
+// Валидация запроса добавления позиции
+func ValidateAddPositionRequest(req *domain.AddPositionRequest) error {
+	if req.Quantity < 0 {
+		return fmt.Errorf("%w: quantity must not be negative", models.ErrInvalidRequest)
+	}
+	if req.AveragePositionPrice < 0 || req.CurrentPrice < 0 || req.CurrentNkd < 0 {
+		return fmt.Errorf("%w: prices must not be negative", models.ErrInvalidRequest)
+	}
+
+	return nil
+}
+
+// Валидация запроса изменения позиции
+func ValidateUpdatePositionRequest(req *domain.UpdatePositionRequest) error {
+	if req.Quantity != nil && *req.Quantity < 0 {
+		return fmt.Errorf("%w: quantity must not be negative", models.ErrInvalidRequest)
+	}
+	if (req.AveragePositionPrice != nil && *req.AveragePositionPrice < 0) ||
+		(req.CurrentPrice != nil && *req.CurrentPrice < 0) ||
+		(req.CurrentNkd != nil && *req.CurrentNkd < 0) {
+		return fmt.Errorf("%w: prices must not be negative", models.ErrInvalidRequest)
+	}
+
+	return nil
+}
+
+// Валидация запроса создания операции
+func ValidateCreateTransactionRequest(req *domain.CreateTransactionRequest) error {
+	if !req.Type.IsValid() {
+		return fmt.Errorf("%w: unsupported transaction type %q", models.ErrInvalidRequest, req.Type)
+	}
+
+	hasInstrument := req.InstrumentUid != "" || req.Figi != "" || req.Ticker != ""
+
+	switch {
+	case req.Type.IsTrade():
+		if !hasInstrument {
+			return fmt.Errorf("%w: instrumentUid, figi or ticker is required", models.ErrInvalidRequest)
+		}
+		if req.Quantity <= 0 {
+			return fmt.Errorf("%w: quantity must be positive", models.ErrInvalidRequest)
+		}
+		if req.Price < 0 {
+			return fmt.Errorf("%w: price must not be negative", models.ErrInvalidRequest)
+		}
+	case req.Type.IsIncome():
+		if !hasInstrument {
+			return fmt.Errorf("%w: instrumentUid, figi or ticker is required", models.ErrInvalidRequest)
+		}
+		if req.Amount <= 0 {
+			return fmt.Errorf("%w: amount must be positive", models.ErrInvalidRequest)
+		}
+	default:
+		if req.Amount <= 0 {
+			return fmt.Errorf("%w: amount must be positive", models.ErrInvalidRequest)
+		}
+	}
+
+	if req.Amount < 0 || req.Commission < 0 {
+		return fmt.Errorf("%w: amount and commission must not be negative", models.ErrInvalidRequest)
+	}
+	if req.Currency != "" {
+		if err := validatePortfolioCurrency(req.Currency); err != nil {
+			return err
+		}
+	}
+	if len(req.Lots) > 0 && req.Type != models.TransactionTypeSell {
+		return fmt.Errorf("%w: lots can be selected only for SELL", models.ErrInvalidRequest)
+	}
+	if err := validateLotSelections(req.Lots); err != nil {
+		return err
+	}
+
+	return validatePortfolioNote(req.Note)
+}
+
+// Валидация выбранных для продажи партий
+func validateLotSelections(lots []domain.LotSelection) error {
+	seen := make(map[string]bool, len(lots))
+
+	for _, lot := range lots {
+		if lot.TransactionID == "" || lot.Quantity <= 0 {
+			return fmt.Errorf("%w: each lot needs transactionId and positive quantity", models.ErrInvalidRequest)
+		}
+		if seen[lot.TransactionID] {
+			return fmt.Errorf("%w: lot %s selected twice", models.ErrInvalidRequest, lot.TransactionID)
+		}
+		seen[lot.TransactionID] = true
+	}
+
+	return nil
+}
+
+// Валидация запроса изменения операции
+func ValidateUpdateTransactionRequest(req *domain.UpdateTransactionRequest) error {
+	if req.Quantity != nil && *req.Quantity <= 0 {
+		return fmt.Errorf("%w: quantity must be positive", models.ErrInvalidRequest)
+	}
+	if (req.Price != nil && *req.Price < 0) ||
+		(req.Amount != nil && *req.Amount < 0) ||
+		(req.Commission != nil && *req.Commission < 0) {
+		return fmt.Errorf("%w: price, amount and commission must not be negative", models.ErrInvalidRequest)
+	}
+	if req.Currency != nil {
+		if err := validatePortfolioCurrency(*req.Currency); err != nil {
+			return err
+		}
+	}
+	if req.Note != nil {
+		if err := validatePortfolioNote(*req.Note); err != nil {
+			return err
+		}
+	}
+	if req.Lots != nil {
+		if err := validateLotSelections(*req.Lots); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Валидация целевого распределения: одно измерение, доли от 0 до 100 в сумме 100%
+func validateTargetAllocations(targets []*domain.TargetAllocation) error {
+	if len(targets) == 0 {
+		return fmt.Errorf("%w: targets must not be empty", models.ErrInvalidRequest)
+	}
+
+	dimension := targets[0].Dimension
+	total := 0.0
+
+	for _, target := range targets {
+		if target == nil {
+			return fmt.Errorf("%w: target must not be null", models.ErrInvalidRequest)
+		}
+		if !target.Dimension.IsValid() {
+			return fmt.Errorf("%w: dimension must be INSTRUMENT, SECTOR, INSTRUMENT_TYPE or CURRENCY", models.ErrInvalidRequest)
+		}
+		if target.Dimension != dimension {
+			return fmt.Errorf("%w: all targets must use the same dimension", models.ErrInvalidRequest)
+		}
+		if strings.TrimSpace(target.Key) == "" {
+			return fmt.Errorf("%w: target key is required", models.ErrInvalidRequest)
+		}
+		if target.WeightPercent < 0 || target.WeightPercent > 100 {
+			return fmt.Errorf("%w: weightPercent must be between 0 and 100", models.ErrInvalidRequest)
+		}
+
+		total += target.WeightPercent
+	}
+
+	if math.Abs(total-100) > targetWeightTolerance {
+		return fmt.Errorf("%w: target weights must sum to 100, got %.2f", models.ErrInvalidRequest, total)
+	}
+
+	return nil
+}
@@ -0,0 +1,250 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/internal/portfolios/repository"
+	"invest-mate/pkg/logger"
+)
+
+type PositionsService interface {
+	GetPositions(ctx context.Context, userID, portfolioID string) ([]*domain.Position, error)
+	GetPosition(ctx context.Context, userID, portfolioID, positionID string) (*domain.Position, error)
+	AddPosition(ctx context.Context, userID, portfolioID string, req *domain.AddPositionRequest) (*domain.Position, error)
+	UpdatePosition(ctx context.Context, userID, portfolioID, positionID string, req *domain.UpdatePositionRequest) (*domain.Position, error)
+	DeletePosition(ctx context.Context, userID, portfolioID, positionID string) (bool, error)
+}
+
+type positionsService struct {
+	portfoliosService PortfoliosService
+	positionsRepo     repository.PositionsRepository
+	instruments       InstrumentResolver
+}
+
+// Создание нового сервиса позиций
+func NewPositionsService(
+	portfoliosService PortfoliosService,
+	positionsRepo repository.PositionsRepository,
+	instruments InstrumentResolver,
+) PositionsService {
+	return &positionsService{
+		portfoliosService: portfoliosService,
+		positionsRepo:     positionsRepo,
+		instruments:       instruments,
+	}
+}
+
+// Получение позиций портфеля
+func (s *positionsService) GetPositions(ctx context.Context, userID, portfolioID string) ([]*domain.Position, error) {
+	if _, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID); err != nil {
+		return nil, err
+	}
+
+	positions, err := s.positionsRepo.GetByPortfolio(ctx, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.attachInstruments(ctx, positions)
+
+	return positions, nil
+}
+
+// Получение позиции портфеля
+func (s *positionsService) GetPosition(ctx context.Context, userID, portfolioID, positionID string) (*domain.Position, error) {
+	if _, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID); err != nil {
+		return nil, err
+	}
+
+	position, err := s.findPosition(ctx, portfolioID, positionID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.attachInstruments(ctx, []*domain.Position{position})
+
+	return position, nil
+}
+
+// Добавление позиции вручную
+func (s *positionsService) AddPosition(ctx context.Context, userID, portfolioID string, req *domain.AddPositionRequest) (*domain.Position, error) {
+	if err := ValidateAddPositionRequest(req); err != nil {
+		return nil, err
+	}
+
+	portfolio, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	if portfolio.IsComposite {
+		return nil, models.ErrCompositePortfolio
+	}
+
+	instrument, err := resolveInstrument(ctx, s.instruments, req.InstrumentUid, req.Figi, req.Ticker)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.positionsRepo.FindByInstrument(ctx, portfolioID, instrument.Uid)
+	if err == nil {
+		return nil, models.ErrPositionAlreadyExists
+	}
+	if !errors.Is(err, models.ErrPositionNotFound) {
+		return nil, err
+	}
+
+	position := &domain.Position{
+		ID:                   uuid.New().String(),
+		PortfolioID:          portfolioID,
+		Figi:                 instrument.Figi,
+		IsCustomData:         true,
+		Ticker:               instrument.Ticker,
+		PositionUid:          instrument.PositionUid,
+		InstrumentUid:        instrument.Uid,
+		Quantity:             req.Quantity,
+		QuantityLots:         quantityToLots(req.Quantity, instrument.Lot),
+		AveragePositionPrice: req.AveragePositionPrice,
+		CurrentPrice:         req.CurrentPrice,
+		CurrentNkd:           req.CurrentNkd,
+		CreatedAt:            time.Now(),
+		UpdatedAt:            time.Now(),
+	}
+
+	if err := s.positionsRepo.Create(ctx, position); err != nil {
+		return nil, err
+	}
+
+	position.Instrument = instrument
+
+	logger.InfoLog("Position %s (%s) added to portfolio %s", position.ID, position.Ticker, portfolioID)
+
+	return position, nil
+}
+
+// Изменение позиции
+func (s *positionsService) UpdatePosition(ctx context.Context, userID, portfolioID, positionID string, req *domain.UpdatePositionRequest) (*domain.Position, error) {
+	if err := ValidateUpdatePositionRequest(req); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID); err != nil {
+		return nil, err
+	}
+
+	position, err := s.findPosition(ctx, portfolioID, positionID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.attachInstruments(ctx, []*domain.Position{position})
+
+	if req.Quantity != nil {
+		position.Quantity = *req.Quantity
+
+		lot := 0
+		if position.Instrument != nil {
+			lot = position.Instrument.Lot
+		}
+		position.QuantityLots = quantityToLots(position.Quantity, lot)
+	}
+	if req.AveragePositionPrice != nil {
+		position.AveragePositionPrice = *req.AveragePositionPrice
+	}
+	if req.CurrentPrice != nil {
+		position.CurrentPrice = *req.CurrentPrice
+	}
+	if req.CurrentNkd != nil {
+		position.CurrentNkd = *req.CurrentNkd
+	}
+
+	// Ручная правка позиции брокера превращает её в пользовательскую
+	position.IsCustomData = true
+	position.UpdatedAt = time.Now()
+
+	if err := s.positionsRepo.Update(ctx, position); err != nil {
+		return nil, err
+	}
+
+	return position, nil
+}
+
+// Удаление позиции
+func (s *positionsService) DeletePosition(ctx context.Context, userID, portfolioID, positionID string) (bool, error) {
+	if _, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID); err != nil {
+		return false, err
+	}
+
+	if _, err := s.findPosition(ctx, portfolioID, positionID); err != nil {
+		return false, err
+	}
+
+	return s.positionsRepo.Delete(ctx, portfolioID, positionID)
+}
+
+// Поиск позиции с проверкой идентификатора
+func (s *positionsService) findPosition(ctx context.Context, portfolioID, positionID string) (*domain.Position, error) {
+	if _, err := uuid.Parse(positionID); err != nil {
+		return nil, models.ErrPositionNotFound
+	}
+
+	return s.positionsRepo.FindByID(ctx, portfolioID, positionID)
+}
+
+// Заполнение отсутствующих идентификаторов инструмента из хранилища
+func (s *positionsService) fillMissingIdentifiers(ctx context.Context, position *domain.Position) {
+	if position.Instrument == nil {
+		instrument, err := resolveInstrument(ctx, s.instruments, position.InstrumentUid, position.Figi, position.Ticker)
+		if err != nil {
+			return
+		}
+		position.Instrument = instrument
+	}
+
+	if position.InstrumentUid == "" {
+		position.InstrumentUid = position.Instrument.Uid
+	}
+	if position.Figi == "" {
+		position.Figi = position.Instrument.Figi
+	}
+	if position.Ticker == "" {
+		position.Ticker = position.Instrument.Ticker
+	}
+	if position.PositionUid == "" {
+		position.PositionUid = position.Instrument.PositionUid
+	}
+}
+
+// Добавление сведений об инструментах к позициям и заполнение недостающих идентификаторов
+func (s *positionsService) attachInstruments(ctx context.Context, positions []*domain.Position) {
+	if len(positions) == 0 {
+		return
+	}
+
+	uids := make([]string, 0, len(positions))
+	for _, position := range positions {
+		if position.InstrumentUid != "" {
+			uids = append(uids, position.InstrumentUid)
+		}
+	}
+
+	instruments, err := s.instruments.GetInstrumentsByUids(ctx, uids)
+	if err != nil {
+		logger.ErrorLog("Failed to load instruments for positions: %v", err)
+		return
+	}
+
+	for _, position := range positions {
+		if instrument, ok := instruments[position.InstrumentUid]; ok {
+			position.Instrument = &instrument
+		}
+
+		s.fillMissingIdentifiers(ctx, position)
+	}
+}
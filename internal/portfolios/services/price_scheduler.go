@@ -0,0 +1,23 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"invest-mate/pkg/scheduler"
+)
+
+// Периодическое обновление текущих цен позиций по последним ценам
+type PriceScheduler struct {
+	*scheduler.Runner
+}
+
+// Создание планировщика обновления цен с интервалом запуска (первое обновление — сразу после старта)
+func NewPriceScheduler(service PricesService, interval time.Duration) *PriceScheduler {
+	return &PriceScheduler{
+		Runner: scheduler.NewRunner("Price", interval, func(ctx context.Context) error {
+			_, err := service.RefreshPrices(ctx)
+			return err
+		}),
+	}
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+
+	assetsDomain "invest-mate/internal/assets/models/domain"
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/repository"
+	sharedModels "invest-mate/internal/shared/models"
+	"invest-mate/pkg/logger"
+)
+
+// Запрос последних цен в обход кэша (реализуется хранилищем модуля активов)
+type PriceRefresher interface {
+	RefreshLastPrices(ctx context.Context, uids []string) (map[string]float64, error)
+}
+
+type PricesService interface {
+	RefreshPrices(ctx context.Context) (int64, error)
+}
+
+type pricesService struct {
+	positionsRepo repository.PositionsRepository
+	instruments   InstrumentResolver
+	prices        PriceRefresher
+}
+
+// Создание нового сервиса обновления цен позиций
+func NewPricesService(
+	positionsRepo repository.PositionsRepository,
+	instruments InstrumentResolver,
+	prices PriceRefresher,
+) PricesService {
+	return &pricesService{
+		positionsRepo: positionsRepo,
+		instruments:   instruments,
+		prices:        prices,
+	}
+}
+
+// Обновление текущих цен позиций всех портфелей по последним ценам одним пакетом;
+// возвращает число обновлённых позиций
+func (s *pricesService) RefreshPrices(ctx context.Context) (int64, error) {
+	uids, err := s.positionsRepo.GetHeldInstrumentUids(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(uids) == 0 {
+		return 0, nil
+	}
+
+	lastPrices, err := s.prices.RefreshLastPrices(ctx, uids)
+	if err != nil {
+		logger.ErrorLog("Failed to load last prices: %v", err)
+		return 0, models.ErrMarketDataUnavailable
+	}
+
+	instruments, err := s.instruments.GetInstrumentsByUids(ctx, uids)
+	if err != nil {
+		return 0, err
+	}
+
+	prices := make(map[string]float64, len(lastPrices))
+	for uid, lastPrice := range lastPrices {
+		instrument, ok := instruments[uid]
+		if !ok {
+			continue
+		}
+
+		prices[uid] = positionPrice(instrument, lastPrice)
+	}
+
+	updated, err := s.positionsRepo.UpdateCurrentPrices(ctx, prices)
+	if err != nil {
+		return 0, err
+	}
+
+	logger.InfoLog("Position prices refreshed: %d instruments, %d positions updated", len(prices), updated)
+
+	return updated, nil
+}
+
+// Цена одной бумаги в валюте инструмента по последней цене
+// (для облигаций последняя цена — в % номинала, для валют — за номинал)
+func positionPrice(instrument assetsDomain.Instrument, lastPrice float64) float64 {
+	if instrument.Nominal <= 0 {
+		return lastPrice
+	}
+
+	switch instrument.InstrumentType {
+	case sharedModels.InstrumentTypeBond:
+		return lastPrice * instrument.Nominal / 100
+	case sharedModels.InstrumentTypeCurrency:
+		return lastPrice / instrument.Nominal
+	}
+
+	return lastPrice
+}
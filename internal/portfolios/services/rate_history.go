@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	assetsDomain "invest-mate/internal/assets/models/domain"
+	mdModels "invest-mate/internal/marketdata/models"
+	mdDomain "invest-mate/internal/marketdata/models/domain"
+	"invest-mate/pkg/logger"
+)
+
+// Запас на выходные и праздники перед первой датой периода
+const rateHistoryLookbackDays = 10
+
+// Валютные инструменты для расчёта курсов (реализуется хранилищем модуля активов)
+type RateInstrumentSource interface {
+	GetRateInstruments(ctx context.Context) (map[string]assetsDomain.Currency, error)
+}
+
+// Источник дневных свечей (реализуется сервисом свечей модуля рыночных данных)
+type CandleSource interface {
+	GetCandles(ctx context.Context, query *mdDomain.CandlesQuery) (*mdDomain.CandleSeries, error)
+}
+
+// Курс валюты к рублю на дату закрытия торгов
+type datedRate struct {
+	date time.Time
+	rate float64
+}
+
+// Курсы валют к рублю по дням; для дат без истории используется текущий курс
+type rateHistory struct {
+	current map[string]float64
+	daily   map[string][]datedRate
+}
+
+// Загрузка дневных курсов валют за период по свечам валютных инструментов
+func loadRateHistory(
+	ctx context.Context,
+	instruments RateInstrumentSource,
+	candles CandleSource,
+	current map[string]float64,
+	currencies []string,
+	from, to time.Time,
+) *rateHistory {
+	history := &rateHistory{
+		current: current,
+		daily:   make(map[string][]datedRate),
+	}
+
+	if len(currencies) == 0 {
+		return history
+	}
+
+	byIso, err := instruments.GetRateInstruments(ctx)
+	if err != nil {
+		// Без истории суммы пересчитываются по текущему курсу
+		logger.ErrorLog("Failed to load currency instruments for rate history: %v", err)
+		return history
+	}
+
+	from = from.AddDate(0, 0, -rateHistoryLookbackDays)
+
+	for _, currency := range currencies {
+		instrument, ok := byIso[currency]
+		if !ok {
+			continue
+		}
+
+		series, err := candles.GetCandles(ctx, &mdDomain.CandlesQuery{
+			InstrumentUid: instrument.Uid,
+			Interval:      mdModels.CandleIntervalDay,
+			From:          &from,
+			To:            &to,
+		})
+		if err != nil {
+			logger.ErrorLog("Failed to load %s rate history: %v", currency, err)
+			continue
+		}
+
+		rates := make([]datedRate, 0, len(series.Candles))
+		for _, candle := range series.Candles {
+			if candle.Close <= 0 {
+				continue
+			}
+
+			// Цена валюты указывается за номинал (например, за 100 единиц)
+			rate := candle.Close
+			if instrument.Nominal > 0 {
+				rate /= instrument.Nominal
+			}
+
+			rates = append(rates, datedRate{date: candle.Time, rate: rate})
+		}
+
+		sort.Slice(rates, func(i, j int) bool {
+			return rates[i].date.Before(rates[j].date)
+		})
+
+		history.daily[currency] = rates
+	}
+
+	return history
+}
+
+// Курс валюты к рублю на момент: последний дневной курс не позже него
+func (h *rateHistory) rate(currency string, at time.Time) (float64, bool) {
+	currency = strings.ToUpper(currency)
+
+	rates := h.daily[currency]
+	index := sort.Search(len(rates), func(i int) bool {
+		return rates[i].date.After(at)
+	})
+	if index > 0 {
+		return rates[index-1].rate, true
+	}
+
+	rate, ok := h.current[currency]
+	return rate, ok
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"math"
+	"sort"
+
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+)
+
+// Бумага, участвующая в ребалансировке
+type rebalanceHolding struct {
+	uid      string
+	ticker   string
+	currency string
+	quantity int32
+	// Текущая стоимость в базовой валюте
+	value float64
+	// Цена одной бумаги в валюте инструмента
+	price float64
+	// Курс валюты инструмента к базовой валюте
+	rate      float64
+	lot       int
+	increment float64
+	// Номинал облигации (шаг цены облигаций задаётся в процентах номинала)
+	nominal float64
+}
+
+// Группа целевого распределения с входящими в неё бумагами
+type rebalanceGroup struct {
+	drift    *domain.AllocationDrift
+	holdings []*rebalanceHolding
+}
+
+// Расчёт отклонений групп и заявок целыми лотами; возвращает заявки и итог движения денег
+// (продажи минус покупки) в базовой валюте
+func buildRebalanceOrders(groups []*rebalanceGroup, total float64) ([]*domain.RebalanceOrder, float64) {
+	orders := make([]*domain.RebalanceOrder, 0)
+	cashFlow := 0.0
+
+	for _, group := range groups {
+		drift := group.drift
+
+		for _, holding := range group.holdings {
+			drift.CurrentValue += holding.value
+			if holding.quantity > 0 {
+				drift.HasHoldings = true
+			}
+		}
+
+		drift.TargetValue = total * drift.TargetPercent / 100
+		drift.Difference = drift.TargetValue - drift.CurrentValue
+		drift.CurrentPercent = drift.CurrentValue / total * 100
+		drift.DriftPercent = drift.CurrentPercent - drift.TargetPercent
+
+		// Разница группы делится между бумагами пропорционально их стоимости
+		change := 0.0
+		for _, holding := range group.holdings {
+			share := 1 / float64(len(group.holdings))
+			if drift.CurrentValue > 0 {
+				share = holding.value / drift.CurrentValue
+			}
+
+			// Бумаги с нулевой целевой долей продаются полностью
+			order := lotOrder(holding, drift.Difference*share, drift.TargetPercent == 0)
+			if order == nil {
+				continue
+			}
+
+			if order.Direction == models.TransactionTypeBuy {
+				change += order.AmountBase
+			} else {
+				change -= order.AmountBase
+			}
+
+			orders = append(orders, order)
+		}
+
+		drift.PostRebalancePercent = (drift.CurrentValue + change) / total * 100
+		cashFlow -= change
+	}
+
+	// Сначала продажи, которые освобождают деньги для покупок
+	sort.SliceStable(orders, func(i, j int) bool {
+		return orders[i].Direction == models.TransactionTypeSell && orders[j].Direction != models.TransactionTypeSell
+	})
+
+	return orders, cashFlow
+}
+
+// Группы с целевой долей без бумаг, для которых не составлено ни одной покупки:
+// в группе нет инструментов или лот дороже целевой суммы
+func unfilledGroups(groups []*rebalanceGroup, orders []*domain.RebalanceOrder) []string {
+	bought := make(map[string]bool, len(orders))
+	for _, order := range orders {
+		if order.Direction == models.TransactionTypeBuy {
+			bought[order.InstrumentUid] = true
+		}
+	}
+
+	result := make([]string, 0)
+	for _, group := range groups {
+		if group.drift.TargetPercent <= 0 || group.drift.HasHoldings {
+			continue
+		}
+
+		filled := false
+		for _, holding := range group.holdings {
+			filled = filled || bought[holding.uid]
+		}
+
+		if !filled {
+			result = append(result, group.drift.Key)
+		}
+	}
+
+	return result
+}
+
+// Заявка на сумму в базовой валюте, округлённая к нулю до целого числа лотов
+func lotOrder(holding *rebalanceHolding, amountBase float64, sellAll bool) *domain.RebalanceOrder {
+	price := roundToIncrement(holding.price, holding.increment, holding.nominal)
+	if price <= 0 || holding.rate <= 0 || amountBase == 0 {
+		return nil
+	}
+
+	lot := holding.lot
+	if lot <= 0 {
+		lot = 1
+	}
+
+	lots := int32(math.Abs(amountBase) / (price * float64(lot) * holding.rate))
+
+	direction := models.TransactionTypeBuy
+	if amountBase < 0 {
+		direction = models.TransactionTypeSell
+		lots = min(lots, quantityToLots(holding.quantity, lot))
+		if sellAll {
+			lots = quantityToLots(holding.quantity, lot)
+		}
+	}
+
+	if lots <= 0 {
+		return nil
+	}
+
+	quantity := lots * int32(lot)
+	amount := price * float64(quantity)
+
+	return &domain.RebalanceOrder{
+		InstrumentUid: holding.uid,
+		Ticker:        holding.ticker,
+		Direction:     direction,
+		Lots:          lots,
+		Quantity:      quantity,
+		Price:         price,
+		Currency:      holding.currency,
+		Amount:        amount,
+		AmountBase:    amount * holding.rate,
+	}
+}
+
+// Округление цены до шага цены инструмента; для облигаций шаг задан в процентах номинала
+func roundToIncrement(price, increment, nominal float64) float64 {
+	if increment <= 0 {
+		return price
+	}
+
+	rounded := price
+	if nominal > 0 {
+		rounded = math.Round(price/nominal*100/increment) * increment * nominal / 100
+	} else {
+		rounded = math.Round(price/increment) * increment
+	}
+
+	if rounded <= 0 {
+		return price
+	}
+
+	// Устранение погрешности умножения на шаг
+	return math.Round(rounded*1e9) / 1e9
+}
@@ -0,0 +1,285 @@
+package services
+
+import (
+	"slices"
+	"testing"
+
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+)
+
+func TestLotOrder(t *testing.T) {
+	tests := []struct {
+		name      string
+		holding   rebalanceHolding
+		amount    float64
+		sellAll   bool
+		direction models.TransactionType
+		lots      int32
+		quantity  int32
+		price     float64
+		amountRub float64
+	}{
+		{
+			// 25000 / (250 * 10) = 10 лотов ровно
+			name:      "buy whole lots",
+			holding:   rebalanceHolding{uid: "sber", price: 250, rate: 1, lot: 10},
+			amount:    25000,
+			direction: models.TransactionTypeBuy,
+			lots:      10,
+			quantity:  100,
+			price:     250,
+			amountRub: 25000,
+		},
+		{
+			// 2.9 лота округляются к нулю
+			name:      "buy rounds lots towards zero",
+			holding:   rebalanceHolding{uid: "sber", price: 250, rate: 1, lot: 10},
+			amount:    7250,
+			direction: models.TransactionTypeBuy,
+			lots:      2,
+			quantity:  20,
+			price:     250,
+			amountRub: 5000,
+		},
+		{
+			// Цена 101.37 при шаге 0.05 становится 101.35
+			name:      "price is rounded to the increment",
+			holding:   rebalanceHolding{uid: "gazp", price: 101.37, rate: 1, lot: 1, increment: 0.05},
+			amount:    1000,
+			direction: models.TransactionTypeBuy,
+			lots:      9,
+			quantity:  9,
+			price:     101.35,
+			amountRub: 912.15,
+		},
+		{
+			// Шаг облигации 0.01% номинала 1000: цена 987.654 становится 987.7
+			name:      "bond price is rounded to the increment of nominal",
+			holding:   rebalanceHolding{uid: "ofz", price: 987.654, rate: 1, lot: 1, increment: 0.01, nominal: 1000},
+			amount:    5000,
+			direction: models.TransactionTypeBuy,
+			lots:      5,
+			quantity:  5,
+			price:     987.7,
+			amountRub: 4938.5,
+		},
+		{
+			// Сумма в рублях пересчитывается в валюту инструмента по курсу
+			name:      "foreign instrument uses the rate",
+			holding:   rebalanceHolding{uid: "aapl", currency: "usd", price: 200, rate: 90, lot: 1},
+			amount:    50000,
+			direction: models.TransactionTypeBuy,
+			lots:      2,
+			quantity:  2,
+			price:     200,
+			amountRub: 36000,
+		},
+		{
+			name:      "sell is capped by the holding",
+			holding:   rebalanceHolding{uid: "sber", price: 250, rate: 1, lot: 10, quantity: 35},
+			amount:    -100000,
+			direction: models.TransactionTypeSell,
+			lots:      3,
+			quantity:  30,
+			price:     250,
+			amountRub: 7500,
+		},
+		{
+			// Бумага вне распределения продаётся целиком, даже если разница меньше
+			name:      "sell all ignores the amount",
+			holding:   rebalanceHolding{uid: "sber", price: 250, rate: 1, lot: 10, quantity: 40},
+			amount:    -100,
+			sellAll:   true,
+			direction: models.TransactionTypeSell,
+			lots:      4,
+			quantity:  40,
+			price:     250,
+			amountRub: 10000,
+		},
+		{
+			name:    "lot larger than the target amount",
+			holding: rebalanceHolding{uid: "lkoh", price: 7000, rate: 1, lot: 1},
+			amount:  6999,
+		},
+		{
+			name:    "sell of less than one lot held",
+			holding: rebalanceHolding{uid: "sber", price: 250, rate: 1, lot: 10, quantity: 5},
+			amount:  -10000,
+		},
+		{
+			name:    "no price",
+			holding: rebalanceHolding{uid: "sber", rate: 1, lot: 10},
+			amount:  10000,
+		},
+		{
+			name:    "no rate",
+			holding: rebalanceHolding{uid: "aapl", price: 200, lot: 1},
+			amount:  10000,
+		},
+		{
+			name:    "zero amount",
+			holding: rebalanceHolding{uid: "sber", price: 250, rate: 1, lot: 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := lotOrder(&tt.holding, tt.amount, tt.sellAll)
+
+			if tt.lots == 0 {
+				if order != nil {
+					t.Fatalf("order = %+v, want none", order)
+				}
+				return
+			}
+			if order == nil {
+				t.Fatal("no order")
+			}
+
+			if order.Direction != tt.direction || order.Lots != tt.lots || order.Quantity != tt.quantity {
+				t.Errorf("order = %s %d lots (%d), want %s %d lots (%d)", order.Direction, order.Lots, order.Quantity, tt.direction, tt.lots, tt.quantity)
+			}
+			assertClose(t, "price", order.Price, tt.price)
+			assertClose(t, "amount in base", order.AmountBase, tt.amountRub)
+		})
+	}
+}
+
+func TestBuildRebalanceOrders(t *testing.T) {
+	// Портфель на 100000: SBER 70000, GAZP 30000; цель 50/50 и новая бумага LKOH
+	groups := []*rebalanceGroup{
+		{
+			drift:    &domain.AllocationDrift{Key: "sber", TargetPercent: 40},
+			holdings: []*rebalanceHolding{{uid: "sber", quantity: 280, value: 70000, price: 250, rate: 1, lot: 10}},
+		},
+		{
+			drift:    &domain.AllocationDrift{Key: "gazp", TargetPercent: 40},
+			holdings: []*rebalanceHolding{{uid: "gazp", quantity: 200, value: 30000, price: 150, rate: 1, lot: 10}},
+		},
+		{
+			drift:    &domain.AllocationDrift{Key: "lkoh", TargetPercent: 20},
+			holdings: []*rebalanceHolding{{uid: "lkoh", price: 7000, rate: 1, lot: 1}},
+		},
+	}
+
+	orders, cashFlow := buildRebalanceOrders(groups, 100000)
+
+	want := []struct {
+		uid       string
+		direction models.TransactionType
+		lots      int32
+	}{
+		// Продажи идут первыми: 30000 / 2500 = 12 лотов SBER
+		{"sber", models.TransactionTypeSell, 12},
+		// 10000 / 1500 = 6 лотов GAZP, 20000 / 7000 = 2 лота LKOH
+		{"gazp", models.TransactionTypeBuy, 6},
+		{"lkoh", models.TransactionTypeBuy, 2},
+	}
+
+	if len(orders) != len(want) {
+		t.Fatalf("orders = %d, want %d", len(orders), len(want))
+	}
+	for i, w := range want {
+		if orders[i].InstrumentUid != w.uid || orders[i].Direction != w.direction || orders[i].Lots != w.lots {
+			t.Errorf("order %d = %s %s %d, want %s %s %d", i, orders[i].InstrumentUid, orders[i].Direction, orders[i].Lots, w.uid, w.direction, w.lots)
+		}
+	}
+
+	assertClose(t, "cash flow", cashFlow, 30000-9000-14000)
+	assertClose(t, "sber drift", groups[0].drift.DriftPercent, 30)
+	assertClose(t, "sber after", groups[0].drift.PostRebalancePercent, 40)
+	assertClose(t, "gazp after", groups[1].drift.PostRebalancePercent, 39)
+	assertClose(t, "lkoh after", groups[2].drift.PostRebalancePercent, 14)
+
+	if groups[2].drift.HasHoldings {
+		t.Error("lkoh has holdings, want none")
+	}
+}
+
+func TestUnfilledGroups(t *testing.T) {
+	tests := []struct {
+		name   string
+		groups []*rebalanceGroup
+		want   []string
+	}{
+		{
+			name: "target without instruments",
+			groups: []*rebalanceGroup{
+				{drift: &domain.AllocationDrift{Key: "it", TargetPercent: 30}},
+			},
+			want: []string{"it"},
+		},
+		{
+			// Лот LKOH дороже 20% портфеля на 10000
+			name: "lot size larger than the target amount",
+			groups: []*rebalanceGroup{
+				{
+					drift:    &domain.AllocationDrift{Key: "sber", TargetPercent: 80},
+					holdings: []*rebalanceHolding{{uid: "sber", quantity: 40, value: 10000, price: 250, rate: 1, lot: 10}},
+				},
+				{
+					drift:    &domain.AllocationDrift{Key: "lkoh", TargetPercent: 20},
+					holdings: []*rebalanceHolding{{uid: "lkoh", price: 7000, rate: 1, lot: 1}},
+				},
+			},
+			want: []string{"lkoh"},
+		},
+		{
+			name: "group bought in whole lots is filled",
+			groups: []*rebalanceGroup{
+				{
+					drift:    &domain.AllocationDrift{Key: "sber", TargetPercent: 80},
+					holdings: []*rebalanceHolding{{uid: "sber", quantity: 40, value: 10000, price: 250, rate: 1, lot: 10}},
+				},
+				{
+					drift:    &domain.AllocationDrift{Key: "gazp", TargetPercent: 20},
+					holdings: []*rebalanceHolding{{uid: "gazp", price: 150, rate: 1, lot: 10}},
+				},
+			},
+			want: []string{},
+		},
+		{
+			// Группа с бумагами не считается незаполненной, даже если докупить лот нельзя
+			name: "held group without a buy order",
+			groups: []*rebalanceGroup{
+				{
+					drift:    &domain.AllocationDrift{Key: "lkoh", TargetPercent: 100},
+					holdings: []*rebalanceHolding{{uid: "lkoh", quantity: 1, value: 7000, price: 7000, rate: 1, lot: 1}},
+				},
+			},
+			want: []string{},
+		},
+		{
+			name: "group outside the targets",
+			groups: []*rebalanceGroup{
+				{
+					drift:    &domain.AllocationDrift{Key: "sber", TargetPercent: 100},
+					holdings: []*rebalanceHolding{{uid: "sber", quantity: 40, value: 10000, price: 250, rate: 1, lot: 10}},
+				},
+				{drift: &domain.AllocationDrift{Key: "gazp"}},
+			},
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			total := 0.0
+			for _, group := range tt.groups {
+				for _, holding := range group.holdings {
+					total += holding.value
+				}
+			}
+			if total == 0 {
+				total = 10000
+			}
+
+			orders, _ := buildRebalanceOrders(tt.groups, total)
+
+			if got := unfilledGroups(tt.groups, orders); !slices.Equal(got, tt.want) {
+				t.Errorf("unfilled = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"sort"
+	"time"
+
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/internal/portfolios/repository"
+	"invest-mate/pkg/logger"
+)
+
+const (
+	shareTokenLength   = 10
+	shareTokenAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz23456789"
+	shareTokenAttempts = 5
+)
+
+type ShareService interface {
+	GetShare(ctx context.Context, userID, portfolioID string) (*domain.PortfolioShare, error)
+	SharePortfolio(ctx context.Context, userID, portfolioID string, req *domain.SharePortfolioRequest) (*domain.PortfolioShare, error)
+	RevokeShare(ctx context.Context, userID, portfolioID string) (*domain.PortfolioShare, error)
+	GetPublicPortfolio(ctx context.Context, token string) (*domain.PublicPortfolio, error)
+}
+
+type shareService struct {
+	portfoliosService PortfoliosService
+	portfoliosRepo    repository.PortfoliosRepository
+	valuationService  ValuationService
+	instruments       InstrumentResolver
+}
+
+// Создание нового сервиса открытого доступа к портфелям
+func NewShareService(
+	portfoliosService PortfoliosService,
+	portfoliosRepo repository.PortfoliosRepository,
+	valuationService ValuationService,
+	instruments InstrumentResolver,
+) ShareService {
+	return &shareService{
+		portfoliosService: portfoliosService,
+		portfoliosRepo:    portfoliosRepo,
+		valuationService:  valuationService,
+		instruments:       instruments,
+	}
+}
+
+// Получение состояния открытого доступа к портфелю
+func (s *shareService) GetShare(ctx context.Context, userID, portfolioID string) (*domain.PortfolioShare, error) {
+	portfolio, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPortfolioShare(portfolio), nil
+}
+
+// Выпуск нового токена доступа (прежний перестаёт действовать)
+func (s *shareService) SharePortfolio(ctx context.Context, userID, portfolioID string, req *domain.SharePortfolioRequest) (*domain.PortfolioShare, error) {
+	portfolio, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	portfolio.HasToken = true
+	portfolio.ShareAmounts = req.ShareAmounts
+	portfolio.UpdatedAt = time.Now()
+
+	if err := s.saveWithNewToken(ctx, portfolio); err != nil {
+		return nil, err
+	}
+
+	logger.InfoLog("Share token issued for portfolio %s", portfolioID)
+
+	return toPortfolioShare(portfolio), nil
+}
+
+// Отзыв токена доступа
+func (s *shareService) RevokeShare(ctx context.Context, userID, portfolioID string) (*domain.PortfolioShare, error) {
+	portfolio, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	portfolio.HasToken = false
+	portfolio.Token = ""
+	portfolio.ShareAmounts = false
+	portfolio.UpdatedAt = time.Now()
+
+	if err := s.portfoliosRepo.Update(ctx, portfolio); err != nil {
+		return nil, err
+	}
+
+	logger.InfoLog("Share token revoked for portfolio %s", portfolioID)
+
+	return toPortfolioShare(portfolio), nil
+}
+
+// Получение публичного представления портфеля по токену
+func (s *shareService) GetPublicPortfolio(ctx context.Context, token string) (*domain.PublicPortfolio, error) {
+	if len(token) != shareTokenLength {
+		return nil, models.ErrPortfolioNotFound
+	}
+
+	portfolio, err := s.portfoliosRepo.FindByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	valuation, err := s.valuationService.Valuate(ctx, portfolio, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	public := &domain.PublicPortfolio{
+		Name:         portfolio.Name,
+		Currency:     valuation.Currency,
+		IsComposite:  portfolio.IsComposite,
+		YieldPercent: valuation.YieldPercent,
+		Exposure:     make([]*domain.PublicExposure, 0, len(valuation.Exposure)),
+		Positions:    s.publicPositions(ctx, valuation, portfolio.ShareAmounts),
+		UpdatedAt:    portfolio.UpdatedAt,
+	}
+
+	for _, exposure := range valuation.Exposure {
+		public.Exposure = append(public.Exposure, &domain.PublicExposure{
+			Currency:     exposure.Currency,
+			SharePercent: exposure.SharePercent,
+		})
+	}
+
+	if portfolio.ShareAmounts {
+		public.TotalValue = &valuation.TotalValue
+		public.ExpectedYield = &valuation.ExpectedYield
+	}
+
+	return public, nil
+}
+
+// Сведение позиций дерева портфелей по инструменту с расчётом долей
+func (s *shareService) publicPositions(ctx context.Context, valuation *domain.PortfolioValuation, shareAmounts bool) []*domain.PublicPosition {
+	type holding struct {
+		position *domain.PublicPosition
+		uid      string
+		quantity int32
+		value    float64
+		invested float64
+	}
+
+	byInstrument := make(map[string]*holding)
+	order := make([]string, 0, len(valuation.Positions))
+
+	for _, position := range valuation.Positions {
+		current, ok := byInstrument[position.InstrumentUid]
+		if !ok {
+			current = &holding{
+				uid: position.InstrumentUid,
+				position: &domain.PublicPosition{
+					Ticker:   position.Ticker,
+					Currency: position.Currency,
+				},
+			}
+			byInstrument[position.InstrumentUid] = current
+			order = append(order, position.InstrumentUid)
+		}
+
+		current.quantity += position.Quantity
+		current.value += position.ValueBase
+		current.invested += position.InvestedBase
+	}
+
+	instruments, err := s.instruments.GetInstrumentsByUids(ctx, order)
+	if err != nil {
+		logger.ErrorLog("Failed to load instruments for public portfolio: %v", err)
+	}
+
+	result := make([]*domain.PublicPosition, 0, len(order))
+	for _, uid := range order {
+		current := byInstrument[uid]
+		position := current.position
+
+		if instrument, ok := instruments[uid]; ok {
+			position.Name = instrument.Name
+			position.InstrumentType = instrument.InstrumentType
+		}
+
+		if valuation.TotalValue != 0 {
+			position.WeightPercent = current.value / valuation.TotalValue * 100
+		}
+		position.YieldPercent = yieldPercent(current.value-current.invested, current.invested)
+
+		if shareAmounts {
+			quantity, value := current.quantity, current.value
+			position.Quantity = &quantity
+			position.Value = &value
+		}
+
+		result = append(result, position)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].WeightPercent > result[j].WeightPercent
+	})
+
+	return result
+}
+
+// Сохранение портфеля с новым токеном; при совпадении токена с чужим выпускается другой
+func (s *shareService) saveWithNewToken(ctx context.Context, portfolio *domain.Portfolio) error {
+	for i := 0; i < shareTokenAttempts; i++ {
+		token, err := generateShareToken()
+		if err != nil {
+			return err
+		}
+
+		portfolio.Token = token
+
+		err = s.portfoliosRepo.Update(ctx, portfolio)
+		if !errors.Is(err, models.ErrShareTokenTaken) {
+			return err
+		}
+	}
+
+	return models.ErrShareTokenTaken
+}
+
+// Случайный токен из букв и цифр без похожих символов
+func generateShareToken() (string, error) {
+	alphabetSize := big.NewInt(int64(len(shareTokenAlphabet)))
+	token := make([]byte, shareTokenLength)
+
+	for i := range token {
+		index, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", err
+		}
+		token[i] = shareTokenAlphabet[index.Int64()]
+	}
+
+	return string(token), nil
+}
+
+// Состояние доступа к портфелю
+func toPortfolioShare(portfolio *domain.Portfolio) *domain.PortfolioShare {
+	return &domain.PortfolioShare{
+		PortfolioID:  portfolio.ID,
+		HasToken:     portfolio.HasToken,
+		Token:        portfolio.Token,
+		ShareAmounts: portfolio.ShareAmounts,
+	}
+}
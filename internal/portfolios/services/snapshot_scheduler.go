@@ -0,0 +1,24 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"invest-mate/pkg/scheduler"
+)
+
+// Периодическое сохранение снимков портфелей.
+// Снимок за день перезаписывается при каждом запуске, поэтому в истории остаётся последняя оценка дня
+type SnapshotScheduler struct {
+	*scheduler.Runner
+}
+
+// Создание планировщика снимков с интервалом запуска (первый снимок — сразу после старта)
+func NewSnapshotScheduler(service SnapshotsService, interval time.Duration) *SnapshotScheduler {
+	return &SnapshotScheduler{
+		Runner: scheduler.NewRunner("Snapshot", interval, func(ctx context.Context) error {
+			_, err := service.TakeSnapshots(ctx)
+			return err
+		}),
+	}
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/internal/portfolios/repository"
+	"invest-mate/pkg/logger"
+)
+
+const (
+	GranularityDay   = "day"
+	GranularityWeek  = "week"
+	GranularityMonth = "month"
+)
+
+type SnapshotsService interface {
+	TakeSnapshots(ctx context.Context) (int, error)
+	TakeSnapshot(ctx context.Context, userID, portfolioID string) (*domain.PortfolioSnapshot, error)
+	GetHistory(ctx context.Context, userID, portfolioID, granularity string, from, to *time.Time, withPositions bool) (*domain.PortfolioHistory, error)
+}
+
+type snapshotsService struct {
+	portfoliosService PortfoliosService
+	portfoliosRepo    repository.PortfoliosRepository
+	snapshotsRepo     repository.SnapshotsRepository
+	valuationService  ValuationService
+	rates             ExchangeRateSource
+}
+
+// Создание нового сервиса снимков стоимости портфелей
+func NewSnapshotsService(
+	portfoliosService PortfoliosService,
+	portfoliosRepo repository.PortfoliosRepository,
+	snapshotsRepo repository.SnapshotsRepository,
+	valuationService ValuationService,
+	rates ExchangeRateSource,
+) SnapshotsService {
+	return &snapshotsService{
+		portfoliosService: portfoliosService,
+		portfoliosRepo:    portfoliosRepo,
+		snapshotsRepo:     snapshotsRepo,
+		valuationService:  valuationService,
+		rates:             rates,
+	}
+}
+
+// Сохранение снимков всех портфелей за текущий день; возвращает число сохранённых снимков
+func (s *snapshotsService) TakeSnapshots(ctx context.Context) (int, error) {
+	portfolios, err := s.portfoliosRepo.GetAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// Курсы загружаются один раз на все портфели; при ошибке каждый портфель
+	// загружает курсы сам, и сбой затрагивает только его снимок
+	rates, err := s.rates.GetExchangeRates(ctx)
+	if err != nil {
+		logger.ErrorLog("Failed to load exchange rates for snapshots: %v", err)
+		rates = nil
+	}
+
+	saved := 0
+	for _, portfolio := range portfolios {
+		if _, err := s.saveSnapshot(ctx, portfolio, rates); err != nil {
+			logger.ErrorLog("Failed to save snapshot of portfolio %s: %v", portfolio.ID, err)
+			continue
+		}
+		saved++
+	}
+
+	logger.InfoLog("Portfolio snapshots saved: %d of %d", saved, len(portfolios))
+
+	return saved, nil
+}
+
+// Сохранение снимка портфеля за текущий день по запросу пользователя
+func (s *snapshotsService) TakeSnapshot(ctx context.Context, userID, portfolioID string) (*domain.PortfolioSnapshot, error) {
+	portfolio, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.saveSnapshot(ctx, portfolio, nil)
+}
+
+// Получение истории стоимости портфеля по снимкам
+func (s *snapshotsService) GetHistory(ctx context.Context, userID, portfolioID, granularity string, from, to *time.Time, withPositions bool) (*domain.PortfolioHistory, error) {
+	granularity = strings.ToLower(firstNonEmpty(granularity, GranularityDay))
+
+	if granularity != GranularityDay && granularity != GranularityWeek && granularity != GranularityMonth {
+		return nil, fmt.Errorf("%w: granularity must be day, week or month", models.ErrInvalidRequest)
+	}
+
+	if _, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID); err != nil {
+		return nil, err
+	}
+
+	snapshots, err := s.snapshotsRepo.GetByPortfolio(ctx, portfolioID, from, to, withPositions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PortfolioHistory{
+		PortfolioID: portfolioID,
+		Granularity: granularity,
+		From:        from,
+		To:          to,
+		Points:      downsampleSnapshots(snapshots, granularity),
+	}, nil
+}
+
+// Оценка портфеля и сохранение снимка за текущий день
+func (s *snapshotsService) saveSnapshot(ctx context.Context, portfolio *domain.Portfolio, rates map[string]float64) (*domain.PortfolioSnapshot, error) {
+	valuation, err := s.valuationService.Valuate(ctx, portfolio, "", rates)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &domain.PortfolioSnapshot{
+		PortfolioID:    portfolio.ID,
+		Date:           snapshotDate(time.Now()),
+		Currency:       valuation.Currency,
+		TotalValue:     valuation.TotalValue,
+		InvestedAmount: valuation.InvestedAmount,
+		ExpectedYield:  valuation.ExpectedYield,
+		YieldPercent:   valuation.YieldPercent,
+		Positions:      make([]*domain.SnapshotPosition, 0, len(valuation.Positions)),
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	for _, position := range valuation.Positions {
+		snapshot.Positions = append(snapshot.Positions, &domain.SnapshotPosition{
+			InstrumentUid: position.InstrumentUid,
+			Ticker:        position.Ticker,
+			Currency:      position.Currency,
+			Quantity:      position.Quantity,
+			Rate:          position.Rate,
+			Value:         position.Value,
+			ValueBase:     position.ValueBase,
+			InvestedBase:  position.InvestedBase,
+			ExpectedYield: position.ExpectedYieldBase,
+		})
+	}
+
+	if err := s.snapshotsRepo.Save(ctx, snapshot); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// Дата снимка (начало суток по UTC)
+func snapshotDate(moment time.Time) time.Time {
+	year, month, day := moment.UTC().Date()
+
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// Прореживание снимков: для недели и месяца берётся последний снимок периода
+func downsampleSnapshots(snapshots []*domain.PortfolioSnapshot, granularity string) []*domain.PortfolioSnapshot {
+	if granularity == GranularityDay {
+		return snapshots
+	}
+
+	result := make([]*domain.PortfolioSnapshot, 0)
+	lastBucket := ""
+
+	for _, snapshot := range snapshots {
+		bucket := snapshotBucket(snapshot.Date, granularity)
+
+		if bucket == lastBucket && len(result) > 0 {
+			result[len(result)-1] = snapshot
+			continue
+		}
+
+		result = append(result, snapshot)
+		lastBucket = bucket
+	}
+
+	return result
+}
+
+// Ключ периода снимка
+func snapshotBucket(date time.Time, granularity string) string {
+	if granularity == GranularityWeek {
+		year, week := date.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	}
+
+	return date.Format("2006-01")
+}
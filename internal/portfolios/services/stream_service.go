@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	assetsDomain "invest-mate/internal/assets/models/domain"
+	"invest-mate/internal/marketdata/stream"
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/internal/portfolios/repository"
+)
+
+// Подписка на изменения цен (реализуется хабом модуля рыночных данных)
+type PriceStream interface {
+	Subscribe(uids []string) *stream.Subscription
+}
+
+type StreamService interface {
+	SubscribePortfolio(ctx context.Context, userID, portfolioID string) (*PortfolioSubscription, error)
+}
+
+type streamService struct {
+	portfoliosService PortfoliosService
+	compositeService  CompositeService
+	positionsRepo     repository.PositionsRepository
+	instruments       InstrumentResolver
+	prices            PriceStream
+}
+
+// Создание нового сервиса потоковой переоценки портфелей
+func NewStreamService(
+	portfoliosService PortfoliosService,
+	compositeService CompositeService,
+	positionsRepo repository.PositionsRepository,
+	instruments InstrumentResolver,
+	prices PriceStream,
+) StreamService {
+	return &streamService{
+		portfoliosService: portfoliosService,
+		compositeService:  compositeService,
+		positionsRepo:     positionsRepo,
+		instruments:       instruments,
+		prices:            prices,
+	}
+}
+
+// Подписка на цены бумаг портфеля и вложенных портфелей.
+// Состав позиций фиксируется в момент подписки
+func (s *streamService) SubscribePortfolio(ctx context.Context, userID, portfolioID string) (*PortfolioSubscription, error) {
+	if _, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID); err != nil {
+		return nil, err
+	}
+
+	portfolioIDs, err := s.compositeService.GetTreePortfolioIDs(ctx, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	positions, err := s.positionsRepo.GetByPortfolios(ctx, portfolioIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	byInstrument := make(map[string][]*domain.Position)
+	uids := make([]string, 0, len(positions))
+
+	for _, position := range positions {
+		if position.Quantity == 0 {
+			continue
+		}
+
+		if _, ok := byInstrument[position.InstrumentUid]; !ok {
+			uids = append(uids, position.InstrumentUid)
+		}
+		byInstrument[position.InstrumentUid] = append(byInstrument[position.InstrumentUid], position)
+	}
+
+	if len(uids) == 0 {
+		return nil, fmt.Errorf("%w: portfolio has no open positions", models.ErrInvalidRequest)
+	}
+
+	instruments, err := s.instruments.GetInstrumentsByUids(ctx, uids)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PortfolioSubscription{
+		subscription: s.prices.Subscribe(uids),
+		positions:    byInstrument,
+		instruments:  instruments,
+	}, nil
+}
+
+// Подписка на переоценку позиций портфеля
+type PortfolioSubscription struct {
+	subscription *stream.Subscription
+	positions    map[string][]*domain.Position
+	instruments  map[string]assetsDomain.Instrument
+}
+
+// Канал изменений цен бумаг портфеля
+func (s *PortfolioSubscription) Ticks() <-chan stream.Tick {
+	return s.subscription.Ticks()
+}
+
+// Отписка
+func (s *PortfolioSubscription) Close() {
+	s.subscription.Close()
+}
+
+// Переоценка позиций инструмента по новой цене
+func (s *PortfolioSubscription) Revalue(tick stream.Tick) []*domain.PositionTick {
+	price := tick.Price
+	if instrument, ok := s.instruments[tick.InstrumentUid]; ok {
+		price = positionPrice(instrument, tick.Price)
+	}
+
+	result := make([]*domain.PositionTick, 0, len(s.positions[tick.InstrumentUid]))
+
+	for _, position := range s.positions[tick.InstrumentUid] {
+		revalued := *position
+		revalued.CurrentPrice = price
+
+		item := &domain.PositionTick{
+			PositionID:     position.ID,
+			PortfolioID:    position.PortfolioID,
+			InstrumentUid:  position.InstrumentUid,
+			Ticker:         position.Ticker,
+			Currency:       s.instruments[tick.InstrumentUid].Currency,
+			Quantity:       position.Quantity,
+			Price:          price,
+			Value:          positionValue(&revalued),
+			InvestedAmount: positionInvestedAmount(position),
+			Time:           tick.Time,
+		}
+
+		item.ExpectedYield = item.Value - item.InvestedAmount
+		item.YieldPercent = yieldPercent(item.ExpectedYield, item.InvestedAmount)
+
+		result = append(result, item)
+	}
+
+	return result
+}
@@ -0,0 +1,440 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	assetsDomain "invest-mate/internal/assets/models/domain"
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/internal/portfolios/repository"
+	sharedModels "invest-mate/internal/shared/models"
+	"invest-mate/pkg/logger"
+	"invest-mate/pkg/services"
+)
+
+type TransactionsService interface {
+	GetTransactions(ctx context.Context, userID, portfolioID string, filter *domain.TransactionFilter, page, limit int) ([]*domain.Transaction, int64, error)
+	GetTransaction(ctx context.Context, userID, portfolioID, transactionID string) (*domain.Transaction, error)
+	CreateTransaction(ctx context.Context, userID, portfolioID string, req *domain.CreateTransactionRequest) (*domain.Transaction, error)
+	UpdateTransaction(ctx context.Context, userID, portfolioID, transactionID string, req *domain.UpdateTransactionRequest) (*domain.Transaction, error)
+	DeleteTransaction(ctx context.Context, userID, portfolioID, transactionID string) (bool, error)
+	RecalculatePositions(ctx context.Context, userID, portfolioID string) ([]*domain.Position, error)
+}
+
+type transactionsService struct {
+	portfoliosService PortfoliosService
+	transactionsRepo  repository.TransactionsRepository
+	positionsRepo     repository.PositionsRepository
+	instruments       InstrumentResolver
+}
+
+// Создание нового сервиса операций
+func NewTransactionsService(
+	portfoliosService PortfoliosService,
+	transactionsRepo repository.TransactionsRepository,
+	positionsRepo repository.PositionsRepository,
+	instruments InstrumentResolver,
+) TransactionsService {
+	return &transactionsService{
+		portfoliosService: portfoliosService,
+		transactionsRepo:  transactionsRepo,
+		positionsRepo:     positionsRepo,
+		instruments:       instruments,
+	}
+}
+
+// Получение операций портфеля
+func (s *transactionsService) GetTransactions(ctx context.Context, userID, portfolioID string, filter *domain.TransactionFilter, page, limit int) ([]*domain.Transaction, int64, error) {
+	if _, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID); err != nil {
+		return nil, 0, err
+	}
+
+	limit, offset := services.PageOffset(page, limit)
+
+	transactions, err := s.transactionsRepo.GetByPortfolio(ctx, portfolioID, filter, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.transactionsRepo.CountByPortfolio(ctx, portfolioID, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return transactions, total, nil
+}
+
+// Получение операции портфеля
+func (s *transactionsService) GetTransaction(ctx context.Context, userID, portfolioID, transactionID string) (*domain.Transaction, error) {
+	if _, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID); err != nil {
+		return nil, err
+	}
+
+	return s.findTransaction(ctx, portfolioID, transactionID)
+}
+
+// Создание операции и пересчёт позиции по инструменту
+func (s *transactionsService) CreateTransaction(ctx context.Context, userID, portfolioID string, req *domain.CreateTransactionRequest) (*domain.Transaction, error) {
+	req.Type = models.TransactionType(strings.ToUpper(string(req.Type)))
+
+	if err := ValidateCreateTransactionRequest(req); err != nil {
+		return nil, err
+	}
+
+	portfolio, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	if portfolio.IsComposite {
+		return nil, models.ErrCompositePortfolio
+	}
+
+	transaction := &domain.Transaction{
+		ID:          uuid.New().String(),
+		PortfolioID: portfolioID,
+		Type:        req.Type,
+		Quantity:    req.Quantity,
+		Price:       req.Price,
+		Amount:      req.Amount,
+		Commission:  req.Commission,
+		Currency:    strings.ToUpper(req.Currency),
+		Note:        req.Note,
+		ExecutedAt:  time.Now(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if req.ExecutedAt != nil {
+		transaction.ExecutedAt = *req.ExecutedAt
+	}
+
+	if transaction.Type == models.TransactionTypeSell {
+		transaction.Lots = req.Lots
+	}
+
+	var instrument *assetsDomain.Instrument
+
+	// Пополнение и вывод средств не привязаны к инструменту
+	if !req.Type.IsCashFlow() && (req.InstrumentUid != "" || req.Figi != "" || req.Ticker != "") {
+		instrument, err = resolveInstrument(ctx, s.instruments, req.InstrumentUid, req.Figi, req.Ticker)
+		if err != nil {
+			return nil, err
+		}
+
+		transaction.InstrumentUid = instrument.Uid
+		transaction.Figi = instrument.Figi
+		transaction.Ticker = instrument.Ticker
+	}
+
+	if transaction.Currency == "" {
+		transaction.Currency = portfolio.Currency
+		if instrument != nil && instrument.Currency != "" {
+			transaction.Currency = strings.ToUpper(instrument.Currency)
+		}
+	}
+
+	if !transaction.Type.IsTrade() {
+		transaction.Quantity = 0
+		transaction.Price = 0
+	} else if transaction.Amount == 0 {
+		transaction.Amount = tradeAmount(transaction)
+	}
+
+	if transaction.Type.IsTrade() {
+		if _, err := s.replayWith(ctx, portfolioID, transaction.InstrumentUid, transaction, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.transactionsRepo.Create(ctx, transaction); err != nil {
+		return nil, err
+	}
+
+	if transaction.Type.IsTrade() {
+		if _, err := s.syncPosition(ctx, portfolioID, instrument); err != nil {
+			return nil, err
+		}
+	}
+
+	logger.InfoLog("Transaction %s (%s) added to portfolio %s", transaction.ID, transaction.Type, portfolioID)
+
+	return transaction, nil
+}
+
+// Изменение операции и пересчёт позиции по инструменту
+func (s *transactionsService) UpdateTransaction(ctx context.Context, userID, portfolioID, transactionID string, req *domain.UpdateTransactionRequest) (*domain.Transaction, error) {
+	if err := ValidateUpdateTransactionRequest(req); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID); err != nil {
+		return nil, err
+	}
+
+	transaction, err := s.findTransaction(ctx, portfolioID, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if transaction.Type.IsTrade() {
+		if req.Quantity != nil {
+			transaction.Quantity = *req.Quantity
+		}
+		if req.Price != nil {
+			transaction.Price = *req.Price
+		}
+		if req.Amount == nil && (req.Quantity != nil || req.Price != nil) {
+			transaction.Amount = tradeAmount(transaction)
+		}
+	}
+	if req.Amount != nil {
+		transaction.Amount = *req.Amount
+	}
+	if req.Commission != nil {
+		transaction.Commission = *req.Commission
+	}
+	if req.Currency != nil {
+		transaction.Currency = strings.ToUpper(*req.Currency)
+	}
+	if req.Note != nil {
+		transaction.Note = *req.Note
+	}
+	if req.ExecutedAt != nil {
+		transaction.ExecutedAt = *req.ExecutedAt
+	}
+	if req.Lots != nil {
+		if transaction.Type != models.TransactionTypeSell {
+			return nil, fmt.Errorf("%w: lots can be selected only for SELL", models.ErrInvalidRequest)
+		}
+		transaction.Lots = *req.Lots
+	}
+
+	transaction.UpdatedAt = time.Now()
+
+	if transaction.Type.IsTrade() {
+		if _, err := s.replayWith(ctx, portfolioID, transaction.InstrumentUid, transaction, transaction.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.transactionsRepo.Update(ctx, transaction); err != nil {
+		return nil, err
+	}
+
+	if transaction.Type.IsTrade() {
+		if _, err := s.syncPositionByUid(ctx, portfolioID, transaction.InstrumentUid); err != nil {
+			return nil, err
+		}
+	}
+
+	return transaction, nil
+}
+
+// Удаление операции и пересчёт позиции по инструменту
+func (s *transactionsService) DeleteTransaction(ctx context.Context, userID, portfolioID, transactionID string) (bool, error) {
+	if _, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID); err != nil {
+		return false, err
+	}
+
+	transaction, err := s.findTransaction(ctx, portfolioID, transactionID)
+	if err != nil {
+		return false, err
+	}
+
+	if transaction.Type.IsTrade() {
+		if _, err := s.replayWith(ctx, portfolioID, transaction.InstrumentUid, nil, transaction.ID); err != nil {
+			return false, err
+		}
+	}
+
+	deleted, err := s.transactionsRepo.Delete(ctx, portfolioID, transactionID)
+	if err != nil {
+		return false, err
+	}
+
+	if deleted && transaction.Type.IsTrade() {
+		if _, err := s.syncPositionByUid(ctx, portfolioID, transaction.InstrumentUid); err != nil {
+			return false, err
+		}
+	}
+
+	return deleted, nil
+}
+
+// Пересчёт всех позиций портфеля по журналу сделок
+func (s *transactionsService) RecalculatePositions(ctx context.Context, userID, portfolioID string) ([]*domain.Position, error) {
+	portfolio, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	if portfolio.IsComposite {
+		return nil, models.ErrCompositePortfolio
+	}
+
+	uids, err := s.transactionsRepo.GetTradedInstrumentUids(ctx, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, uid := range uids {
+		if _, err := s.syncPositionByUid(ctx, portfolioID, uid); err != nil {
+			return nil, err
+		}
+	}
+
+	logger.InfoLog("Positions of portfolio %s recalculated from %d instruments", portfolioID, len(uids))
+
+	return s.positionsRepo.GetByPortfolio(ctx, portfolioID)
+}
+
+// Поиск операции с проверкой идентификатора
+func (s *transactionsService) findTransaction(ctx context.Context, portfolioID, transactionID string) (*domain.Transaction, error) {
+	if _, err := uuid.Parse(transactionID); err != nil {
+		return nil, models.ErrTransactionNotFound
+	}
+
+	return s.transactionsRepo.FindByID(ctx, portfolioID, transactionID)
+}
+
+// Воспроизведение журнала по инструменту с заменой (или удалением) одной операции
+func (s *transactionsService) replayWith(ctx context.Context, portfolioID, instrumentUid string, candidate *domain.Transaction, excludeID string) (*ledgerPosition, error) {
+	transactions, err := s.transactionsRepo.GetByInstrument(ctx, portfolioID, instrumentUid)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*domain.Transaction, 0, len(transactions)+1)
+	for _, transaction := range transactions {
+		if excludeID != "" && transaction.ID == excludeID {
+			continue
+		}
+		result = append(result, transaction)
+	}
+
+	if candidate != nil {
+		result = append(result, candidate)
+	}
+
+	return replayTrades(result, models.CostMethodSpecific)
+}
+
+// Пересчёт позиции по идентификатору инструмента
+func (s *transactionsService) syncPositionByUid(ctx context.Context, portfolioID, instrumentUid string) (*domain.Position, error) {
+	instrument, err := resolveInstrument(ctx, s.instruments, instrumentUid, "", "")
+	if err != nil && !errors.Is(err, models.ErrInstrumentNotFound) {
+		return nil, err
+	}
+
+	if instrument == nil {
+		instrument = &assetsDomain.Instrument{Uid: instrumentUid}
+	}
+
+	return s.syncPosition(ctx, portfolioID, instrument)
+}
+
+// Пересчёт позиции по журналу сделок: количество и средние цены берутся из журнала,
+// закрытая позиция удаляется
+func (s *transactionsService) syncPosition(ctx context.Context, portfolioID string, instrument *assetsDomain.Instrument) (*domain.Position, error) {
+	transactions, err := s.transactionsRepo.GetByInstrument(ctx, portfolioID, instrument.Uid)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := replayTrades(transactions, models.CostMethodSpecific)
+	if err != nil {
+		return nil, err
+	}
+
+	fifo, err := replayTrades(transactions, models.CostMethodFifo)
+	if err != nil {
+		return nil, err
+	}
+
+	position, err := s.positionsRepo.FindByInstrument(ctx, portfolioID, instrument.Uid)
+	if err != nil && !errors.Is(err, models.ErrPositionNotFound) {
+		return nil, err
+	}
+
+	if state.Quantity == 0 {
+		if position != nil {
+			if _, err := s.positionsRepo.Delete(ctx, portfolioID, position.ID); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	}
+
+	isNew := position == nil
+	if isNew {
+		position = &domain.Position{
+			ID:            uuid.New().String(),
+			PortfolioID:   portfolioID,
+			InstrumentUid: instrument.Uid,
+			Figi:          instrument.Figi,
+			Ticker:        instrument.Ticker,
+			PositionUid:   instrument.PositionUid,
+			CreatedAt:     time.Now(),
+		}
+
+		if len(transactions) > 0 {
+			position.Figi = firstNonEmpty(position.Figi, transactions[0].Figi)
+			position.Ticker = firstNonEmpty(position.Ticker, transactions[0].Ticker)
+		}
+	}
+
+	position.IsCustomData = true
+	position.Quantity = state.Quantity
+	position.QuantityLots = quantityToLots(state.Quantity, instrument.Lot)
+	position.AveragePositionPrice = state.AveragePrice
+	position.AveragePositionPriceFifo = averageLotPrice(fifo.OpenLots)
+	position.AveragePositionPricePt = priceInPoints(state.AveragePrice, instrument)
+	position.UpdatedAt = time.Now()
+
+	if position.CurrentPrice > 0 {
+		position.ExpectedYield = positionValue(position) - positionInvestedAmount(position)
+	}
+
+	if isNew {
+		err = s.positionsRepo.Create(ctx, position)
+	} else {
+		err = s.positionsRepo.Update(ctx, position)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return position, nil
+}
+
+// Цена в пунктах: для облигаций — процент от номинала, для остальных инструментов совпадает с ценой
+func priceInPoints(price float64, instrument *assetsDomain.Instrument) float64 {
+	if instrument.InstrumentType == sharedModels.InstrumentTypeBond && instrument.Nominal > 0 {
+		return price / instrument.Nominal * 100
+	}
+
+	return price
+}
+
+// Сумма сделки
+func tradeAmount(transaction *domain.Transaction) float64 {
+	return float64(transaction.Quantity) * transaction.Price
+}
+
+// Первое непустое значение
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"invest-mate/internal/portfolios/models"
+	"invest-mate/internal/portfolios/models/domain"
+	"invest-mate/internal/portfolios/repository"
+	"invest-mate/pkg/logger"
+)
+
+// Источник курсов валют к рублю (реализуется хранилищем модуля активов)
+type ExchangeRateSource interface {
+	GetExchangeRates(ctx context.Context) (map[string]float64, error)
+}
+
+type ValuationService interface {
+	GetValuation(ctx context.Context, userID, portfolioID, currency string) (*domain.PortfolioValuation, error)
+	Valuate(ctx context.Context, portfolio *domain.Portfolio, currency string, rates map[string]float64) (*domain.PortfolioValuation, error)
+}
+
+type valuationService struct {
+	portfoliosService PortfoliosService
+	compositeService  CompositeService
+	positionsRepo     repository.PositionsRepository
+	instruments       InstrumentResolver
+	rates             ExchangeRateSource
+}
+
+// Создание нового сервиса оценки портфелей
+func NewValuationService(
+	portfoliosService PortfoliosService,
+	compositeService CompositeService,
+	positionsRepo repository.PositionsRepository,
+	instruments InstrumentResolver,
+	rates ExchangeRateSource,
+) ValuationService {
+	return &valuationService{
+		portfoliosService: portfoliosService,
+		compositeService:  compositeService,
+		positionsRepo:     positionsRepo,
+		instruments:       instruments,
+		rates:             rates,
+	}
+}
+
+// Оценка позиций портфеля и вложенных портфелей в базовой валюте
+// (по умолчанию — валюте портфеля)
+func (s *valuationService) GetValuation(ctx context.Context, userID, portfolioID, currency string) (*domain.PortfolioValuation, error) {
+	portfolio, err := s.portfoliosService.GetPortfolio(ctx, userID, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Valuate(ctx, portfolio, currency, nil)
+}
+
+// Оценка портфеля без проверки владельца; курсы загружаются, если не переданы
+func (s *valuationService) Valuate(ctx context.Context, portfolio *domain.Portfolio, currency string, rates map[string]float64) (*domain.PortfolioValuation, error) {
+	portfolioID := portfolio.ID
+
+	base := strings.ToUpper(firstNonEmpty(currency, portfolio.Currency))
+	if err := validatePortfolioCurrency(base); err != nil {
+		return nil, err
+	}
+
+	if rates == nil {
+		var err error
+
+		rates, err = s.rates.GetExchangeRates(ctx)
+		if err != nil {
+			logger.ErrorLog("Failed to load exchange rates: %v", err)
+			return nil, models.ErrMarketDataUnavailable
+		}
+	}
+
+	baseRate, ok := rates[base]
+	if !ok {
+		return nil, fmt.Errorf("%w %s", models.ErrExchangeRateNotFound, base)
+	}
+
+	portfolioIDs, err := s.compositeService.GetTreePortfolioIDs(ctx, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	positions, err := s.positionsRepo.GetByPortfolios(ctx, portfolioIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	currencies := s.positionCurrencies(ctx, positions)
+
+	valuation := &domain.PortfolioValuation{
+		PortfolioID:  portfolioID,
+		PortfolioIDs: portfolioIDs,
+		Currency:     base,
+		Rates:        make(map[string]float64),
+		Exposure:     make([]*domain.CurrencyExposure, 0),
+		Positions:    make([]*domain.PositionValuation, 0, len(positions)),
+		Unvalued:     make([]string, 0),
+	}
+
+	exposure := make(map[string]*domain.CurrencyExposure)
+
+	for _, position := range positions {
+		// Позиция без известной валюты инструмента не оценивается
+		positionCurrency := currencies[position.InstrumentUid]
+
+		rate, ok := rates[positionCurrency]
+		if positionCurrency == "" || !ok {
+			valuation.Unvalued = append(valuation.Unvalued, firstNonEmpty(position.Ticker, position.InstrumentUid))
+			continue
+		}
+
+		// Курс валюты позиции к базовой валюте через рубль
+		rate /= baseRate
+		valuation.Rates[positionCurrency] = rate
+
+		item := &domain.PositionValuation{
+			PositionID:     position.ID,
+			PortfolioID:    position.PortfolioID,
+			InstrumentUid:  position.InstrumentUid,
+			Ticker:         position.Ticker,
+			Currency:       positionCurrency,
+			Quantity:       position.Quantity,
+			Rate:           rate,
+			Value:          positionValue(position),
+			InvestedAmount: positionInvestedAmount(position),
+		}
+
+		item.ValueBase = item.Value * rate
+		item.InvestedBase = item.InvestedAmount * rate
+		item.ExpectedYieldBase = item.ValueBase - item.InvestedBase
+
+		valuation.Positions = append(valuation.Positions, item)
+		valuation.TotalValue += item.ValueBase
+		valuation.InvestedAmount += item.InvestedBase
+
+		currencyExposure, ok := exposure[positionCurrency]
+		if !ok {
+			currencyExposure = &domain.CurrencyExposure{Currency: positionCurrency}
+			exposure[positionCurrency] = currencyExposure
+			valuation.Exposure = append(valuation.Exposure, currencyExposure)
+		}
+
+		currencyExposure.Value += item.Value
+		currencyExposure.ValueBase += item.ValueBase
+	}
+
+	valuation.ExpectedYield = valuation.TotalValue - valuation.InvestedAmount
+	valuation.YieldPercent = yieldPercent(valuation.ExpectedYield, valuation.InvestedAmount)
+
+	for _, currencyExposure := range valuation.Exposure {
+		if valuation.TotalValue != 0 {
+			currencyExposure.SharePercent = currencyExposure.ValueBase / valuation.TotalValue * 100
+		}
+	}
+
+	sort.Slice(valuation.Exposure, func(i, j int) bool {
+		return valuation.Exposure[i].ValueBase > valuation.Exposure[j].ValueBase
+	})
+
+	return valuation, nil
+}
+
+// Валюты инструментов позиций
+func (s *valuationService) positionCurrencies(ctx context.Context, positions []*domain.Position) map[string]string {
+	uids := make([]string, 0, len(positions))
+	for _, position := range positions {
+		uids = append(uids, position.InstrumentUid)
+	}
+
+	currencies := make(map[string]string, len(uids))
+	if len(uids) == 0 {
+		return currencies
+	}
+
+	instruments, err := s.instruments.GetInstrumentsByUids(ctx, uids)
+	if err != nil {
+		// Без сведений об инструментах позиции попадают в неоценённые
+		logger.ErrorLog("Failed to load instruments for valuation: %v", err)
+		return currencies
+	}
+
+	for uid, instrument := range instruments {
+		currencies[uid] = strings.ToUpper(instrument.Currency)
+	}
+
+	return currencies
+}
@@ -7,12 +7,15 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"invest-mate/internal/shared/config"
 	"invest-mate/pkg/logger"
 )
 
+const defaultTinkoffBaseURL = "https://invest-public-api.tbank.ru/rest/"
+
 type TinkoffClient struct {
 	baseURL    string
 	token      string
@@ -23,9 +26,23 @@ type TinkoffClient struct {
 func NewTinkoffClient() *TinkoffClient {
 	cfg := config.GetConfig()
 
+	return NewTinkoffClientWithOptions(cfg.TinkoffAPIURL, cfg.TinkoffToken)
+}
+
+// Создание клиента Tinkoff с собственным адресом API и токеном
+// (пустой адрес заменяется адресом публичного API)
+func NewTinkoffClientWithOptions(baseURL, token string) *TinkoffClient {
+	if baseURL == "" {
+		baseURL = defaultTinkoffBaseURL
+	}
+
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+
 	return &TinkoffClient{
-		baseURL: "https://invest-public-api.tbank.ru/rest/",
-		token:   cfg.TinkoffToken,
+		baseURL: baseURL,
+		token:   token,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
@@ -15,12 +15,33 @@ import (
 
 type Config struct {
 	TinkoffToken   string
+	TinkoffAPIURL  string
 	Port           string
 	Env            string
 	LogLevel       string
 	CacheTTL       int
 	MaxConnections int
 
+	SnapshotInterval     time.Duration
+	PriceRefreshInterval time.Duration
+
+	PriceStreamInterval time.Duration
+
+	AlertCheckInterval time.Duration
+
+	SMTPHost          string
+	SMTPPort          int
+	SMTPUsername      string
+	SMTPPassword      string
+	SMTPFrom          string
+	TelegramBotToken  string
+	TelegramAPIURL    string
+	NotifyMaxAttempts int
+	NotifyRetryDelay  time.Duration
+	NotifyTimeout     time.Duration
+	// Разрешить вебхуки на адреса локальной и частных сетей
+	WebhookAllowPrivate bool
+
 	CORSOrigins string
 
 	DBHost         string
@@ -44,12 +65,32 @@ func LoadEnv() *Config {
 
 	AppConfig = &Config{
 		TinkoffToken:   getEnv("TINKOFF_TOKEN", ""),
+		TinkoffAPIURL:  getEnv("TINKOFF_API_URL", ""),
 		Port:           getEnv("PORT", "8080"),
 		Env:            getEnv("ENV", "development"),
 		LogLevel:       getEnv("LOG_LEVEL", "info"),
 		CacheTTL:       getEnvAsInt("CACHE_TTL", 3600),
 		MaxConnections: getEnvAsInt("MAX_CONNECTIONS", 100),
 
+		SnapshotInterval:     time.Duration(getEnvAsInt("SNAPSHOT_INTERVAL_MINUTES", 60)) * time.Minute,
+		PriceRefreshInterval: time.Duration(getEnvAsInt("PRICE_REFRESH_INTERVAL_SECONDS", 300)) * time.Second,
+
+		PriceStreamInterval: time.Duration(getEnvAsInt("PRICE_STREAM_INTERVAL_SECONDS", 5)) * time.Second,
+
+		AlertCheckInterval: time.Duration(getEnvAsInt("ALERT_CHECK_INTERVAL_SECONDS", 60)) * time.Second,
+
+		SMTPHost:            getEnv("SMTP_HOST", ""),
+		SMTPPort:            getEnvAsInt("SMTP_PORT", 587),
+		SMTPUsername:        getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:        getEnv("SMTP_PASSWORD", ""),
+		SMTPFrom:            getEnv("SMTP_FROM", ""),
+		TelegramBotToken:    getEnv("TELEGRAM_BOT_TOKEN", ""),
+		TelegramAPIURL:      getEnv("TELEGRAM_API_URL", ""),
+		NotifyMaxAttempts:   getEnvAsInt("NOTIFY_MAX_ATTEMPTS", 5),
+		NotifyRetryDelay:    time.Duration(getEnvAsInt("NOTIFY_RETRY_DELAY_SECONDS", 5)) * time.Second,
+		NotifyTimeout:       time.Duration(getEnvAsInt("NOTIFY_TIMEOUT_SECONDS", 10)) * time.Second,
+		WebhookAllowPrivate: getEnv("WEBHOOK_ALLOW_PRIVATE_NETWORKS", "false") == "true",
+
 		CORSOrigins: getEnv("CORS_ORIGINS", ""),
 
 		DBHost:         getEnv("DB_HOST", "localhost"),
@@ -0,0 +1,51 @@
+package instruments
+
+import (
+	"context"
+	"fmt"
+
+	assetsDomain "invest-mate/internal/assets/models/domain"
+)
+
+// Поиск инструмента по полю (реализуется хранилищем модуля активов)
+type Finder interface {
+	FindInstrument(ctx context.Context, fieldName string, fieldValue string) (*assetsDomain.Instrument, error)
+}
+
+// Поиск инструмента по uid, figi или тикеру (в порядке приоритета).
+// Ошибки передаются модулем: invalid — запрос без идентификаторов, notFound — инструмент не найден
+func Resolve(ctx context.Context, finder Finder, uid, figi, ticker string, invalid, notFound error) (*assetsDomain.Instrument, error) {
+	lookups := []struct {
+		field string
+		value string
+	}{
+		{"uid", uid},
+		{"figi", figi},
+		{"ticker", ticker},
+	}
+
+	searched := false
+
+	for _, lookup := range lookups {
+		if lookup.value == "" {
+			continue
+		}
+
+		searched = true
+
+		instrument, err := finder.FindInstrument(ctx, lookup.field, lookup.value)
+		if err != nil {
+			return nil, err
+		}
+
+		if instrument != nil {
+			return instrument, nil
+		}
+	}
+
+	if !searched {
+		return nil, fmt.Errorf("%w: instrumentUid, figi or ticker is required", invalid)
+	}
+
+	return nil, notFound
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"invest-mate/internal/shared/api"
+	"invest-mate/internal/users/mappers"
+	"invest-mate/internal/users/models/domain"
+	"invest-mate/internal/users/models/dto"
+	"invest-mate/pkg/logger"
+)
+
+const (
+	getAccountsEndpoint  = "tinkoff.public.invest.api.contract.v1.UsersService/GetAccounts"
+	getPortfolioEndpoint = "tinkoff.public.invest.api.contract.v1.OperationsService/GetPortfolio"
+
+	defaultPortfolioCurrency = "RUB"
+)
+
+// Источник брокерских портфелей Tinkoff.
+// Клиент создаётся на каждый запрос, поскольку токен у каждого пользователя свой
+type PortfolioSource struct {
+	baseURL string
+}
+
+// Создание источника портфелей (пустой адрес — публичное API)
+func NewPortfolioSource(baseURL string) *PortfolioSource {
+	return &PortfolioSource{baseURL: baseURL}
+}
+
+// Получение счетов пользователя по токену
+func (s *PortfolioSource) GetAccounts(ctx context.Context, token string) ([]*domain.BrokerAccount, error) {
+	return GetAccounts(ctx, api.NewTinkoffClientWithOptions(s.baseURL, token))
+}
+
+// Получение портфеля счёта по токену
+func (s *PortfolioSource) GetPortfolio(ctx context.Context, token, accountID, currency string) (*domain.BrokerPortfolio, error) {
+	return GetPortfolio(ctx, api.NewTinkoffClientWithOptions(s.baseURL, token), accountID, currency)
+}
+
+// Получение счетов пользователя
+func GetAccounts(ctx context.Context, client *api.TinkoffClient) ([]*domain.BrokerAccount, error) {
+	var response dto.GetAccountsResponse
+
+	if err := doRequest(ctx, client, getAccountsEndpoint, map[string]string{}, &response); err != nil {
+		return nil, err
+	}
+
+	logger.InfoLog("Successfully parsed %d accounts from %s", len(response.Accounts), getAccountsEndpoint)
+
+	return mappers.FromAccountDtoToDomainSlice(response.Accounts), nil
+}
+
+// Получение портфеля счёта в указанной валюте
+func GetPortfolio(ctx context.Context, client *api.TinkoffClient, accountID, currency string) (*domain.BrokerPortfolio, error) {
+	if currency == "" {
+		currency = defaultPortfolioCurrency
+	}
+
+	body := map[string]string{
+		"accountId": accountID,
+		"currency":  currency,
+	}
+
+	var response dto.PortfolioResponse
+
+	if err := doRequest(ctx, client, getPortfolioEndpoint, body, &response); err != nil {
+		return nil, err
+	}
+
+	logger.InfoLog("Successfully parsed %d portfolio positions from %s", len(response.Positions), getPortfolioEndpoint)
+
+	return mappers.FromPortfolioDtoToDomain(response), nil
+}
+
+// Выполнение запроса к API и разбор ответа
+func doRequest(ctx context.Context, client *api.TinkoffClient, endpoint string, body any, target any) error {
+	resp, err := client.DoRequest(ctx, "POST", endpoint, body)
+
+	if err != nil {
+		return fmt.Errorf("request %s: %w", endpoint, err)
+	}
+
+	defer resp.Body.Close()
+
+	logger.InfoLog("%s API response status: %d", endpoint, resp.StatusCode)
+
+	if resp.StatusCode != http.StatusOK {
+		return client.HandleAPIError(resp, endpoint)
+	}
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+
+	if err != nil {
+		return fmt.Errorf("read response body: %w", err)
+	}
+
+	if err := json.NewDecoder(bytes.NewReader(bodyBytes)).Decode(target); err != nil {
+		logger.ErrorLog("Failed to decode JSON for %s. Body start: %s",
+			endpoint, string(bodyBytes[:min(500, len(bodyBytes))]))
+
+		return fmt.Errorf("decode DTO response for %s: %w", endpoint, err)
+	}
+
+	return nil
+}
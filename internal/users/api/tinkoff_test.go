@@ -0,0 +1,112 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Поддельный сервер API брокера с ответами на счета и портфель
+func newFakeBroker(t *testing.T) (*httptest.Server, *[]map[string]string) {
+	t.Helper()
+
+	var requests []map[string]string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer user-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"code":16,"message":"authentication token is missing or invalid"}`))
+			return
+		}
+
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode request body: %v", err)
+		}
+		requests = append(requests, body)
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, ".UsersService/GetAccounts"):
+			w.Write([]byte(`{"accounts":[{"id":"2000000001","type":"ACCOUNT_TYPE_TINKOFF","name":"Брокерский счёт","status":"ACCOUNT_STATUS_OPEN","accessLevel":"ACCOUNT_ACCESS_LEVEL_READ_ONLY"}]}`))
+		case strings.HasSuffix(r.URL.Path, ".OperationsService/GetPortfolio"):
+			w.Write([]byte(`{
+				"accountId": "2000000001",
+				"totalAmountPortfolio": {"currency": "rub", "units": "15250", "nano": 500000000},
+				"positions": [
+					{"figi": "BBG004730N88", "ticker": "SBER", "instrumentType": "share", "instrumentUid": "e6123145-9665-43e0-8413-cd61b8aa9b13",
+					 "quantity": {"units": "50", "nano": 0}, "quantityLots": {"units": "5", "nano": 0},
+					 "averagePositionPrice": {"currency": "rub", "units": "250", "nano": 100000000},
+					 "currentPrice": {"currency": "rub", "units": "305", "nano": 0}},
+					{"figi": "BBG000B9XRY4", "ticker": "AAPL", "instrumentType": "share",
+					 "quantity": {"units": "0", "nano": 250000000}, "quantityLots": {"units": "0", "nano": 250000000},
+					 "currentPrice": {"currency": "usd", "units": "190", "nano": 0}}
+				]
+			}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func TestPortfolioSourceGetAccounts(t *testing.T) {
+	server, _ := newFakeBroker(t)
+
+	accounts, err := NewPortfolioSource(server.URL).GetAccounts(t.Context(), "user-token")
+	if err != nil {
+		t.Fatalf("GetAccounts: %v", err)
+	}
+
+	if len(accounts) != 1 {
+		t.Fatalf("accounts = %d, want 1", len(accounts))
+	}
+	if accounts[0].ID != "2000000001" || accounts[0].Name != "Брокерский счёт" || accounts[0].Status != "ACCOUNT_STATUS_OPEN" {
+		t.Errorf("unexpected account %+v", accounts[0])
+	}
+}
+
+func TestPortfolioSourceGetPortfolio(t *testing.T) {
+	server, requests := newFakeBroker(t)
+
+	portfolio, err := NewPortfolioSource(server.URL).GetPortfolio(t.Context(), "user-token", "2000000001", "")
+	if err != nil {
+		t.Fatalf("GetPortfolio: %v", err)
+	}
+
+	if got := (*requests)[0]; got["accountId"] != "2000000001" || got["currency"] != defaultPortfolioCurrency {
+		t.Errorf("request body = %v, want account and default currency", got)
+	}
+
+	if portfolio.AccountID != "2000000001" || portfolio.TotalAmountPortfolio != 15250.5 {
+		t.Errorf("unexpected portfolio %+v", portfolio)
+	}
+	if len(portfolio.Positions) != 2 {
+		t.Fatalf("positions = %d, want 2", len(portfolio.Positions))
+	}
+
+	sber := portfolio.Positions[0]
+	if sber.Ticker != "SBER" || sber.Quantity != 50 || sber.QuantityLots != 5 || sber.AveragePositionPrice != 250.1 || sber.Currency != "rub" {
+		t.Errorf("unexpected position %+v", sber)
+	}
+
+	// Дробное количество передаётся как есть, решение о загрузке принимает сервис портфелей
+	if apple := portfolio.Positions[1]; apple.Quantity != 0.25 {
+		t.Errorf("fractional quantity = %v, want 0.25", apple.Quantity)
+	}
+}
+
+func TestPortfolioSourceRejectedToken(t *testing.T) {
+	server, _ := newFakeBroker(t)
+
+	_, err := NewPortfolioSource(server.URL).GetAccounts(t.Context(), "wrong-token")
+	if err == nil {
+		t.Fatal("expected error for rejected token")
+	}
+	if !strings.Contains(err.Error(), "status 401") {
+		t.Errorf("error = %v, want status 401", err)
+	}
+}
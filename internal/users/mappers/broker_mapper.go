@@ -0,0 +1,69 @@
+package mappers
+
+import (
+	"invest-mate/internal/users/models/domain"
+	"invest-mate/internal/users/models/dto"
+)
+
+func FromAccountDtoToDomain(dto dto.Account) *domain.BrokerAccount {
+	return &domain.BrokerAccount{
+		ID:          dto.Id,
+		Type:        dto.Type,
+		Name:        dto.Name,
+		Status:      dto.Status,
+		OpenedDate:  dto.OpenedDate,
+		ClosedDate:  dto.ClosedDate,
+		AccessLevel: dto.AccessLevel,
+	}
+}
+
+func FromAccountDtoToDomainSlice(dtoSlice []dto.Account) []*domain.BrokerAccount {
+	domainSlice := make([]*domain.BrokerAccount, len(dtoSlice))
+
+	for index, dto := range dtoSlice {
+		domainSlice[index] = FromAccountDtoToDomain(dto)
+	}
+
+	return domainSlice
+}
+
+func FromPortfolioPositionDtoToDomain(dto dto.PortfolioPosition) *domain.BrokerPosition {
+	return &domain.BrokerPosition{
+		Figi:                     dto.Figi,
+		Ticker:                   dto.Ticker,
+		InstrumentType:           dto.InstrumentType,
+		InstrumentUid:            dto.InstrumentUid,
+		PositionUid:              dto.PositionUid,
+		Quantity:                 dto.Quantity.ToFloat(),
+		QuantityLots:             dto.QuantityLots.ToFloat(),
+		AveragePositionPrice:     dto.AveragePositionPrice.ToFloat(),
+		AveragePositionPriceFifo: dto.AveragePositionPriceFifo.ToFloat(),
+		AveragePositionPricePt:   dto.AveragePositionPricePt.ToFloat(),
+		CurrentPrice:             dto.CurrentPrice.ToFloat(),
+		CurrentNkd:               dto.CurrentNkd.ToFloat(),
+		ExpectedYield:            dto.ExpectedYield.ToFloat(),
+		ExpectedYieldFifo:        dto.ExpectedYieldFifo.ToFloat(),
+		DailyYield:               dto.DailyYield.ToFloat(),
+		VarMargin:                dto.VarMargin.ToFloat(),
+		Blocked:                  dto.Blocked,
+		BlockedLots:              dto.BlockedLots.ToFloat(),
+		Currency:                 dto.CurrentPrice.Currency,
+	}
+}
+
+func FromPortfolioDtoToDomain(dto dto.PortfolioResponse) *domain.BrokerPortfolio {
+	positions := make([]*domain.BrokerPosition, len(dto.Positions))
+
+	for index, position := range dto.Positions {
+		positions[index] = FromPortfolioPositionDtoToDomain(position)
+	}
+
+	return &domain.BrokerPortfolio{
+		AccountID:            dto.AccountId,
+		Currency:             dto.TotalAmountPortfolio.Currency,
+		TotalAmountPortfolio: dto.TotalAmountPortfolio.ToFloat(),
+		ExpectedYield:        dto.ExpectedYield.ToFloat(),
+		DailyYield:           dto.DailyYield.ToFloat(),
+		Positions:            positions,
+	}
+}
@@ -0,0 +1,45 @@
+package domain
+
+// Брокерский счёт пользователя
+type BrokerAccount struct {
+	ID          string `json:"id"`
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Status      string `json:"status"`
+	OpenedDate  string `json:"openedDate"`
+	ClosedDate  string `json:"closedDate"`
+	AccessLevel string `json:"accessLevel"`
+}
+
+// Позиция брокерского счёта
+type BrokerPosition struct {
+	Figi                     string  `json:"figi"`
+	Ticker                   string  `json:"ticker"`
+	InstrumentType           string  `json:"instrumentType"`
+	InstrumentUid            string  `json:"instrumentUid"`
+	PositionUid              string  `json:"positionUid"`
+	Quantity                 float64 `json:"quantity"`
+	QuantityLots             float64 `json:"quantityLots"`
+	AveragePositionPrice     float64 `json:"averagePositionPrice"`
+	AveragePositionPriceFifo float64 `json:"averagePositionPriceFifo"`
+	AveragePositionPricePt   float64 `json:"averagePositionPricePt"`
+	CurrentPrice             float64 `json:"currentPrice"`
+	CurrentNkd               float64 `json:"currentNkd"`
+	ExpectedYield            float64 `json:"expectedYield"`
+	ExpectedYieldFifo        float64 `json:"expectedYieldFifo"`
+	DailyYield               float64 `json:"dailyYield"`
+	VarMargin                float64 `json:"varMargin"`
+	Blocked                  bool    `json:"blocked"`
+	BlockedLots              float64 `json:"blockedLots"`
+	Currency                 string  `json:"currency"`
+}
+
+// Портфель брокерского счёта
+type BrokerPortfolio struct {
+	AccountID            string            `json:"accountId"`
+	Currency             string            `json:"currency"`
+	TotalAmountPortfolio float64           `json:"totalAmountPortfolio"`
+	ExpectedYield        float64           `json:"expectedYield"`
+	DailyYield           float64           `json:"dailyYield"`
+	Positions            []*BrokerPosition `json:"positions"`
+}
@@ -0,0 +1,54 @@
+package dto
+
+import (
+	assetsDto "invest-mate/internal/assets/models/dto"
+)
+
+type Account struct {
+	Id          string `json:"id"`
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Status      string `json:"status"`
+	OpenedDate  string `json:"openedDate"`
+	ClosedDate  string `json:"closedDate"`
+	AccessLevel string `json:"accessLevel"`
+}
+
+type GetAccountsResponse struct {
+	Accounts []Account `json:"accounts"`
+}
+
+type PortfolioPosition struct {
+	Figi                     string               `json:"figi"`
+	InstrumentType           string               `json:"instrumentType"`
+	Quantity                 assetsDto.Quotation  `json:"quantity"`
+	AveragePositionPrice     assetsDto.MoneyValue `json:"averagePositionPrice"`
+	ExpectedYield            assetsDto.Quotation  `json:"expectedYield"`
+	CurrentNkd               assetsDto.MoneyValue `json:"currentNkd"`
+	AveragePositionPricePt   assetsDto.Quotation  `json:"averagePositionPricePt"`
+	CurrentPrice             assetsDto.MoneyValue `json:"currentPrice"`
+	AveragePositionPriceFifo assetsDto.MoneyValue `json:"averagePositionPriceFifo"`
+	QuantityLots             assetsDto.Quotation  `json:"quantityLots"`
+	Blocked                  bool                 `json:"blocked"`
+	BlockedLots              assetsDto.Quotation  `json:"blockedLots"`
+	PositionUid              string               `json:"positionUid"`
+	InstrumentUid            string               `json:"instrumentUid"`
+	VarMargin                assetsDto.MoneyValue `json:"varMargin"`
+	ExpectedYieldFifo        assetsDto.Quotation  `json:"expectedYieldFifo"`
+	DailyYield               assetsDto.MoneyValue `json:"dailyYield"`
+	Ticker                   string               `json:"ticker"`
+}
+
+type PortfolioResponse struct {
+	AccountId             string               `json:"accountId"`
+	TotalAmountShares     assetsDto.MoneyValue `json:"totalAmountShares"`
+	TotalAmountBonds      assetsDto.MoneyValue `json:"totalAmountBonds"`
+	TotalAmountEtf        assetsDto.MoneyValue `json:"totalAmountEtf"`
+	TotalAmountCurrencies assetsDto.MoneyValue `json:"totalAmountCurrencies"`
+	TotalAmountFutures    assetsDto.MoneyValue `json:"totalAmountFutures"`
+	TotalAmountPortfolio  assetsDto.MoneyValue `json:"totalAmountPortfolio"`
+	ExpectedYield         assetsDto.Quotation  `json:"expectedYield"`
+	DailyYield            assetsDto.MoneyValue `json:"dailyYield"`
+	DailyYieldRelative    assetsDto.Quotation  `json:"dailyYieldRelative"`
+	Positions             []PortfolioPosition  `json:"positions"`
+}
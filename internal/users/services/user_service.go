@@ -12,6 +12,7 @@ import (
 	"invest-mate/internal/users/models/domain"
 	"invest-mate/internal/users/repository"
 	"invest-mate/pkg/logger"
+	"invest-mate/pkg/services"
 )
 
 type UserService interface {
@@ -139,22 +140,7 @@ func (s *userService) UpdateUser(ctx context.Context, id string, updates *domain
 
 // Получение списка пользователей
 func (s *userService) GetListUsers(ctx context.Context, page, limit int) ([]*domain.UserResponse, int64, error) {
-	if page < 1 {
-		page = 1
-	}
-
-	if limit < 0 {
-		limit = 0
-	}
-
-	if limit > 100 {
-		limit = 100
-	}
-
-	offset := 0
-	if limit > 0 {
-		offset = (page - 1) * limit
-	}
+	limit, offset := services.PageOffset(page, limit)
 
 	users, err := s.userRepo.GetList(ctx, limit, offset)
 	if err != nil {
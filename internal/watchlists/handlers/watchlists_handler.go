@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"invest-mate/internal/watchlists/models"
+	"invest-mate/internal/watchlists/models/domain"
+	"invest-mate/internal/watchlists/services"
+	"invest-mate/pkg/handlers"
+	middleware "invest-mate/pkg/middlewares"
+)
+
+type WatchlistsHandler struct {
+	watchlistsService services.WatchlistsService
+}
+
+// Создание нового хендлера
+func NewWatchlistsHandler(watchlistsService services.WatchlistsService) *WatchlistsHandler {
+	return &WatchlistsHandler{
+		watchlistsService: watchlistsService,
+	}
+}
+
+// Регистрация маршрутов
+func (h *WatchlistsHandler) RegisterRoutes(router *gin.RouterGroup) {
+	watchlists := router.Group("/watchlists")
+	watchlists.Use(middleware.AuthMiddleware())
+	{
+		watchlists.GET("", h.GetWatchlists)
+		watchlists.POST("", h.CreateWatchlist)
+		watchlists.PUT("/order", h.ReorderWatchlists)
+		watchlists.GET("/:id", h.GetWatchlist)
+		watchlists.PUT("/:id", h.UpdateWatchlist)
+		watchlists.DELETE("/:id", h.DeleteWatchlist)
+
+		watchlists.POST("/:id/items", h.AddItem)
+		watchlists.PUT("/:id/items/order", h.ReorderItems)
+		watchlists.DELETE("/:id/items/:itemId", h.DeleteItem)
+	}
+}
+
+// Обработчик получения списков пользователя
+func (h *WatchlistsHandler) GetWatchlists(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	watchlists, err := h.watchlistsService.GetWatchlists(c.Request.Context(), userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(watchlists))
+}
+
+// Обработчик создания списка
+func (h *WatchlistsHandler) CreateWatchlist(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.CreateWatchlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	watchlist, err := h.watchlistsService.CreateWatchlist(c.Request.Context(), userID, &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, handlers.BuildResponse(watchlist))
+}
+
+// Обработчик изменения порядка списков
+func (h *WatchlistsHandler) ReorderWatchlists(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.ReorderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	watchlists, err := h.watchlistsService.ReorderWatchlists(c.Request.Context(), userID, req.IDs)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(watchlists))
+}
+
+// Обработчик получения списка с инструментами и последними ценами
+func (h *WatchlistsHandler) GetWatchlist(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	watchlist, err := h.watchlistsService.GetWatchlist(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(watchlist))
+}
+
+// Обработчик переименования списка
+func (h *WatchlistsHandler) UpdateWatchlist(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.UpdateWatchlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	watchlist, err := h.watchlistsService.UpdateWatchlist(c.Request.Context(), userID, c.Param("id"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(watchlist))
+}
+
+// Обработчик удаления списка
+func (h *WatchlistsHandler) DeleteWatchlist(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.watchlistsService.DeleteWatchlist(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(result))
+}
+
+// Обработчик добавления инструмента в список
+func (h *WatchlistsHandler) AddItem(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.AddItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	item, err := h.watchlistsService.AddItem(c.Request.Context(), userID, c.Param("id"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, handlers.BuildResponse(item))
+}
+
+// Обработчик изменения порядка инструментов списка
+func (h *WatchlistsHandler) ReorderItems(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.ReorderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	watchlist, err := h.watchlistsService.ReorderItems(c.Request.Context(), userID, c.Param("id"), req.IDs)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(watchlist))
+}
+
+// Обработчик удаления инструмента из списка
+func (h *WatchlistsHandler) DeleteItem(c *gin.Context) {
+	userID, ok := handlers.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.watchlistsService.DeleteItem(c.Request.Context(), userID, c.Param("id"), c.Param("itemId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, handlers.BuildResponse(result))
+}
+
+// Формирование ответа с ошибкой
+func respondError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, models.ErrWatchlistNotFound),
+		errors.Is(err, models.ErrItemNotFound),
+		errors.Is(err, models.ErrInstrumentNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, models.ErrItemAlreadyExists):
+		status = http.StatusConflict
+	case errors.Is(err, models.ErrWatchlistAccessDenied),
+		errors.Is(err, models.ErrWatchlistLimitReached),
+		errors.Is(err, models.ErrItemLimitReached):
+		status = http.StatusForbidden
+	case errors.Is(err, models.ErrInvalidRequest):
+		status = http.StatusBadRequest
+	}
+
+	c.JSON(status, gin.H{"error": err.Error()})
+}
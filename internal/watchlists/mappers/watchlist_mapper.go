@@ -0,0 +1,68 @@
+package mappers
+
+import (
+	"invest-mate/internal/watchlists/models/domain"
+	"invest-mate/internal/watchlists/models/entity"
+)
+
+func FromWatchlistEntityToDomain(entity entity.Watchlist) *domain.Watchlist {
+	return &domain.Watchlist{
+		ID:        entity.ID,
+		UserID:    entity.UserID,
+		Name:      entity.Name,
+		Position:  entity.Position,
+		CreatedAt: entity.CreatedAt,
+		UpdatedAt: entity.UpdatedAt,
+	}
+}
+
+func FromWatchlistEntityToDomainSlice(entitySlice []entity.Watchlist) []*domain.Watchlist {
+	domainSlice := make([]*domain.Watchlist, len(entitySlice))
+
+	for index, entity := range entitySlice {
+		domainSlice[index] = FromWatchlistEntityToDomain(entity)
+	}
+
+	return domainSlice
+}
+
+func FromWatchlistDomainToEntity(domain *domain.Watchlist) entity.Watchlist {
+	return entity.Watchlist{
+		ID:        domain.ID,
+		UserID:    domain.UserID,
+		Name:      domain.Name,
+		Position:  domain.Position,
+		CreatedAt: domain.CreatedAt,
+		UpdatedAt: domain.UpdatedAt,
+	}
+}
+
+func FromItemEntityToDomain(entity entity.WatchlistItem) *domain.WatchlistItem {
+	return &domain.WatchlistItem{
+		ID:            entity.ID,
+		WatchlistID:   entity.WatchlistID,
+		InstrumentUid: entity.InstrumentUid,
+		Position:      entity.Position,
+		AddedAt:       entity.AddedAt,
+	}
+}
+
+func FromItemEntityToDomainSlice(entitySlice []entity.WatchlistItem) []*domain.WatchlistItem {
+	domainSlice := make([]*domain.WatchlistItem, len(entitySlice))
+
+	for index, entity := range entitySlice {
+		domainSlice[index] = FromItemEntityToDomain(entity)
+	}
+
+	return domainSlice
+}
+
+func FromItemDomainToEntity(domain *domain.WatchlistItem) entity.WatchlistItem {
+	return entity.WatchlistItem{
+		ID:            domain.ID,
+		WatchlistID:   domain.WatchlistID,
+		InstrumentUid: domain.InstrumentUid,
+		Position:      domain.Position,
+		AddedAt:       domain.AddedAt,
+	}
+}
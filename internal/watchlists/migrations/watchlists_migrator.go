@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+
+	"invest-mate/internal/watchlists/models/entity"
+)
+
+type WatchlistsMigrator struct{}
+
+func NewWatchlistsMigrator() *WatchlistsMigrator {
+	return &WatchlistsMigrator{}
+}
+
+func (m *WatchlistsMigrator) Migrate(db *gorm.DB) error {
+	return db.AutoMigrate(
+		&entity.Watchlist{},
+		&entity.WatchlistItem{},
+	)
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"time"
+
+	assetsDomain "invest-mate/internal/assets/models/domain"
+)
+
+// Именованный список отслеживаемых инструментов пользователя
+type Watchlist struct {
+	ID         string           `json:"id"`
+	UserID     string           `json:"userId"`
+	Name       string           `json:"name"`
+	Position   int              `json:"position"`
+	ItemsCount int64            `json:"itemsCount"`
+	Items      []*WatchlistItem `json:"items,omitempty"`
+	CreatedAt  time.Time        `json:"createdAt"`
+	UpdatedAt  time.Time        `json:"updatedAt"`
+}
+
+// Инструмент в списке отслеживания
+type WatchlistItem struct {
+	ID            string                   `json:"id"`
+	WatchlistID   string                   `json:"watchlistId"`
+	InstrumentUid string                   `json:"instrumentUid"`
+	Position      int                      `json:"position"`
+	Instrument    *assetsDomain.Instrument `json:"instrument,omitempty"`
+	// Последняя цена из кэша (для облигаций — в % номинала)
+	LastPrice *float64  `json:"lastPrice,omitempty"`
+	AddedAt   time.Time `json:"addedAt"`
+}
+
+type CreateWatchlistRequest struct {
+	Name string `json:"name"`
+}
+
+type UpdateWatchlistRequest struct {
+	Name string `json:"name"`
+}
+
+// Новый порядок — все идентификаторы списков или инструментов списка
+type ReorderRequest struct {
+	IDs []string `json:"ids"`
+}
+
+type AddItemRequest struct {
+	InstrumentUid string `json:"instrumentUid"`
+	Figi          string `json:"figi"`
+	Ticker        string `json:"ticker"`
+}
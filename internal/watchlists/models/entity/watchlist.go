@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+)
+
+type Watchlist struct {
+	ID        string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	UserID    string    `gorm:"not null;index"`
+	Name      string    `gorm:"size:100;not null"`
+	Position  int       `gorm:"not null;default:0"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+type WatchlistItem struct {
+	ID            string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	WatchlistID   string    `gorm:"type:uuid;not null;uniqueIndex:idx_watchlist_item_instrument"`
+	InstrumentUid string    `gorm:"type:text;not null;uniqueIndex:idx_watchlist_item_instrument"`
+	Position      int       `gorm:"not null;default:0"`
+	AddedAt       time.Time `gorm:"autoCreateTime"`
+}
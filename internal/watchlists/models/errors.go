@@ -0,0 +1,16 @@
+package models
+
+import (
+	"errors"
+)
+
+var (
+	ErrWatchlistNotFound     = errors.New("Список отслеживания не найден")
+	ErrWatchlistAccessDenied = errors.New("Нет доступа к списку отслеживания")
+	ErrWatchlistLimitReached = errors.New("Достигнуто наибольшее число списков отслеживания")
+	ErrItemNotFound          = errors.New("Инструмент не найден в списке отслеживания")
+	ErrItemAlreadyExists     = errors.New("Инструмент уже есть в списке отслеживания")
+	ErrItemLimitReached      = errors.New("Достигнуто наибольшее число инструментов в списке")
+	ErrInstrumentNotFound    = errors.New("Инструмент не найден")
+	ErrInvalidRequest        = errors.New("Некорректный запрос")
+)
@@ -0,0 +1,35 @@
+package watchlists
+
+import (
+	"gorm.io/gorm"
+
+	assetsRepository "invest-mate/internal/assets/repository"
+	"invest-mate/internal/assets/storage"
+	"invest-mate/internal/shared/config"
+	"invest-mate/internal/watchlists/handlers"
+	"invest-mate/internal/watchlists/migrations"
+	"invest-mate/internal/watchlists/repository"
+	"invest-mate/internal/watchlists/services"
+)
+
+type Module struct {
+	watchlistsHandler *handlers.WatchlistsHandler
+}
+
+// Инициализация модуля
+func InitModule(db *gorm.DB, cfg *config.Config) (*Module, error) {
+	watchlistsMigrator := migrations.NewWatchlistsMigrator()
+	if err := watchlistsMigrator.Migrate(db); err != nil {
+		return nil, err
+	}
+
+	tinkoffStorage := storage.GetInstance(assetsRepository.NewAssetRepository(db))
+
+	watchlistsRepo := repository.NewWatchlistsRepository(db)
+	watchlistsService := services.NewWatchlistsService(watchlistsRepo, tinkoffStorage)
+	watchlistsHandler := handlers.NewWatchlistsHandler(watchlistsService)
+
+	return &Module{
+		watchlistsHandler: watchlistsHandler,
+	}, nil
+}
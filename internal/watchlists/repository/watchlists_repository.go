@@ -0,0 +1,276 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"invest-mate/internal/watchlists/mappers"
+	"invest-mate/internal/watchlists/models"
+	"invest-mate/internal/watchlists/models/domain"
+	"invest-mate/internal/watchlists/models/entity"
+)
+
+type WatchlistsRepository interface {
+	Create(ctx context.Context, watchlist *domain.Watchlist) error
+	FindByID(ctx context.Context, id string) (*domain.Watchlist, error)
+	GetListByUser(ctx context.Context, userID string) ([]*domain.Watchlist, error)
+	CountByUser(ctx context.Context, userID string) (int64, error)
+	Update(ctx context.Context, watchlist *domain.Watchlist) error
+	Delete(ctx context.Context, id string) (bool, error)
+	UpdatePositions(ctx context.Context, ids []string) error
+
+	GetItems(ctx context.Context, watchlistID string) ([]*domain.WatchlistItem, error)
+	CountItems(ctx context.Context, watchlistIDs []string) (map[string]int64, error)
+	FindItemByInstrument(ctx context.Context, watchlistID, instrumentUid string) (*domain.WatchlistItem, error)
+	AddItem(ctx context.Context, item *domain.WatchlistItem) error
+	DeleteItem(ctx context.Context, watchlistID, itemID string) (bool, error)
+	UpdateItemPositions(ctx context.Context, watchlistID string, ids []string) error
+}
+
+type watchlistsRepository struct {
+	db *gorm.DB
+}
+
+// Создание нового репозитория списков отслеживания
+func NewWatchlistsRepository(db *gorm.DB) WatchlistsRepository {
+	return &watchlistsRepository{db: db}
+}
+
+// Создание списка в конце порядка списков пользователя в БД
+func (r *watchlistsRepository) Create(ctx context.Context, watchlist *domain.Watchlist) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var maxPosition *int
+
+		if err := tx.Model(&entity.Watchlist{}).
+			Where("user_id = ?", watchlist.UserID).
+			Select("MAX(position)").
+			Scan(&maxPosition).Error; err != nil {
+			return err
+		}
+
+		watchlist.Position = 0
+		if maxPosition != nil {
+			watchlist.Position = *maxPosition + 1
+		}
+
+		entityWatchlist := mappers.FromWatchlistDomainToEntity(watchlist)
+
+		if err := tx.Create(&entityWatchlist).Error; err != nil {
+			return err
+		}
+
+		watchlist.ID = entityWatchlist.ID
+		watchlist.CreatedAt = entityWatchlist.CreatedAt
+		watchlist.UpdatedAt = entityWatchlist.UpdatedAt
+
+		return nil
+	})
+}
+
+// Найти список по идентификатору в БД
+func (r *watchlistsRepository) FindByID(ctx context.Context, id string) (*domain.Watchlist, error) {
+	var entityWatchlist entity.Watchlist
+
+	err := r.db.WithContext(ctx).First(&entityWatchlist, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.ErrWatchlistNotFound
+		}
+		return nil, err
+	}
+
+	return mappers.FromWatchlistEntityToDomain(entityWatchlist), nil
+}
+
+// Получить списки пользователя по порядку из БД
+func (r *watchlistsRepository) GetListByUser(ctx context.Context, userID string) ([]*domain.Watchlist, error) {
+	var entityWatchlists []entity.Watchlist
+
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("position, created_at").
+		Find(&entityWatchlists).Error; err != nil {
+		return nil, err
+	}
+
+	return mappers.FromWatchlistEntityToDomainSlice(entityWatchlists), nil
+}
+
+// Подсчёт списков пользователя в БД
+func (r *watchlistsRepository) CountByUser(ctx context.Context, userID string) (int64, error) {
+	var count int64
+
+	if err := r.db.WithContext(ctx).Model(&entity.Watchlist{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// Обновить список в БД
+func (r *watchlistsRepository) Update(ctx context.Context, watchlist *domain.Watchlist) error {
+	entityWatchlist := mappers.FromWatchlistDomainToEntity(watchlist)
+
+	if err := r.db.WithContext(ctx).Save(&entityWatchlist).Error; err != nil {
+		return err
+	}
+
+	watchlist.UpdatedAt = entityWatchlist.UpdatedAt
+
+	return nil
+}
+
+// Удаление списка вместе с инструментами из БД
+func (r *watchlistsRepository) Delete(ctx context.Context, id string) (bool, error) {
+	var deleted bool
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&entity.WatchlistItem{}, "watchlist_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		result := tx.Delete(&entity.Watchlist{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		deleted = result.RowsAffected > 0
+
+		return nil
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return deleted, nil
+}
+
+// Сохранение порядка списков (позиция — индекс в ids) в БД
+func (r *watchlistsRepository) UpdatePositions(ctx context.Context, ids []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for position, id := range ids {
+			if err := tx.Model(&entity.Watchlist{}).Where("id = ?", id).Update("position", position).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Получить инструменты списка по порядку из БД
+func (r *watchlistsRepository) GetItems(ctx context.Context, watchlistID string) ([]*domain.WatchlistItem, error) {
+	var entityItems []entity.WatchlistItem
+
+	if err := r.db.WithContext(ctx).
+		Where("watchlist_id = ?", watchlistID).
+		Order("position, added_at").
+		Find(&entityItems).Error; err != nil {
+		return nil, err
+	}
+
+	return mappers.FromItemEntityToDomainSlice(entityItems), nil
+}
+
+// Подсчёт инструментов в списках в БД
+func (r *watchlistsRepository) CountItems(ctx context.Context, watchlistIDs []string) (map[string]int64, error) {
+	counts := make(map[string]int64, len(watchlistIDs))
+
+	if len(watchlistIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		WatchlistID string
+		Count       int64
+	}
+
+	if err := r.db.WithContext(ctx).Model(&entity.WatchlistItem{}).
+		Select("watchlist_id, COUNT(*) AS count").
+		Where("watchlist_id IN ?", watchlistIDs).
+		Group("watchlist_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.WatchlistID] = row.Count
+	}
+
+	return counts, nil
+}
+
+// Найти инструмент в списке в БД
+func (r *watchlistsRepository) FindItemByInstrument(ctx context.Context, watchlistID, instrumentUid string) (*domain.WatchlistItem, error) {
+	var entityItem entity.WatchlistItem
+
+	err := r.db.WithContext(ctx).
+		Where("watchlist_id = ? AND instrument_uid = ?", watchlistID, instrumentUid).
+		First(&entityItem).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.ErrItemNotFound
+		}
+		return nil, err
+	}
+
+	return mappers.FromItemEntityToDomain(entityItem), nil
+}
+
+// Добавление инструмента в конец списка в БД
+func (r *watchlistsRepository) AddItem(ctx context.Context, item *domain.WatchlistItem) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var maxPosition *int
+
+		if err := tx.Model(&entity.WatchlistItem{}).
+			Where("watchlist_id = ?", item.WatchlistID).
+			Select("MAX(position)").
+			Scan(&maxPosition).Error; err != nil {
+			return err
+		}
+
+		item.Position = 0
+		if maxPosition != nil {
+			item.Position = *maxPosition + 1
+		}
+
+		entityItem := mappers.FromItemDomainToEntity(item)
+
+		if err := tx.Create(&entityItem).Error; err != nil {
+			return err
+		}
+
+		item.ID = entityItem.ID
+		item.AddedAt = entityItem.AddedAt
+
+		return nil
+	})
+}
+
+// Удаление инструмента из списка в БД
+func (r *watchlistsRepository) DeleteItem(ctx context.Context, watchlistID, itemID string) (bool, error) {
+	result := r.db.WithContext(ctx).Delete(&entity.WatchlistItem{}, "id = ? AND watchlist_id = ?", itemID, watchlistID)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// Сохранение порядка инструментов списка (позиция — индекс в ids) в БД
+func (r *watchlistsRepository) UpdateItemPositions(ctx context.Context, watchlistID string, ids []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for position, id := range ids {
+			if err := tx.Model(&entity.WatchlistItem{}).
+				Where("id = ? AND watchlist_id = ?", id, watchlistID).
+				Update("position", position).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
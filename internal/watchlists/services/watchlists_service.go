@@ -0,0 +1,396 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	assetsDomain "invest-mate/internal/assets/models/domain"
+	sharedInstruments "invest-mate/internal/shared/instruments"
+	"invest-mate/internal/watchlists/models"
+	"invest-mate/internal/watchlists/models/domain"
+	"invest-mate/internal/watchlists/repository"
+	"invest-mate/pkg/logger"
+)
+
+const (
+	maxWatchlistsPerUser   = 20
+	maxItemsPerWatchlist   = 200
+	maxWatchlistNameLength = 100
+)
+
+// Инструменты и последние цены (реализуется хранилищем модуля активов)
+type InstrumentStorage interface {
+	FindInstrument(ctx context.Context, fieldName string, fieldValue string) (*assetsDomain.Instrument, error)
+	GetInstrumentsByUids(ctx context.Context, uids []string) (map[string]assetsDomain.Instrument, error)
+	GetLastPrices(ctx context.Context, uids []string) (map[string]float64, error)
+}
+
+type WatchlistsService interface {
+	GetWatchlists(ctx context.Context, userID string) ([]*domain.Watchlist, error)
+	CreateWatchlist(ctx context.Context, userID string, req *domain.CreateWatchlistRequest) (*domain.Watchlist, error)
+	GetWatchlist(ctx context.Context, userID, id string) (*domain.Watchlist, error)
+	UpdateWatchlist(ctx context.Context, userID, id string, req *domain.UpdateWatchlistRequest) (*domain.Watchlist, error)
+	DeleteWatchlist(ctx context.Context, userID, id string) (bool, error)
+	ReorderWatchlists(ctx context.Context, userID string, ids []string) ([]*domain.Watchlist, error)
+
+	AddItem(ctx context.Context, userID, id string, req *domain.AddItemRequest) (*domain.WatchlistItem, error)
+	DeleteItem(ctx context.Context, userID, id, itemID string) (bool, error)
+	ReorderItems(ctx context.Context, userID, id string, ids []string) (*domain.Watchlist, error)
+}
+
+type watchlistsService struct {
+	watchlistsRepo repository.WatchlistsRepository
+	instruments    InstrumentStorage
+}
+
+// Создание нового сервиса списков отслеживания
+func NewWatchlistsService(watchlistsRepo repository.WatchlistsRepository, instruments InstrumentStorage) WatchlistsService {
+	return &watchlistsService{
+		watchlistsRepo: watchlistsRepo,
+		instruments:    instruments,
+	}
+}
+
+// Получение списков пользователя по порядку с числом инструментов
+func (s *watchlistsService) GetWatchlists(ctx context.Context, userID string) ([]*domain.Watchlist, error) {
+	watchlists, err := s.watchlistsRepo.GetListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(watchlists))
+	for index, watchlist := range watchlists {
+		ids[index] = watchlist.ID
+	}
+
+	counts, err := s.watchlistsRepo.CountItems(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, watchlist := range watchlists {
+		watchlist.ItemsCount = counts[watchlist.ID]
+	}
+
+	return watchlists, nil
+}
+
+// Создание списка (добавляется в конец)
+func (s *watchlistsService) CreateWatchlist(ctx context.Context, userID string, req *domain.CreateWatchlistRequest) (*domain.Watchlist, error) {
+	name, err := validateName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.watchlistsRepo.CountByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if count >= maxWatchlistsPerUser {
+		return nil, fmt.Errorf("%w: %d", models.ErrWatchlistLimitReached, maxWatchlistsPerUser)
+	}
+
+	watchlist := &domain.Watchlist{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Name:      name,
+		Items:     []*domain.WatchlistItem{},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := s.watchlistsRepo.Create(ctx, watchlist); err != nil {
+		return nil, err
+	}
+
+	logger.InfoLog("Watchlist created: %s (user %s)", watchlist.ID, userID)
+
+	return watchlist, nil
+}
+
+// Получение списка с инструментами и последними ценами
+func (s *watchlistsService) GetWatchlist(ctx context.Context, userID, id string) (*domain.Watchlist, error) {
+	watchlist, err := s.getOwnedWatchlist(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.watchlistsRepo.GetItems(ctx, watchlist.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.resolveItems(ctx, items); err != nil {
+		return nil, err
+	}
+
+	watchlist.Items = items
+	watchlist.ItemsCount = int64(len(items))
+
+	return watchlist, nil
+}
+
+// Переименование списка
+func (s *watchlistsService) UpdateWatchlist(ctx context.Context, userID, id string, req *domain.UpdateWatchlistRequest) (*domain.Watchlist, error) {
+	name, err := validateName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	watchlist, err := s.getOwnedWatchlist(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	watchlist.Name = name
+	watchlist.UpdatedAt = time.Now()
+
+	if err := s.watchlistsRepo.Update(ctx, watchlist); err != nil {
+		return nil, err
+	}
+
+	return watchlist, nil
+}
+
+// Удаление списка вместе с инструментами
+func (s *watchlistsService) DeleteWatchlist(ctx context.Context, userID, id string) (bool, error) {
+	if _, err := s.getOwnedWatchlist(ctx, userID, id); err != nil {
+		return false, err
+	}
+
+	deleted, err := s.watchlistsRepo.Delete(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	if deleted {
+		logger.InfoLog("Watchlist deleted: %s (user %s)", id, userID)
+	}
+
+	return deleted, nil
+}
+
+// Изменение порядка списков (ids — все списки пользователя в новом порядке)
+func (s *watchlistsService) ReorderWatchlists(ctx context.Context, userID string, ids []string) ([]*domain.Watchlist, error) {
+	watchlists, err := s.watchlistsRepo.GetListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	current := make([]string, len(watchlists))
+	for index, watchlist := range watchlists {
+		current[index] = watchlist.ID
+	}
+
+	if err := validateOrder(ids, current); err != nil {
+		return nil, err
+	}
+
+	if err := s.watchlistsRepo.UpdatePositions(ctx, ids); err != nil {
+		return nil, err
+	}
+
+	return s.GetWatchlists(ctx, userID)
+}
+
+// Добавление инструмента в конец списка
+func (s *watchlistsService) AddItem(ctx context.Context, userID, id string, req *domain.AddItemRequest) (*domain.WatchlistItem, error) {
+	watchlist, err := s.getOwnedWatchlist(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	instrument, err := sharedInstruments.Resolve(ctx, s.instruments, req.InstrumentUid, req.Figi, req.Ticker, models.ErrInvalidRequest, models.ErrInstrumentNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.watchlistsRepo.FindItemByInstrument(ctx, watchlist.ID, instrument.Uid)
+	if err == nil {
+		return nil, models.ErrItemAlreadyExists
+	}
+	if !errors.Is(err, models.ErrItemNotFound) {
+		return nil, err
+	}
+
+	counts, err := s.watchlistsRepo.CountItems(ctx, []string{watchlist.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if counts[watchlist.ID] >= maxItemsPerWatchlist {
+		return nil, fmt.Errorf("%w: %d", models.ErrItemLimitReached, maxItemsPerWatchlist)
+	}
+
+	item := &domain.WatchlistItem{
+		ID:            uuid.New().String(),
+		WatchlistID:   watchlist.ID,
+		InstrumentUid: instrument.Uid,
+		AddedAt:       time.Now(),
+	}
+
+	if err := s.watchlistsRepo.AddItem(ctx, item); err != nil {
+		return nil, err
+	}
+
+	item.Instrument = instrument
+	if prices, err := s.instruments.GetLastPrices(ctx, []string{instrument.Uid}); err == nil {
+		if price, ok := prices[instrument.Uid]; ok {
+			item.LastPrice = &price
+		}
+	}
+
+	return item, nil
+}
+
+// Удаление инструмента из списка
+func (s *watchlistsService) DeleteItem(ctx context.Context, userID, id, itemID string) (bool, error) {
+	if _, err := s.getOwnedWatchlist(ctx, userID, id); err != nil {
+		return false, err
+	}
+
+	if _, err := uuid.Parse(itemID); err != nil {
+		return false, models.ErrItemNotFound
+	}
+
+	deleted, err := s.watchlistsRepo.DeleteItem(ctx, id, itemID)
+	if err != nil {
+		return false, err
+	}
+
+	if !deleted {
+		return false, models.ErrItemNotFound
+	}
+
+	return true, nil
+}
+
+// Изменение порядка инструментов (ids — все инструменты списка в новом порядке)
+func (s *watchlistsService) ReorderItems(ctx context.Context, userID, id string, ids []string) (*domain.Watchlist, error) {
+	watchlist, err := s.getOwnedWatchlist(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.watchlistsRepo.GetItems(ctx, watchlist.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	current := make([]string, len(items))
+	for index, item := range items {
+		current[index] = item.ID
+	}
+
+	if err := validateOrder(ids, current); err != nil {
+		return nil, err
+	}
+
+	if err := s.watchlistsRepo.UpdateItemPositions(ctx, watchlist.ID, ids); err != nil {
+		return nil, err
+	}
+
+	return s.GetWatchlist(ctx, userID, id)
+}
+
+// Заполнение инструментов и последних цен одним запросом к хранилищу.
+// Инструменты, которых больше нет в справочнике, возвращаются без сведений
+func (s *watchlistsService) resolveItems(ctx context.Context, items []*domain.WatchlistItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	uids := make([]string, len(items))
+	for index, item := range items {
+		uids[index] = item.InstrumentUid
+	}
+
+	instruments, err := s.instruments.GetInstrumentsByUids(ctx, uids)
+	if err != nil {
+		return err
+	}
+
+	prices, err := s.instruments.GetLastPrices(ctx, uids)
+	if err != nil {
+		// Список отдаётся и без цен, если API недоступно
+		logger.ErrorLog("Failed to load last prices for watchlist: %v", err)
+		prices = map[string]float64{}
+	}
+
+	for _, item := range items {
+		if instrument, ok := instruments[item.InstrumentUid]; ok {
+			item.Instrument = &instrument
+		}
+
+		if price, ok := prices[item.InstrumentUid]; ok {
+			item.LastPrice = &price
+		}
+	}
+
+	return nil
+}
+
+// Получение списка с проверкой владельца
+func (s *watchlistsService) getOwnedWatchlist(ctx context.Context, userID, id string) (*domain.Watchlist, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, models.ErrWatchlistNotFound
+	}
+
+	watchlist, err := s.watchlistsRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if watchlist.UserID != userID {
+		return nil, models.ErrWatchlistAccessDenied
+	}
+
+	return watchlist, nil
+}
+
+// Проверка названия списка
+func validateName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+
+	if name == "" {
+		return "", fmt.Errorf("%w: name is required", models.ErrInvalidRequest)
+	}
+
+	if utf8.RuneCountInString(name) > maxWatchlistNameLength {
+		return "", fmt.Errorf("%w: name must be at most %d characters", models.ErrInvalidRequest, maxWatchlistNameLength)
+	}
+
+	return name, nil
+}
+
+// Новый порядок должен содержать каждый текущий идентификатор ровно один раз
+func validateOrder(ids, current []string) error {
+	if len(ids) != len(current) {
+		return fmt.Errorf("%w: ids must list all %d elements", models.ErrInvalidRequest, len(current))
+	}
+
+	known := make(map[string]bool, len(current))
+	for _, id := range current {
+		known[id] = false
+	}
+
+	for _, id := range ids {
+		seen, ok := known[id]
+		if !ok {
+			return fmt.Errorf("%w: unknown id %s", models.ErrInvalidRequest, id)
+		}
+		if seen {
+			return fmt.Errorf("%w: duplicate id %s", models.ErrInvalidRequest, id)
+		}
+		known[id] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package finance
+
+import (
+	"math"
+	"time"
+)
+
+// Дюрация Маколея и модифицированная дюрация (в годах) и выпуклость потоков
+// при эффективной годовой ставке rate (в долях)
+func Duration(flows []Flow, settlement time.Time, rate float64) (macaulay, modified, convexity float64, ok bool) {
+	if rate <= -1 {
+		return 0, 0, 0, false
+	}
+
+	var price, weighted, curvature float64
+
+	for _, flow := range flows {
+		years := YearsBetween(settlement, flow.Date)
+		if years < 0 {
+			continue
+		}
+
+		value := flow.Amount / math.Pow(1+rate, years)
+
+		price += value
+		weighted += years * value
+		curvature += years * (years + 1) * value
+	}
+
+	if price <= 0 {
+		return 0, 0, 0, false
+	}
+
+	macaulay = weighted / price
+	modified = macaulay / (1 + rate)
+	convexity = curvature / (price * (1 + rate) * (1 + rate))
+
+	return macaulay, modified, convexity, true
+}
@@ -0,0 +1,101 @@
+package finance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDuration(t *testing.T) {
+	settlement := day(2025, 1, 1)
+
+	// Купон 50 через год и 1050 через два года при ставке 5%: цена 1000
+	coupon := 50 / 1.05
+	redemption := 1050 / (1.05 * 1.05)
+
+	tests := []struct {
+		name      string
+		flows     []Flow
+		rate      float64
+		macaulay  float64
+		modified  float64
+		convexity float64
+	}{
+		{
+			name:      "zero coupon equals its term",
+			flows:     []Flow{{day(2027, 1, 1), 1000}},
+			rate:      0.05,
+			macaulay:  2,
+			modified:  2 / 1.05,
+			convexity: 2 * 3 / (1.05 * 1.05),
+		},
+		{
+			name:      "coupon bond",
+			flows:     []Flow{{day(2026, 1, 1), 50}, {day(2027, 1, 1), 1050}},
+			rate:      0.05,
+			macaulay:  (coupon + 2*redemption) / 1000,
+			modified:  (coupon + 2*redemption) / 1000 / 1.05,
+			convexity: (1*2*coupon + 2*3*redemption) / (1000 * 1.05 * 1.05),
+		},
+		{
+			// Прошедшие потоки не учитываются
+			name:      "flows before settlement are skipped",
+			flows:     []Flow{{day(2024, 1, 1), 50}, {day(2026, 1, 1), 50}, {day(2027, 1, 1), 1050}},
+			rate:      0.05,
+			macaulay:  (coupon + 2*redemption) / 1000,
+			modified:  (coupon + 2*redemption) / 1000 / 1.05,
+			convexity: (1*2*coupon + 2*3*redemption) / (1000 * 1.05 * 1.05),
+		},
+		{
+			name:      "zero rate",
+			flows:     []Flow{{day(2026, 1, 1), 100}, {day(2027, 1, 1), 100}},
+			rate:      0,
+			macaulay:  1.5,
+			modified:  1.5,
+			convexity: (1*2*100 + 2*3*100) / 200.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			macaulay, modified, convexity, ok := Duration(tt.flows, settlement, tt.rate)
+			if !ok {
+				t.Fatal("Duration is not calculated")
+			}
+
+			for _, value := range []struct {
+				name      string
+				got, want float64
+			}{
+				{"macaulay", macaulay, tt.macaulay},
+				{"modified", modified, tt.modified},
+				{"convexity", convexity, tt.convexity},
+			} {
+				if math.Abs(value.got-value.want) > 1e-9 {
+					t.Errorf("%s = %v, want %v", value.name, value.got, value.want)
+				}
+			}
+		})
+	}
+}
+
+func TestDurationNotCalculated(t *testing.T) {
+	settlement := day(2025, 1, 1)
+
+	tests := []struct {
+		name  string
+		flows []Flow
+		rate  float64
+	}{
+		{"no flows", nil, 0.05},
+		{"only past flows", []Flow{{day(2024, 1, 1), 1000}}, 0.05},
+		{"rate at minus one hundred percent", []Flow{{day(2026, 1, 1), 1000}}, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, _, ok := Duration(tt.flows, settlement, tt.rate); ok {
+				t.Error("Duration is calculated, want no result")
+			}
+		})
+	}
+}
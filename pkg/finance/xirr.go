@@ -0,0 +1,114 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+const (
+	DaysInYear = 365.0
+
+	xirrMaxIterations = 100
+	xirrTolerance     = 1e-9
+	xirrLowerBound    = -0.999999
+	xirrUpperBound    = 1e6
+)
+
+var ErrNotConverged = errors.New("xirr did not converge")
+
+// Денежный поток на дату: вложение отрицательное, поступление положительное
+type Flow struct {
+	Date   time.Time
+	Amount float64
+}
+
+// Срок в годах между датами
+func YearsBetween(from, to time.Time) float64 {
+	return to.Sub(from).Hours() / 24 / DaysInYear
+}
+
+// Внутренняя норма доходности нерегулярных потоков (XIRR), в долях за год
+func Xirr(flows []Flow) (float64, error) {
+	if len(flows) < 2 {
+		return 0, ErrNotConverged
+	}
+
+	hasPositive, hasNegative := false, false
+	for _, flow := range flows {
+		hasPositive = hasPositive || flow.Amount > 0
+		hasNegative = hasNegative || flow.Amount < 0
+	}
+
+	if !hasPositive || !hasNegative {
+		return 0, ErrNotConverged
+	}
+
+	start := flows[0].Date
+	for _, flow := range flows {
+		if flow.Date.Before(start) {
+			start = flow.Date
+		}
+	}
+
+	npv := func(rate float64) (float64, float64) {
+		var value, derivative float64
+
+		for _, flow := range flows {
+			years := YearsBetween(start, flow.Date)
+			discount := math.Pow(1+rate, years)
+
+			value += flow.Amount / discount
+			derivative -= years * flow.Amount / (discount * (1 + rate))
+		}
+
+		return value, derivative
+	}
+
+	// Метод Ньютона с переходом на деление отрезка при расхождении
+	rate := 0.1
+	for i := 0; i < xirrMaxIterations; i++ {
+		value, derivative := npv(rate)
+		if math.Abs(value) < xirrTolerance {
+			return rate, nil
+		}
+		if derivative == 0 {
+			break
+		}
+
+		next := rate - value/derivative
+		if math.IsNaN(next) || math.IsInf(next, 0) || next <= xirrLowerBound || next >= xirrUpperBound {
+			break
+		}
+		if math.Abs(next-rate) < xirrTolerance {
+			return next, nil
+		}
+
+		rate = next
+	}
+
+	low, high := xirrLowerBound, xirrUpperBound
+	lowValue, _ := npv(low)
+	highValue, _ := npv(high)
+
+	if lowValue*highValue > 0 {
+		return 0, ErrNotConverged
+	}
+
+	for i := 0; i < 10*xirrMaxIterations; i++ {
+		middle := (low + high) / 2
+		value, _ := npv(middle)
+
+		if math.Abs(value) < xirrTolerance || (high-low)/2 < xirrTolerance {
+			return middle, nil
+		}
+
+		if value*lowValue < 0 {
+			high = middle
+		} else {
+			low, lowValue = middle, value
+		}
+	}
+
+	return 0, ErrNotConverged
+}
@@ -0,0 +1,105 @@
+package finance
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func day(year int, month time.Month, date int) time.Time {
+	return time.Date(year, month, date, 0, 0, 0, 0, time.UTC)
+}
+
+func TestXirr(t *testing.T) {
+	tests := []struct {
+		name      string
+		flows     []Flow
+		want      float64
+		tolerance float64
+	}{
+		{
+			name:      "one year at ten percent",
+			flows:     []Flow{{day(2023, 1, 1), -1000}, {day(2024, 1, 1), 1100}},
+			want:      0.1,
+			tolerance: 1e-9,
+		},
+		{
+			// 1000 * 1.1^2 за 730 дней
+			name:      "two years compounded",
+			flows:     []Flow{{day(2021, 1, 1), -1000}, {day(2023, 1, 1), 1210}},
+			want:      0.1,
+			tolerance: 1e-6,
+		},
+		{
+			// Пример из документации функции ЧИСТВНДОХ (XIRR) Excel
+			name: "irregular flows reference",
+			flows: []Flow{
+				{day(2008, 1, 1), -10000},
+				{day(2008, 3, 1), 2750},
+				{day(2008, 10, 30), 4250},
+				{day(2009, 2, 15), 3250},
+				{day(2009, 4, 1), 2750},
+			},
+			want:      0.373362535,
+			tolerance: 1e-6,
+		},
+		{
+			name:      "flows out of date order",
+			flows:     []Flow{{day(2024, 1, 1), 1100}, {day(2023, 1, 1), -1000}},
+			want:      0.1,
+			tolerance: 1e-9,
+		},
+		{
+			name:      "large gain",
+			flows:     []Flow{{day(2023, 1, 1), -1}, {day(2024, 1, 1), 1000}},
+			want:      999,
+			tolerance: 1e-6,
+		},
+		{
+			// Метод Ньютона из 10% уходит за нижнюю границу, корень находит деление отрезка
+			name:      "near total loss falls back to bisection",
+			flows:     []Flow{{day(2023, 1, 1), -1000}, {day(2024, 1, 1), 1}},
+			want:      -0.999,
+			tolerance: 1e-6,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Xirr(tt.flows)
+			if err != nil {
+				t.Fatalf("Xirr: %v", err)
+			}
+			if math.Abs(got-tt.want) > tt.tolerance {
+				t.Errorf("Xirr = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestXirrNoSolution(t *testing.T) {
+	tests := []struct {
+		name  string
+		flows []Flow
+	}{
+		{"no flows", nil},
+		{"single flow", []Flow{{day(2023, 1, 1), -1000}}},
+		{"only investments", []Flow{{day(2023, 1, 1), -1000}, {day(2024, 1, 1), -500}}},
+		{"only returns", []Flow{{day(2023, 1, 1), 1000}, {day(2024, 1, 1), 500}}},
+		{"zero flows", []Flow{{day(2023, 1, 1), 0}, {day(2024, 1, 1), 0}}},
+		{"rate beyond the search range", []Flow{{day(2023, 1, 1), -1000}, {day(2023, 1, 11), 3000}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Xirr(tt.flows)
+			if !errors.Is(err, ErrNotConverged) {
+				t.Errorf("Xirr = %v, %v; want ErrNotConverged", got, err)
+			}
+			if math.IsNaN(got) || math.IsInf(got, 0) {
+				t.Errorf("Xirr returned %v with the error", got)
+			}
+		})
+	}
+}
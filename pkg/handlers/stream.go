@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Интервал служебных событий, не дающих прокси закрыть соединение
+const streamHeartbeat = 15 * time.Second
+
+var (
+	streamsDone      = make(chan struct{})
+	closeStreamsOnce sync.Once
+)
+
+// Завершение всех открытых потоков (вызывается при остановке сервера,
+// иначе соединения потоков не дают серверу завершиться)
+func CloseStreams() {
+	closeStreamsOnce.Do(func() {
+		close(streamsDone)
+	})
+}
+
+// Отправка событий клиенту по Server-Sent Events до закрытия канала или отключения клиента.
+// Ограничение времени записи сервера для потока снимается
+func StreamEvents[T any](c *gin.Context, source <-chan T, write func(c *gin.Context, item T)) {
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case <-streamsDone:
+			return false
+		case item, ok := <-source:
+			if !ok {
+				return false
+			}
+			write(c, item)
+		case moment := <-heartbeat.C:
+			c.SSEvent("ping", moment.UTC())
+		}
+
+		return true
+	})
+}
@@ -14,7 +14,7 @@ func HandleListRequest[T any](getFunc func(ctx context.Context, page, limit int)
 	return func(c *gin.Context) {
 		ctx := c.Request.Context()
 
-		page, limit := parsePaginationParams(c)
+		page, limit := ParsePaginationParams(c)
 
 		data, total, err := getFunc(ctx, page, limit)
 		if err != nil {
@@ -70,7 +70,7 @@ func HandleByFieldRequest[T any](
 }
 
 // Функция для разбора параметров пагинации
-func parsePaginationParams(c *gin.Context) (page, limit int) {
+func ParsePaginationParams(c *gin.Context) (page, limit int) {
 	pageStr := c.Query("page")
 	limitStr := c.Query("limit")
 
@@ -0,0 +1,86 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"invest-mate/pkg/logger"
+)
+
+// Периодический запуск фоновой задачи: первый запуск — сразу после старта,
+// следующие — по тикеру. Ошибки задачи пишутся в лог и не останавливают запуск
+type Runner struct {
+	name     string
+	interval time.Duration
+	job      func(ctx context.Context) error
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// Создание запуска задачи с названием для логов и интервалом (0 — задача отключена)
+func NewRunner(name string, interval time.Duration, job func(ctx context.Context) error) *Runner {
+	return &Runner{
+		name:     name,
+		interval: interval,
+		job:      job,
+	}
+}
+
+// Запуск задачи в фоне
+func (r *Runner) Start() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.interval <= 0 || r.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			r.run(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	logger.InfoLog("%s scheduler started with interval %s", r.name, r.interval)
+}
+
+// Остановка с ожиданием текущего запуска задачи
+func (r *Runner) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cancel == nil {
+		return
+	}
+
+	r.cancel()
+	r.wg.Wait()
+	r.cancel = nil
+
+	logger.InfoLog("%s scheduler stopped", r.name)
+}
+
+// Один запуск задачи; ошибка из-за остановки не считается сбоем
+func (r *Runner) run(ctx context.Context) {
+	if err := r.job(ctx); err != nil && ctx.Err() == nil {
+		logger.ErrorLog("%s job failed: %v", r.name, err)
+	}
+}
@@ -49,3 +49,25 @@ func paginate[T any](items []T, page, limit int) ([]T, int64) {
 
 	return items[start:end], int64(len(items))
 }
+
+// Размер страницы (не больше 100, 0 — без ограничения) и смещение для запроса к БД
+func PageOffset(page, limit int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+
+	if limit < 0 {
+		limit = 0
+	}
+
+	if limit > 100 {
+		limit = 100
+	}
+
+	offset := 0
+	if limit > 0 {
+		offset = (page - 1) * limit
+	}
+
+	return limit, offset
+}